@@ -1,64 +1,76 @@
 package main
 
 import (
+	"sync"
+
 	"fyne.io/fyne/v2"
 	fyneApp "fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
+	"github.com/gavinwade12/ecLogger/config"
 	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/pkg/errors"
 )
 
 type TabType int
 
 const (
-	TabConnection TabType = 0
-	TabParameters TabType = 1
-	TabLogging    TabType = 2
-	TabDTCs       TabType = 3
-	TabSettings   TabType = 4
+	TabConnection   TabType = 0
+	TabParameters   TabType = 1
+	TabRAM          TabType = 2
+	TabFormulas     TabType = 3
+	TabCalibrations TabType = 4
+	TabLogging      TabType = 5
+	TabDTCs         TabType = 6
+	TabSettings     TabType = 7
 )
 
-type Config struct {
-	SelectedPort        string
-	LogDirectory        *string
-	LogFileNameFormat   *string
-	LoggedParams        map[string]*LoggedParam
-	UseFakeConnection   bool
-	AutoConnect         bool
-	DefaultToLoggingTab bool
-}
-
 type App struct {
-	config       *Config
+	config       *config.Config
 	loggedParams *LoggedParams
 
 	fyneApp fyne.App
-
-	tabItems      *container.AppTabs
-	ConnectionTab *ConnectionTab
-	ParametersTab *ParametersTab
-	LoggingTab    *LoggingTab
-	DTCsTab       *DTCsTab
-	SettingsTab   *SettingsTab
-
-	connection ssm2.Connection
-	ecu        *ssm2.ECU
+	window  fyne.Window
+
+	tabItems        *container.AppTabs
+	ConnectionTab   *ConnectionTab
+	ParametersTab   *ParametersTab
+	RAMTab          *RAMTab
+	FormulasTab     *FormulasTab
+	CalibrationsTab *CalibrationsTab
+	LoggingTab      *LoggingTab
+	DTCsTab         *DTCsTab
+	SettingsTab     *SettingsTab
+
+	// connection and ecu are guarded by connectionMu, since the serial port watcher
+	// disconnects outside of the UI's goroutine. They're read through Connection and
+	// ECU, and the ECU is replaced rather than changed.
+	connection   ssm2.Connection
+	ecu          *ssm2.ECU
+	connectionMu sync.RWMutex
 }
 
-func NewApp(config *Config) *App {
+func NewApp(cfg *config.Config) *App {
 	app := &App{
-		config:       config,
-		loggedParams: NewLoggedParams(config.LoggedParams),
+		config:       cfg,
+		loggedParams: NewLoggedParams(cfg.Logging.ActiveProfile().Parameters),
 		fyneApp:      fyneApp.New(),
 	}
+	app.window = app.fyneApp.NewWindow("Logger")
 
 	app.ConnectionTab = NewConnectionTab(app)
 	app.ParametersTab = NewParametersTab(app)
 	app.LoggingTab = NewLoggingTab(app)
+	app.RAMTab = NewRAMTab(app)
+	app.FormulasTab = NewFormulasTab(app)
+	app.CalibrationsTab = NewCalibrationsTab(app)
 	app.DTCsTab = NewDTCsTab(app)
 	app.SettingsTab = NewSettingsTab(app)
 	app.tabItems = container.NewAppTabs(
 		container.NewTabItem("Connection", app.ConnectionTab.Container()),
 		container.NewTabItem("Parameters", app.ParametersTab.Container()),
+		container.NewTabItem("RAM", app.RAMTab.Container()),
+		container.NewTabItem("Formulas", app.FormulasTab.Container()),
+		container.NewTabItem("Calibrations", app.CalibrationsTab.Container()),
 		container.NewTabItem("Logging", app.LoggingTab.Container()),
 		container.NewTabItem("DTCs", app.DTCsTab.Container()),
 		container.NewTabItem("Settings", app.SettingsTab.Container()),
@@ -70,8 +82,22 @@ func NewApp(config *Config) *App {
 }
 
 func (a *App) OnNewConnection(conn ssm2.Connection, ecu *ssm2.ECU) {
+	a.connectionMu.Lock()
 	a.connection = conn
 	a.ecu = ecu
+	a.connectionMu.Unlock()
+
+	// restore the settings last used with the vehicle, or its default profile the first
+	// time an identified vehicle is connected
+	a.saveLoggedParams()
+	v := a.vehicle()
+	if e, ok := a.config.IdentifyVehicle(ecu.ROM_ID, ecu.SSM_ID); ok {
+		a.config.UseDefaultProfile(v, e)
+	}
+	a.loggedParams.Set(a.config.UseVehicleProfile(v))
+	a.ParametersTab.refreshProfiles()
+	a.ConnectionTab.showVehicle(v)
+	a.LoggingTab.setDashboardColumns(v.Dashboard.ColumnCount())
 
 	a.ParametersTab.setAvailableParameters(ecu)
 	a.LoggingTab.updateLiveLogParameters()
@@ -84,20 +110,105 @@ func (a *App) OnDisconnect() {
 		a.LoggingTab.cancelLogging = nil
 	}
 
-	a.connection.Close()
+	a.connectionMu.Lock()
+	conn := a.connection
 	a.connection = nil
 	a.ecu = nil
+	a.connectionMu.Unlock()
+	if conn != nil {
+		conn.Close()
+	}
 	a.ConnectionTab.onDisconnect()
+	a.ConnectionTab.showVehicle(nil)
 
 	a.toggleConnectionRelatedTabs(false)
 	a.ParametersTab.setAvailableParameters(nil)
 	a.LoggingTab.updateLiveLogParameters()
 }
 
+// setUnitPreferences applies the unit preferences to every profile, including the logged params.
+func (a *App) setUnitPreferences(p config.UnitPreferences) error {
+	if a.LoggingTab.logFile != nil {
+		return errors.New("stop logging to file before changing the units")
+	}
+
+	a.saveLoggedParams()
+	if err := a.config.SetUnitPreferences(p); err != nil {
+		return err
+	}
+	// apply the vehicle's units like connecting to it does
+	params := append([]config.Parameter{}, a.config.Logging.ActiveProfile().Parameters...)
+	if v := a.vehicle(); v != nil {
+		v.ApplyUnits(params)
+	}
+	a.loggedParams.Set(params)
+
+	a.ParametersTab.setAvailableParameters(a.ECU())
+	a.LoggingTab.onLoggedParametersChanged()
+	a.LoggingTab.updateLiveLogParameters()
+	return nil
+}
+
+// onUserParametersChanged makes the saved RAM parameters, formulas, and calibrations available
+// for logging. The live log is restarted to use them unless a file is being logged, which keeps
+// its current ones.
+func (a *App) onUserParametersChanged() {
+	a.connectionMu.Lock()
+	if a.ecu != nil {
+		ecu := *a.ecu
+		ecu.UpdateCustomParameters()
+		a.ecu = &ecu
+	}
+	ecu := a.ecu
+	a.connectionMu.Unlock()
+
+	a.ParametersTab.setAvailableParameters(ecu)
+	a.LoggingTab.onLoggedParametersChanged()
+	if a.LoggingTab.logFile == nil {
+		a.LoggingTab.updateLiveLogParameters()
+	}
+}
+
+// saveLoggedParams saves the logged params to the active profile in the config.
+func (a *App) saveLoggedParams() {
+	a.config.Logging.ActiveProfile().SetParameters(a.loggedParams.List())
+}
+
+// vehicle returns the settings for the connected vehicle, or nil when there isn't a connection.
+func (a *App) vehicle() *config.Vehicle {
+	return a.vehicleOf(a.ECU())
+}
+
+// vehicleOf returns the settings for the vehicle with the ECU, or nil when the ECU is nil.
+func (a *App) vehicleOf(ecu *ssm2.ECU) *config.Vehicle {
+	if ecu == nil {
+		return nil
+	}
+	return a.config.Vehicle(ecu.ROM_ID)
+}
+
+// vehicleSpecs returns the specs of the vehicle with the connected ECU, or the default
+// specs when there's no ECU or vehicle.
+func (a *App) vehicleSpecs() ssm2.Vehicle {
+	if v := a.vehicle(); v != nil {
+		return v.Specs
+	}
+	return ssm2.DefaultVehicle()
+}
+
 func (a *App) Connection() ssm2.Connection {
+	a.connectionMu.RLock()
+	defer a.connectionMu.RUnlock()
 	return a.connection
 }
 
+// ECU returns the connected ECU, or nil when there isn't a connection.
+func (a *App) ECU() *ssm2.ECU {
+	a.connectionMu.RLock()
+	defer a.connectionMu.RUnlock()
+	return a.ecu
+}
+
 func (a *App) EnableTab(tab TabType) {
 	a.tabItems.EnableIndex(int(tab))
 }
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/pkg/errors"
+)
+
+const (
+	debugLogFileName   = "logger-ui.log"
+	debugLogMaxSize    = 1024 * 1024 // 1 MiB
+	debugLogMaxBackups = 3
+	debugLogViewLines  = 200
+)
+
+// DebugLog writes the UI's log messages to stdout, a rotating file in the
+// config directory, and a buffer of recent lines shown in the Settings tab.
+type DebugLog struct {
+	Logger ssm2.Logger
+	Path   string
+
+	level  *slog.LevelVar
+	file   *rotatingFile
+	recent *lineBuffer
+}
+
+// debugLog is set once the config directory is known.
+var debugLog *DebugLog
+
+// OpenDebugLog opens the debug log file in dir and returns a DebugLog
+// logging messages at or above level.
+func OpenDebugLog(dir string, level ssm2.Level) (*DebugLog, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, errors.Wrap(err, "creating config directory")
+	}
+
+	path := filepath.Join(dir, debugLogFileName)
+	file, err := openRotatingFile(path, debugLogMaxSize, debugLogMaxBackups)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &DebugLog{
+		Path:   path,
+		level:  &slog.LevelVar{},
+		file:   file,
+		recent: &lineBuffer{max: debugLogViewLines},
+	}
+	l.SetLevel(level)
+	l.Logger = ssm2.NewSlogLogger(slog.New(slog.NewTextHandler(
+		teeWriter{os.Stdout, file, l.recent},
+		&slog.HandlerOptions{Level: l.level},
+	)))
+	return l, nil
+}
+
+// SetLevel changes the minimum level of logged messages.
+func (l *DebugLog) SetLevel(level ssm2.Level) {
+	l.level.Set(level.SlogLevel())
+}
+
+// Recent returns the most recently logged lines.
+func (l *DebugLog) Recent() string {
+	return l.recent.String()
+}
+
+func (l *DebugLog) Close() error {
+	return l.file.Close()
+}
+
+// rotatingFile is an io.Writer that writes to a file and rotates it once it
+// grows past maxSize, keeping up to maxBackups older files (path.1 being the newest).
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	f      *os.File
+	size   int64
+	closed bool
+	mu     sync.Mutex
+}
+
+func openRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrap(err, "opening debug log file")
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return errors.Wrap(err, "reading debug log file info")
+	}
+
+	r.f = f
+	r.size = info.Size()
+	return nil
+}
+
+func (r *rotatingFile) Write(b []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return 0, os.ErrClosed
+	}
+
+	var rotateErr error
+	if r.f != nil && r.size+int64(len(b)) > r.maxSize && r.size > 0 {
+		rotateErr = r.rotate()
+	}
+	if r.f == nil {
+		// the file couldn't be reopened after rotating, so try again
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+	if rotateErr != nil {
+		// keep appending to the current file, and try rotating again
+		// once another maxSize has been written
+		r.size = 0
+	}
+
+	n, err := r.f.Write(b)
+	r.size += int64(n)
+	if err == nil {
+		err = rotateErr
+	}
+	return n, err
+}
+
+// rotate moves the file to the first backup and opens a new one. The file is
+// reopened even when it couldn't be moved, so it's only left closed when it
+// can't be opened at all.
+func (r *rotatingFile) rotate() error {
+	closeErr := r.f.Close()
+	r.f = nil
+
+	var moveErr error
+	for i := r.maxBackups - 1; i > 0; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1))
+	}
+	if r.maxBackups > 0 {
+		if err := os.Rename(r.path, r.path+".1"); err != nil {
+			moveErr = errors.Wrap(err, "rotating debug log file")
+		}
+	} else if err := os.Remove(r.path); err != nil {
+		moveErr = errors.Wrap(err, "removing debug log file")
+	}
+
+	if err := r.open(); err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return errors.Wrap(closeErr, "closing debug log file")
+	}
+	return moveErr
+}
+
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	if r.f == nil {
+		return nil
+	}
+	err := r.f.Close()
+	r.f = nil
+	return err
+}
+
+// teeWriter writes to each of its writers, unlike io.MultiWriter, which stops
+// at the first one that fails. This keeps a failing log file from silencing
+// stdout and the Settings tab. It returns the first error.
+type teeWriter []io.Writer
+
+func (t teeWriter) Write(p []byte) (int, error) {
+	var firstErr error
+	for _, w := range t {
+		if _, err := w.Write(p); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return len(p), firstErr
+}
+
+// lineBuffer keeps the last max lines written to it.
+type lineBuffer struct {
+	max   int
+	lines []string
+	mu    sync.Mutex
+}
+
+func (b *lineBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		b.lines = append(b.lines, line)
+	}
+	if len(b.lines) > b.max {
+		b.lines = append([]string{}, b.lines[len(b.lines)-b.max:]...)
+	}
+	return len(p), nil
+}
+
+func (b *lineBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return strings.Join(b.lines, "\n")
+}
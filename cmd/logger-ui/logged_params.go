@@ -1,18 +1,14 @@
 package main
 
 import (
+	"sort"
 	"sync"
 
+	"github.com/gavinwade12/ecLogger/config"
 	"github.com/gavinwade12/ecLogger/protocols/ssm2"
-	"github.com/gavinwade12/ecLogger/units"
 )
 
-type LoggedParam struct {
-	LogToFile bool
-	LiveLog   bool
-	Derived   bool
-	Unit      units.Unit
-}
+type LoggedParam = config.Parameter
 
 // LoggedParams manages a set of logged params for conccurency-safe access.
 type LoggedParams struct {
@@ -20,8 +16,36 @@ type LoggedParams struct {
 	mu   sync.RWMutex
 }
 
-func NewLoggedParams(data map[string]*LoggedParam) *LoggedParams {
-	return &LoggedParams{data: data}
+func NewLoggedParams(params []config.Parameter) *LoggedParams {
+	p := &LoggedParams{}
+	p.Set(params)
+	return p
+}
+
+// Set replaces the logged params, e.g. when another profile is selected.
+func (p *LoggedParams) Set(params []config.Parameter) {
+	data := make(map[string]*LoggedParam, len(params))
+	for _, lp := range params {
+		lp := lp
+		data[lp.ID] = &lp
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.data = data
+}
+
+// List returns a copy of the logged params sorted by ID.
+func (p *LoggedParams) List() []config.Parameter {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	list := make([]config.Parameter, 0, len(p.data))
+	for _, lp := range p.data {
+		list = append(list, *lp)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
 }
 
 func (p *LoggedParams) Remove(key string) {
@@ -43,6 +67,7 @@ func (p *LoggedParams) UpdateOrAdd(key string, update func(*LoggedParam), add *L
 	if p.data[key] != nil {
 		update(p.data[key])
 	} else {
+		add.ID = key
 		p.data[key] = add
 	}
 }
@@ -64,17 +89,15 @@ func (p *LoggedParams) CopyData() map[string]*LoggedParam {
 	return m
 }
 
-func (p *LoggedParams) CurrentLists() ([]ssm2.Parameter, []ssm2.DerivedParameter) {
-	params := []ssm2.Parameter{}
-	derivedParams := []ssm2.DerivedParameter{}
-	LoggedParams := p.CopyData()
-	for id, p := range LoggedParams {
-		if p.Derived {
-			derivedParams = append(derivedParams, ssm2.DerivedParameters[id])
-		} else {
-			params = append(params, ssm2.Parameters[id])
-		}
+// CurrentLists returns the logged params supported by the ECU in priority order.
+func (p *LoggedParams) CurrentLists(ecu *ssm2.ECU) ([]ssm2.Parameter, []ssm2.DerivedParameter) {
+	s := config.SelectParameters(p.List(), ecu)
+	if len(s.Dropped) > 0 {
+		logger.Warn("not logging the lowest priority parameters since they don't fit in a single read request",
+			"parameters", s.Dropped)
 	}
-
-	return params, derivedParams
+	if len(s.Dependencies) > 0 {
+		logger.Debug("reading parameters required by derived parameters", "parameters", s.Dependencies)
+	}
+	return s.Parameters, s.Derived
 }
@@ -0,0 +1,404 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/gavinwade12/ecLogger/formula"
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+	"github.com/pkg/errors"
+)
+
+// calibrationPreviewProcessor is the key of the logging processor that previews the edited calibration.
+const calibrationPreviewProcessor = "calibrationPreview"
+
+// The ways a calibration can calibrate its source's value.
+const (
+	calibrationLinear = "Scale and Offset"
+	calibrationTable  = "Lookup Table"
+)
+
+// CalibrationsTab edits the user-defined calibrations and previews the edited
+// calibration against the values from the current logging session.
+type CalibrationsTab struct {
+	app *App
+
+	list     *widget.List
+	selected int // the index of the calibration being edited, or -1 for a new one
+
+	id          *widget.Entry
+	name        *widget.Entry
+	source      *widget.SelectEntry
+	sourceUnit  *widget.SelectEntry
+	mode        *widget.RadioGroup
+	scale       *widget.Entry
+	offset      *widget.Entry
+	table       *widget.Entry
+	unit        *widget.SelectEntry
+	description *widget.Entry
+	status      *widget.Label
+	preview     binding.String
+	deleteBtn   *widget.Button
+	editor      *fyne.Container
+	linearForm  *widget.Form
+	tableForm   *widget.Form
+
+	// edited is the calibration in the editor compiled with the saved calibrations it
+	// can reference, or the error compiling it. They're read by the preview processor.
+	edited    ssm2.DerivedParameter
+	editedErr error
+	editedMu  sync.Mutex
+
+	container fyne.CanvasObject
+}
+
+func NewCalibrationsTab(app *App) *CalibrationsTab {
+	t := &CalibrationsTab{
+		app:         app,
+		selected:    -1,
+		id:          widget.NewEntry(),
+		name:        widget.NewEntry(),
+		source:      widget.NewSelectEntry(nil),
+		sourceUnit:  widget.NewSelectEntry(unitOptions()),
+		scale:       widget.NewEntry(),
+		offset:      widget.NewEntry(),
+		table:       widget.NewMultiLineEntry(),
+		unit:        widget.NewSelectEntry(unitOptions()),
+		description: widget.NewEntry(),
+		status:      NewWrappedLabel(""),
+		preview:     binding.NewString(),
+	}
+
+	t.list = widget.NewList(
+		func() int { return len(t.app.config.Calibrations) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(i widget.ListItemID, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(t.app.config.Calibrations[i].Name)
+		},
+	)
+	t.list.OnSelected = func(i widget.ListItemID) { t.edit(i) }
+
+	t.id.SetPlaceHolder("e.g. fuel_remaining")
+	t.name.SetPlaceHolder("e.g. Fuel Remaining")
+	t.source.SetPlaceHolder("e.g. P119")
+	t.sourceUnit.SetPlaceHolder("The source's default unit")
+	t.scale.SetPlaceHolder("e.g. 37.5")
+	t.offset.SetPlaceHolder("e.g. -18.75")
+	t.table.SetPlaceHolder("One point per line: input, output\ne.g.\n5, 16\n90, 0")
+	t.table.SetMinRowsVisible(6)
+	for _, e := range []*widget.Entry{t.id, t.name, t.scale, t.offset, t.table, t.description} {
+		e.OnChanged = func(string) { t.onChanged() }
+	}
+	for _, e := range []*widget.SelectEntry{t.source, t.sourceUnit, t.unit} {
+		e.OnChanged = func(string) { t.onChanged() }
+	}
+
+	t.linearForm = widget.NewForm(
+		widget.NewFormItem("Scale", t.scale),
+		widget.NewFormItem("Offset", t.offset),
+	)
+	t.tableForm = widget.NewForm(widget.NewFormItem("Table", t.table))
+	t.mode = widget.NewRadioGroup([]string{calibrationLinear, calibrationTable}, func(mode string) {
+		if mode == calibrationTable {
+			t.linearForm.Hide()
+			t.tableForm.Show()
+		} else {
+			t.tableForm.Hide()
+			t.linearForm.Show()
+		}
+		t.onChanged()
+	})
+	t.mode.Horizontal = true
+	t.mode.Required = true
+
+	t.deleteBtn = widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), t.onDeleteTapped)
+	form := widget.NewForm(
+		widget.NewFormItem("ID", t.id),
+		widget.NewFormItem("Name", t.name),
+		widget.NewFormItem("Source", t.source),
+		widget.NewFormItem("Source Unit", t.sourceUnit),
+		widget.NewFormItem("Unit", t.unit),
+		widget.NewFormItem("Description", t.description),
+		widget.NewFormItem("Calibration", t.mode),
+	)
+	help := NewWrappedLabel("The source's value is converted to the source unit and calibrated to the unit. " +
+		"Scale and offset calculate source * scale + offset. A lookup table interpolates between its points " +
+		"and uses the first or last point's output for values outside of the table.")
+	t.editor = container.NewVBox(
+		form,
+		t.linearForm,
+		t.tableForm,
+		widget.NewForm(widget.NewFormItem("Preview", widget.NewLabelWithData(t.preview))),
+		t.status,
+		container.NewHBox(
+			widget.NewButtonWithIcon("Save", theme.DocumentSaveIcon(), t.onSaveTapped),
+			t.deleteBtn,
+		),
+		help,
+	)
+	t.editor.Hide()
+
+	t.container = container.NewBorder(nil, nil,
+		container.NewBorder(
+			widget.NewButtonWithIcon("New", theme.ContentAddIcon(), t.onNewTapped),
+			nil, nil, nil, t.list),
+		nil,
+		container.NewVScroll(t.editor),
+	)
+	return t
+}
+
+func (t *CalibrationsTab) Container() fyne.CanvasObject {
+	return t.container
+}
+
+// sourceOptions returns the parameters that can be calibrated, labeled with their names.
+func sourceOptions() []string {
+	params, derived := ssm2.Catalog()
+	options := make([]string, 0, len(params)+len(derived))
+	for id, p := range params {
+		options = append(options, fmt.Sprintf("%s: %s", id, p.Name))
+	}
+	for id, p := range derived {
+		options = append(options, fmt.Sprintf("%s: %s", id, p.Name))
+	}
+	sort.Strings(options)
+	return options
+}
+
+// sourceID returns the ID of the parameter selected from the sourceOptions or entered.
+func sourceID(text string) string {
+	id, _, _ := strings.Cut(text, ":")
+	return strings.TrimSpace(id)
+}
+
+func (t *CalibrationsTab) onNewTapped() {
+	t.list.UnselectAll()
+	t.selected = -1
+	t.show(formula.Calibration{Scale: 1})
+	t.deleteBtn.Hide()
+}
+
+// edit shows the calibration at index i in the editor.
+func (t *CalibrationsTab) edit(i int) {
+	t.selected = i
+	t.show(t.app.config.Calibrations[i])
+	t.deleteBtn.Show()
+}
+
+func (t *CalibrationsTab) show(c formula.Calibration) {
+	// formulas and other calibrations can be calibrated
+	t.source.SetOptions(sourceOptions())
+
+	t.id.SetText(c.ID)
+	t.name.SetText(c.Name)
+	t.source.SetText(c.Source)
+	t.sourceUnit.SetText(string(c.SourceUnit))
+	t.unit.SetText(string(c.Unit))
+	t.description.SetText(c.Description)
+	t.scale.SetText(strconv.FormatFloat(c.Scale, 'f', -1, 64))
+	t.offset.SetText(strconv.FormatFloat(c.Offset, 'f', -1, 64))
+	lines := make([]string, len(c.Table))
+	for i, p := range c.Table {
+		lines[i] = fmt.Sprintf("%s, %s", strconv.FormatFloat(p.In, 'f', -1, 64), strconv.FormatFloat(p.Out, 'f', -1, 64))
+	}
+	t.table.SetText(strings.Join(lines, "\n"))
+	if len(c.Table) > 0 {
+		t.mode.SetSelected(calibrationTable)
+	} else {
+		t.mode.SetSelected(calibrationLinear)
+	}
+
+	t.editor.Show()
+	t.onChanged()
+	t.app.LoggingTab.setLoggingProcessor(calibrationPreviewProcessor, t.updatePreview)
+}
+
+// current returns the calibration in the editor, or an error if a number can't be parsed.
+func (t *CalibrationsTab) current() (formula.Calibration, error) {
+	c := formula.Calibration{
+		ID:          strings.TrimSpace(t.id.Text),
+		Name:        strings.TrimSpace(t.name.Text),
+		Description: strings.TrimSpace(t.description.Text),
+		Source:      sourceID(t.source.Text),
+		SourceUnit:  units.Unit(strings.TrimSpace(t.sourceUnit.Text)),
+		Unit:        units.Unit(strings.TrimSpace(t.unit.Text)),
+	}
+
+	if t.mode.Selected == calibrationTable {
+		table, err := parseCalibrationTable(t.table.Text)
+		if err != nil {
+			return c, err
+		}
+		c.Table = table
+		c.SortTable()
+		return c, nil
+	}
+
+	var err error
+	if c.Scale, err = strconv.ParseFloat(strings.TrimSpace(t.scale.Text), 64); err != nil {
+		return c, errors.New("the scale must be a number")
+	}
+	if offset := strings.TrimSpace(t.offset.Text); offset != "" {
+		if c.Offset, err = strconv.ParseFloat(offset, 64); err != nil {
+			return c, errors.New("the offset must be a number")
+		}
+	}
+	return c, nil
+}
+
+// parseCalibrationTable parses a table with a point per line, each an input
+// and an output separated by a comma. Blank lines are ignored.
+func parseCalibrationTable(text string) ([]formula.Point, error) {
+	var table []formula.Point
+	for i, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		in, out, ok := strings.Cut(line, ",")
+		if !ok {
+			return nil, fmt.Errorf("table line %d: want an input and an output separated by a comma", i+1)
+		}
+		var (
+			p   formula.Point
+			err error
+		)
+		if p.In, err = strconv.ParseFloat(strings.TrimSpace(in), 64); err != nil {
+			return nil, fmt.Errorf("table line %d: the input must be a number", i+1)
+		}
+		if p.Out, err = strconv.ParseFloat(strings.TrimSpace(out), 64); err != nil {
+			return nil, fmt.Errorf("table line %d: the output must be a number", i+1)
+		}
+		table = append(table, p)
+	}
+	return table, nil
+}
+
+// otherCalibrations returns the saved calibrations other than the one being edited.
+func (t *CalibrationsTab) otherCalibrations() []formula.Calibration {
+	others := make([]formula.Calibration, 0, len(t.app.config.Calibrations))
+	for i, c := range t.app.config.Calibrations {
+		if i != t.selected {
+			others = append(others, c)
+		}
+	}
+	return others
+}
+
+// onChanged compiles the edited calibration and updates the calibration being previewed.
+func (t *CalibrationsTab) onChanged() {
+	c, err := t.current()
+	var compiled []ssm2.DerivedParameter
+	if err == nil {
+		compiled, err = formula.CompileCalibrations(append([]formula.Calibration{c}, t.otherCalibrations()...))
+	}
+	t.editedMu.Lock()
+	t.edited, t.editedErr = ssm2.DerivedParameter{}, err
+	if err == nil {
+		t.edited = compiled[0]
+	}
+	t.editedMu.Unlock()
+
+	if err != nil {
+		t.status.SetText(err.Error())
+		t.status.Importance = widget.DangerImportance
+	} else {
+		t.status.SetText("Valid")
+		t.status.Importance = widget.SuccessImportance
+	}
+	t.status.Refresh()
+
+	if t.app.Connection() == nil {
+		t.preview.Set("Connect to preview the value")
+	} else {
+		t.preview.Set("Waiting for values from the live log")
+	}
+}
+
+// updatePreview is a logging processor that evaluates the edited calibration against the session's values.
+func (t *CalibrationsTab) updatePreview(values map[string]ssm2.ParameterValue) {
+	t.editedMu.Lock()
+	p, err := t.edited, t.editedErr
+	t.editedMu.Unlock()
+	if err != nil {
+		t.preview.Set(err.Error())
+		return
+	}
+
+	v, err := formula.Evaluate(p, values, t.app.vehicleSpecs())
+	if err != nil {
+		t.preview.Set(err.Error())
+		return
+	}
+	source := values[p.DependsOnParameters[0]]
+	t.preview.Set(fmt.Sprintf("%s %s (%s %s)",
+		strconv.FormatFloat(float64(v.Value), 'f', 2, 32), v.Unit,
+		strconv.FormatFloat(float64(source.Value), 'f', 2, 32), source.Unit))
+}
+
+func (t *CalibrationsTab) onSaveTapped() {
+	c, err := t.current()
+	if err != nil {
+		dialog.ShowError(err, t.app.window)
+		return
+	}
+	calibrations := t.otherCalibrations()
+	var oldID string
+	if t.selected >= 0 {
+		oldID = t.app.config.Calibrations[t.selected].ID
+		// keep the calibration's position in the list
+		calibrations = append(calibrations[:t.selected], append([]formula.Calibration{c}, calibrations[t.selected:]...)...)
+	} else {
+		calibrations = append(calibrations, c)
+	}
+
+	if err := t.app.config.SetCalibrations(calibrations); err != nil {
+		dialog.ShowError(err, t.app.window)
+		return
+	}
+	if oldID != "" && oldID != c.ID {
+		t.app.loggedParams.Remove(oldID)
+	}
+
+	t.list.Refresh()
+	for i, saved := range t.app.config.Calibrations {
+		if saved.ID == c.ID {
+			t.list.Select(i)
+		}
+	}
+	t.app.onUserParametersChanged()
+}
+
+func (t *CalibrationsTab) onDeleteTapped() {
+	if t.selected < 0 {
+		return
+	}
+	c := t.app.config.Calibrations[t.selected]
+	dialog.ShowConfirm("Delete Calibration", fmt.Sprintf("Delete the %s calibration?", c.Name), func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := t.app.config.SetCalibrations(t.otherCalibrations()); err != nil {
+			dialog.ShowError(err, t.app.window)
+			return
+		}
+		t.app.loggedParams.Remove(c.ID)
+
+		t.list.UnselectAll()
+		t.list.Refresh()
+		t.selected = -1
+		t.editor.Hide()
+		t.app.LoggingTab.removeLoggingProcessor(calibrationPreviewProcessor)
+		t.app.onUserParametersChanged()
+	}, t.app.window)
+}
@@ -2,6 +2,11 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -9,23 +14,36 @@ import (
 	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/widget"
+	"github.com/gavinwade12/ecLogger/config"
 	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/serialport"
 	"github.com/pkg/errors"
-	"go.bug.st/serial"
-	"go.bug.st/serial/enumerator"
 )
 
 type ConnectionTab struct {
 	app *App
 
-	serialPortSelect    *widget.Select
-	stopSerialPortQuery chan struct{}
+	serialPortSelect *widget.Select
+	serialPorts      map[string]bool
+	activePort       string
+	// reconnectPort is the port that was removed while connected. When AutoConnect
+	// is on, the ECU is reconnected once the port is added back.
+	reconnectPort string
+	connecting    bool
+	mu            sync.Mutex
 
 	connectBtn      *widget.Button
 	disconnectBtn   *widget.Button
 	cancelBtn       *widget.Button
 	connectionState binding.String
 
+	vehicleROMID     *widget.Label
+	vehicleLabel     *widget.Label
+	vehicleNickname  *widget.Entry
+	dashboardColumns *widget.Entry
+	vehicleForm      *widget.Form
+	specs            *specsForm
+
 	container *fyne.Container
 }
 
@@ -33,14 +51,15 @@ func NewConnectionTab(app *App) *ConnectionTab {
 	connectionTab := &ConnectionTab{
 		app: app,
 		serialPortSelect: widget.NewSelect([]string{}, func(s string) {
-			app.config.SelectedPort = s
+			app.config.Port = s
 		}),
 		connectBtn:      widget.NewButton("Connect", nil),
 		disconnectBtn:   widget.NewButton("Disconnect", nil),
 		cancelBtn:       widget.NewButton("Cancel", nil),
 		connectionState: binding.NewString(),
+		serialPorts:     map[string]bool{},
 	}
-	go connectionTab.querySerialPorts()
+	go connectionTab.watchSerialPorts()
 
 	form := widget.NewForm(
 		widget.NewFormItem("Port", connectionTab.serialPortSelect),
@@ -53,6 +72,39 @@ func NewConnectionTab(app *App) *ConnectionTab {
 	connectionTab.disconnectBtn.Hide()
 	connectionTab.cancelBtn.Hide()
 
+	connectionTab.vehicleROMID = widget.NewLabel("")
+	connectionTab.vehicleLabel = widget.NewLabel("")
+	connectionTab.vehicleNickname = widget.NewEntry()
+	connectionTab.vehicleNickname.SetPlaceHolder("Used for {{nickname}} in log file names")
+	connectionTab.vehicleNickname.OnChanged = func(s string) {
+		if v := app.vehicle(); v != nil {
+			v.Nickname = s
+		}
+	}
+	connectionTab.dashboardColumns = widget.NewEntry()
+	connectionTab.dashboardColumns.Validator = func(s string) error {
+		if i, err := strconv.Atoi(s); err != nil || i < 1 {
+			return errors.New("must be a positive number")
+		}
+		return nil
+	}
+	connectionTab.dashboardColumns.OnChanged = func(s string) {
+		v := app.vehicle()
+		if i, err := strconv.Atoi(s); err == nil && i > 0 && v != nil {
+			v.Dashboard.Columns = i
+			app.LoggingTab.setDashboardColumns(i)
+		}
+	}
+	connectionTab.vehicleForm = widget.NewForm(
+		widget.NewFormItem("ROM ID", connectionTab.vehicleROMID),
+		widget.NewFormItem("Vehicle", connectionTab.vehicleLabel),
+		widget.NewFormItem("Nickname", connectionTab.vehicleNickname),
+		widget.NewFormItem("Dashboard Columns", connectionTab.dashboardColumns),
+	)
+	connectionTab.vehicleForm.Hide()
+	connectionTab.specs = newSpecsForm(app)
+	connectionTab.specs.form.Hide()
+
 	connectionTab.container = container.New(layout.NewVBoxLayout(),
 		form,
 		container.New(layout.NewHBoxLayout(),
@@ -60,7 +112,9 @@ func NewConnectionTab(app *App) *ConnectionTab {
 			widget.NewLabelWithData(connectionTab.connectionState)),
 		connectionTab.connectBtn,
 		connectionTab.cancelBtn,
-		connectionTab.disconnectBtn)
+		connectionTab.disconnectBtn,
+		connectionTab.vehicleForm,
+		connectionTab.specs.form)
 
 	return connectionTab
 }
@@ -70,12 +124,19 @@ func (t *ConnectionTab) Container() fyne.CanvasObject {
 }
 
 func (t *ConnectionTab) OnConnectTapped() {
-	// disable this button and the select, show the cancel button, and stop
-	// querying for serial port changes
+	// only allow one connection attempt at a time
+	t.mu.Lock()
+	if t.connecting || t.app.Connection() != nil {
+		t.mu.Unlock()
+		return
+	}
+	t.connecting = true
+	t.mu.Unlock()
+
+	// disable this button and the select and show the cancel button
 	t.connectBtn.Disable()
 	t.serialPortSelect.Disable()
 	t.cancelBtn.Show()
-	t.stopSerialPortQuery <- struct{}{}
 
 	// set up the cancel button
 	ctx, cancel := context.WithCancel(context.Background())
@@ -93,11 +154,14 @@ func (t *ConnectionTab) openAndInitConnection(ctx context.Context) {
 		cleanup = func() {
 			t.cancelBtn.Hide()
 			t.serialPortSelect.Enable()
+			t.mu.Lock()
+			t.connecting = false
+			t.mu.Unlock()
 		}
 		onError = func(err error) {
 			cleanup()
 			t.onDisconnect()
-			logger.Debug(err.Error())
+			logger.Error("connecting to ECU", "error", err)
 		}
 	)
 
@@ -129,7 +193,7 @@ func (t *ConnectionTab) initSSM2Connection(ctx context.Context, conn ssm2.Connec
 		default:
 			ecu, err := conn.InitECU(ctx)
 			if err != nil {
-				logger.Debug(err.Error())
+				logger.Warn("initializing ECU", "error", err)
 				continue
 			}
 
@@ -140,74 +204,296 @@ func (t *ConnectionTab) initSSM2Connection(ctx context.Context, conn ssm2.Connec
 	}
 }
 
-func (t *ConnectionTab) querySerialPorts() {
-	t.stopSerialPortQuery = make(chan struct{})
+// watchSerialPorts keeps the port selection up to date as serial ports are
+// added and removed for as long as the app runs.
+func (t *ConnectionTab) watchSerialPorts() {
+	for e := range serialport.Watch(context.Background(), logger) {
+		logger.Info("serial port "+e.Type.String(), "port", e.Port.Name)
+
+		t.mu.Lock()
+		if e.Type == serialport.PortAdded {
+			t.serialPorts[e.Port.Name] = true
+		} else {
+			delete(t.serialPorts, e.Port.Name)
+		}
+		ports := make([]string, 0, len(t.serialPorts)+1)
+		for p := range t.serialPorts {
+			ports = append(ports, p)
+		}
+		activePort := t.activePort
+		reconnect := false
+		switch e.Type {
+		case serialport.PortAdded:
+			reconnect = e.Port.Name == t.reconnectPort
+			if reconnect {
+				t.reconnectPort = ""
+			}
+		case serialport.PortRemoved:
+			if e.Port.Name == activePort && t.app.Connection() != nil {
+				t.reconnectPort = activePort
+			}
+		}
+		t.mu.Unlock()
+
+		sort.Strings(ports)
+		t.serialPortSelect.Options = append([]string{autoPortOption}, ports...)
+		t.serialPortSelect.SetSelected(t.app.config.Port)
+		t.serialPortSelect.Refresh()
 
-	query := func() {
-		pl, err := enumerator.GetDetailedPortsList()
-		if err != nil {
-			logger.Debug(err.Error())
-			return
+		switch e.Type {
+		case serialport.PortAdded:
+			// reconnect when the ECU's cable comes back after being removed
+			if reconnect && t.app.config.UI.AutoConnect {
+				go t.OnConnectTapped()
+			}
+		case serialport.PortRemoved:
+			if t.app.Connection() == nil || e.Port.Name != activePort {
+				continue
+			}
+
+			msg := fmt.Sprintf("The serial port %s was removed while connected.", e.Port.Name)
+			logger.Error("serial port removed while connected", "port", e.Port.Name)
+			t.app.fyneApp.SendNotification(fyne.NewNotification("Connection Lost", msg))
+			t.app.OnDisconnect()
+			t.connectionState.Set("Disconnected (port removed)")
 		}
+	}
+}
 
-		ports := make([]string, len(pl))
-		for i, p := range pl {
-			ports[i] = p.Name
+// showVehicle shows the settings for the connected vehicle, or hides them when v is nil.
+func (t *ConnectionTab) showVehicle(v *config.Vehicle) {
+	if v == nil {
+		t.vehicleForm.Hide()
+		t.specs.form.Hide()
+		return
+	}
+
+	t.vehicleROMID.SetText(v.ROMID)
+	label := "Unknown (it can be added to romIds in the config file)"
+	if ecu := t.app.ECU(); ecu != nil {
+		if e, ok := t.app.config.IdentifyVehicle(ecu.ROM_ID, ecu.SSM_ID); ok {
+			label = e.Label()
 		}
+	}
+	t.vehicleLabel.SetText(label)
+	t.vehicleNickname.SetText(v.Nickname)
+	t.dashboardColumns.SetText(strconv.Itoa(v.Dashboard.ColumnCount()))
+	t.vehicleForm.Show()
+	t.specs.show(v.Specs)
+	t.specs.form.Show()
+}
 
-		t.serialPortSelect.Options = ports
-		t.serialPortSelect.SetSelected(t.app.config.SelectedPort)
-		t.serialPortSelect.Refresh()
+// specsForm edits the specs of the connected vehicle that derived parameters are calculated from.
+type specsForm struct {
+	app *App
+
+	displacement   *widget.Entry
+	cylinders      *widget.Entry
+	injectorSize   *widget.Entry
+	fuelType       *widget.Select
+	ethanolPercent *widget.Entry
+	stoichAFR      *widget.Entry
+	tireSize       *widget.Entry
+	gearRatios     *widget.Entry
+	finalDrive     *widget.Entry
+
+	form *widget.Form
+}
+
+func newSpecsForm(app *App) *specsForm {
+	f := &specsForm{
+		app:            app,
+		displacement:   newNumberEntry("Liters", false),
+		cylinders:      newNumberEntry("", false),
+		injectorSize:   newNumberEntry("cc/min", false),
+		ethanolPercent: newNumberEntry("e.g. 85 for E85", true),
+		stoichAFR:      newNumberEntry("Calculated from the fuel", true),
+		tireSize:       widget.NewEntry(),
+		gearRatios:     widget.NewEntry(),
+		finalDrive:     newNumberEntry("", false),
 	}
 
-	// query now
-	query()
+	fuelTypes := make([]string, len(ssm2.FuelTypes))
+	for i, ft := range ssm2.FuelTypes {
+		fuelTypes[i] = string(ft)
+	}
+	f.fuelType = widget.NewSelect(fuelTypes, func(s string) {
+		if ssm2.FuelType(s) == ssm2.FuelEthanolBlend {
+			f.ethanolPercent.Enable()
+		} else {
+			f.ethanolPercent.Disable()
+		}
+	})
 
-	// and query every 30 seconds until we receive a signal to stop
-	for {
-		select {
-		case <-t.stopSerialPortQuery:
-			return
-		case <-time.NewTicker(time.Second * 30).C:
-			query()
+	f.tireSize.SetPlaceHolder("e.g. 225/45R17")
+	f.tireSize.Validator = func(s string) error {
+		_, err := ssm2.ParseTireSize(s)
+		return err
+	}
+	f.gearRatios.SetPlaceHolder("First gear first, e.g. 3.454, 1.947, 1.296")
+	f.gearRatios.Validator = func(s string) error {
+		_, err := parseGearRatios(s)
+		return err
+	}
+
+	f.form = widget.NewForm(
+		widget.NewFormItem("Displacement", f.displacement),
+		widget.NewFormItem("Cylinders", f.cylinders),
+		widget.NewFormItem("Injector Size", f.injectorSize),
+		widget.NewFormItem("Fuel", f.fuelType),
+		widget.NewFormItem("Ethanol %", f.ethanolPercent),
+		widget.NewFormItem("Stoich AFR", f.stoichAFR),
+		widget.NewFormItem("Tire Size", f.tireSize),
+		widget.NewFormItem("Gear Ratios", f.gearRatios),
+		widget.NewFormItem("Final Drive", f.finalDrive),
+	)
+	f.form.SubmitText = "Apply Specs"
+	f.form.OnSubmit = f.apply
+	return f
+}
+
+// show fills the form with the specs.
+func (f *specsForm) show(specs ssm2.Vehicle) {
+	f.displacement.SetText(formatFloat(specs.Displacement))
+	f.cylinders.SetText(strconv.Itoa(specs.Cylinders))
+	f.injectorSize.SetText(formatFloat(specs.InjectorSize))
+	f.fuelType.SetSelected(string(specs.Fuel.Type))
+	f.ethanolPercent.SetText(formatOptionalFloat(specs.Fuel.EthanolPercent))
+	f.stoichAFR.SetText(formatOptionalFloat(specs.Fuel.StoichAFR))
+	f.tireSize.SetText(specs.TireSize)
+	ratios := make([]string, len(specs.GearRatios))
+	for i, r := range specs.GearRatios {
+		ratios[i] = formatFloat(r)
+	}
+	f.gearRatios.SetText(strings.Join(ratios, ", "))
+	f.finalDrive.SetText(formatFloat(specs.FinalDrive))
+}
+
+// apply saves the specs for the connected vehicle and restarts the live log
+// so derived parameters use them. A log file in progress keeps the specs it
+// was started with to keep file results consistent.
+func (f *specsForm) apply() {
+	v := f.app.vehicle()
+	if v == nil {
+		return
+	}
+
+	// the entries are validated before the form can be submitted
+	cylinders, _ := strconv.Atoi(f.cylinders.Text)
+	ratios, _ := parseGearRatios(f.gearRatios.Text)
+	v.Specs = ssm2.Vehicle{
+		Displacement: parseFloat(f.displacement.Text),
+		Cylinders:    cylinders,
+		InjectorSize: parseFloat(f.injectorSize.Text),
+		Fuel: ssm2.Fuel{
+			Type:      ssm2.FuelType(f.fuelType.Selected),
+			StoichAFR: parseFloat(f.stoichAFR.Text),
+		},
+		TireSize:   strings.TrimSpace(f.tireSize.Text),
+		GearRatios: ratios,
+		FinalDrive: parseFloat(f.finalDrive.Text),
+	}
+	if v.Specs.Fuel.Type == ssm2.FuelEthanolBlend {
+		v.Specs.Fuel.EthanolPercent = parseFloat(f.ethanolPercent.Text)
+	}
+	v.Specs = v.Specs.WithDefaults()
+
+	if f.app.LoggingTab.logFile == nil {
+		f.app.LoggingTab.updateLiveLogParameters()
+	}
+}
+
+// newNumberEntry returns an entry that only accepts positive numbers, or an empty value when optional.
+func newNumberEntry(placeHolder string, optional bool) *widget.Entry {
+	e := widget.NewEntry()
+	e.SetPlaceHolder(placeHolder)
+	e.Validator = func(s string) error {
+		s = strings.TrimSpace(s)
+		if s == "" && optional {
+			return nil
+		}
+		if f, err := strconv.ParseFloat(s, 32); err != nil || f <= 0 {
+			return errors.New("must be a positive number")
+		}
+		return nil
+	}
+	return e
+}
+
+// parseGearRatios parses a comma-separated list of gear ratios.
+func parseGearRatios(s string) ([]float32, error) {
+	var ratios []float32
+	for _, r := range strings.Split(s, ",") {
+		f, err := strconv.ParseFloat(strings.TrimSpace(r), 32)
+		if err != nil || f <= 0 {
+			return nil, errors.New("must be a list of positive numbers separated by commas")
 		}
+		ratios = append(ratios, float32(f))
+	}
+	return ratios, nil
+}
+
+func parseFloat(s string) float32 {
+	f, _ := strconv.ParseFloat(strings.TrimSpace(s), 32)
+	return float32(f)
+}
+
+func formatFloat(f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', -1, 32)
+}
+
+// formatOptionalFloat formats f, returning an empty string when it's zero (unset).
+func formatOptionalFloat(f float32) string {
+	if f == 0 {
+		return ""
 	}
+	return formatFloat(f)
 }
 
 func (t *ConnectionTab) onDisconnect() {
+	t.mu.Lock()
+	t.activePort = ""
+	t.mu.Unlock()
+
 	t.serialPortSelect.Enable()
-	go t.querySerialPorts()
 	t.connectBtn.Enable()
 	t.connectionState.Set("Disconnected")
 }
 
+// autoPortOption is the port selection that probes the available
+// ports and connects to the first one an ECU answers on.
+const autoPortOption = "Auto"
+
 var (
 	defaultOpenFunc = func(app *App) (ssm2.Connection, error) {
-		app.ConnectionTab.connectionState.Set("Connecting...")
-
-		if app.config.SelectedPort == "" {
+		port := app.config.Port
+		if port == "" {
 			return nil, errors.New("a port is required")
 		}
 
-		logger.Debugf("opening serial port %s", app.config.SelectedPort)
-		sp, err := serial.Open(app.config.SelectedPort, &serial.Mode{
-			BaudRate: ssm2.ConnectionBaudRate,
-			DataBits: ssm2.ConnectionDataBits,
-			Parity:   serial.NoParity,
-			StopBits: serial.OneStopBit,
-		})
-		if err != nil {
-			return nil, errors.Wrapf(err, "opening serial port '%s'", app.config.SelectedPort)
-		}
+		if port == autoPortOption {
+			app.ConnectionTab.connectionState.Set("Detecting...")
 
-		if err = sp.SetReadTimeout(ssm2.ConnectionReadTimeout); err != nil {
-			return nil, errors.Wrap(err, "setting serial port read timeout")
+			var err error
+			port, err = serialport.FirstResponding(context.Background(),
+				serialport.DefaultProbeTimeout, app.config.Connection, logger)
+			if err != nil {
+				return nil, errors.Wrap(err, "detecting port")
+			}
+			logger.Info("detected ECU", "port", port)
 		}
+
+		app.ConnectionTab.connectionState.Set("Connecting...")
+		conn, err := serialport.OpenConnection(port, app.config.Connection, logger)
 		if err != nil {
 			return nil, err
 		}
 
-		return ssm2.NewConnection(sp, logger), nil
+		app.ConnectionTab.mu.Lock()
+		app.ConnectionTab.activePort = port
+		app.ConnectionTab.reconnectPort = ""
+		app.ConnectionTab.mu.Unlock()
+		return conn, nil
 	}
 	fakeOpenFunc = func(app *App) (ssm2.Connection, error) {
 		return ssm2.NewFakeConnection(time.Millisecond * 50), nil
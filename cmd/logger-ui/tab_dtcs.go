@@ -44,23 +44,24 @@ func (t *DTCsTab) refresh() {
 	defer t.refreshBtn.Enable()
 	t.grid.RemoveAll()
 
-	if t.app.connection == nil {
+	conn := t.app.Connection()
+	if conn == nil {
 		return
 	}
 
 	t.app.LoggingTab.DisableLogging()
 	defer t.app.LoggingTab.EnableLogging()
 
-	setDTCs, err := t.readDTCs(false)
+	setDTCs, err := t.readDTCs(conn, false)
 	if err != nil {
-		logger.Debug(err.Error())
+		logger.Error("reading set DTCs", "error", err)
 		return
 	}
 	sort.Sort(sortableDTCs(setDTCs))
 
-	storedDTCs, err := t.readDTCs(true)
+	storedDTCs, err := t.readDTCs(conn, true)
 	if err != nil {
-		logger.Debug(err.Error())
+		logger.Error("reading stored DTCs", "error", err)
 		return
 	}
 	sort.Sort(sortableDTCs(storedDTCs))
@@ -82,13 +83,13 @@ func (t *DTCsTab) refresh() {
 	t.grid.Refresh()
 }
 
-func (t *DTCsTab) readDTCs(stored bool) ([]ssm2.DTC, error) {
+func (t *DTCsTab) readDTCs(conn ssm2.Connection, stored bool) ([]ssm2.DTC, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
 	if stored {
-		return ssm2.ReadStoredDTCs(ctx, t.app.connection)
+		return ssm2.ReadStoredDTCs(ctx, conn)
 	}
-	return ssm2.ReadSetDTCs(ctx, t.app.connection)
+	return ssm2.ReadSetDTCs(ctx, conn)
 }
 
 type sortableDTCs []ssm2.DTC
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/gavinwade12/ecLogger/formula"
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+// formulaPreviewProcessor is the key of the logging processor that previews the edited formula.
+const formulaPreviewProcessor = "formulaPreview"
+
+// FormulasTab edits the user-defined formulas and previews the edited
+// formula against the values from the current logging session.
+type FormulasTab struct {
+	app *App
+
+	list     *widget.List
+	selected int // the index of the formula being edited, or -1 for a new one
+
+	id          *widget.Entry
+	name        *widget.Entry
+	unit        *widget.SelectEntry
+	description *widget.Entry
+	expression  *widget.Entry
+	status      *widget.Label
+	preview     binding.String
+	deleteBtn   *widget.Button
+	editor      *fyne.Container
+
+	// edited is the formula in the editor compiled with the saved formulas it can
+	// reference, or the error compiling it. They're read by the preview processor.
+	edited    ssm2.DerivedParameter
+	editedErr error
+	editedMu  sync.Mutex
+
+	container fyne.CanvasObject
+}
+
+func NewFormulasTab(app *App) *FormulasTab {
+	t := &FormulasTab{
+		app:         app,
+		selected:    -1,
+		id:          widget.NewEntry(),
+		name:        widget.NewEntry(),
+		unit:        widget.NewSelectEntry(unitOptions()),
+		description: widget.NewEntry(),
+		expression:  widget.NewMultiLineEntry(),
+		status:      NewWrappedLabel(""),
+		preview:     binding.NewString(),
+	}
+
+	t.list = widget.NewList(
+		func() int { return len(t.app.config.Formulas) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(i widget.ListItemID, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(t.app.config.Formulas[i].Name)
+		},
+	)
+	t.list.OnSelected = func(i widget.ListItemID) { t.edit(i) }
+
+	t.id.SetPlaceHolder("e.g. boost_error")
+	t.name.SetPlaceHolder("e.g. Boost Error")
+	t.expression.SetPlaceHolder("e.g. P25[psi] - P7[psi]")
+	t.expression.Wrapping = fyne.TextWrapWord
+	for _, e := range []*widget.Entry{t.id, t.name, t.description, t.expression} {
+		e.OnChanged = func(string) { t.onChanged() }
+	}
+	t.unit.OnChanged = func(string) { t.onChanged() }
+
+	t.deleteBtn = widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), t.onDeleteTapped)
+	form := widget.NewForm(
+		widget.NewFormItem("ID", t.id),
+		widget.NewFormItem("Name", t.name),
+		widget.NewFormItem("Unit", t.unit),
+		widget.NewFormItem("Description", t.description),
+		widget.NewFormItem("Expression", t.expression),
+		widget.NewFormItem("Preview", widget.NewLabelWithData(t.preview)),
+	)
+	help := NewWrappedLabel("Reference parameters by ID, optionally converted to a unit (e.g. P9[mph]). " +
+		"Supports + - * /, comparisons (< <= > >= == !=), && || !, and the functions " +
+		"min(a, b, ...), max(a, b, ...), abs(a), and if(condition, then, else).")
+	t.editor = container.NewVBox(
+		form,
+		t.status,
+		container.NewHBox(
+			widget.NewButtonWithIcon("Save", theme.DocumentSaveIcon(), t.onSaveTapped),
+			t.deleteBtn,
+		),
+		help,
+	)
+	t.editor.Hide()
+
+	t.container = container.NewBorder(nil, nil,
+		container.NewBorder(
+			widget.NewButtonWithIcon("New", theme.ContentAddIcon(), t.onNewTapped),
+			nil, nil, nil, t.list),
+		nil,
+		container.NewVScroll(t.editor),
+	)
+	return t
+}
+
+func (t *FormulasTab) Container() fyne.CanvasObject {
+	return t.container
+}
+
+// unitOptions returns the known units for the unit selection.
+func unitOptions() []string {
+	seen := map[units.Unit]bool{}
+	params, _ := ssm2.Catalog()
+	for _, p := range params {
+		seen[p.DefaultUnit] = true
+	}
+	for _, u := range units.AllUnits() {
+		seen[u] = true
+	}
+
+	options := make([]string, 0, len(seen))
+	for u := range seen {
+		if u != "" {
+			options = append(options, string(u))
+		}
+	}
+	sort.Strings(options)
+	return options
+}
+
+func (t *FormulasTab) onNewTapped() {
+	t.list.UnselectAll()
+	t.selected = -1
+	t.show(formula.Formula{})
+	t.deleteBtn.Hide()
+}
+
+// edit shows the formula at index i in the editor.
+func (t *FormulasTab) edit(i int) {
+	t.selected = i
+	t.show(t.app.config.Formulas[i])
+	t.deleteBtn.Show()
+}
+
+func (t *FormulasTab) show(f formula.Formula) {
+	t.id.SetText(f.ID)
+	t.name.SetText(f.Name)
+	t.unit.SetText(string(f.Unit))
+	t.description.SetText(f.Description)
+	t.expression.SetText(f.Expression)
+	t.editor.Show()
+	t.onChanged()
+	t.app.LoggingTab.setLoggingProcessor(formulaPreviewProcessor, t.updatePreview)
+}
+
+// current returns the formula in the editor.
+func (t *FormulasTab) current() formula.Formula {
+	return formula.Formula{
+		ID:          strings.TrimSpace(t.id.Text),
+		Name:        strings.TrimSpace(t.name.Text),
+		Description: strings.TrimSpace(t.description.Text),
+		Expression:  t.expression.Text,
+		Unit:        units.Unit(strings.TrimSpace(t.unit.Text)),
+	}
+}
+
+// otherFormulas returns the saved formulas other than the one being edited.
+func (t *FormulasTab) otherFormulas() []formula.Formula {
+	others := make([]formula.Formula, 0, len(t.app.config.Formulas))
+	for i, f := range t.app.config.Formulas {
+		if i != t.selected {
+			others = append(others, f)
+		}
+	}
+	return others
+}
+
+// onChanged compiles the edited formula and updates the formula being previewed.
+func (t *FormulasTab) onChanged() {
+	compiled, err := formula.Compile(append([]formula.Formula{t.current()}, t.otherFormulas()...))
+	t.editedMu.Lock()
+	t.edited, t.editedErr = ssm2.DerivedParameter{}, err
+	if err == nil {
+		t.edited = compiled[0]
+	}
+	t.editedMu.Unlock()
+
+	if err != nil {
+		t.status.SetText(err.Error())
+		t.status.Importance = widget.DangerImportance
+	} else {
+		t.status.SetText("Valid")
+		t.status.Importance = widget.SuccessImportance
+	}
+	t.status.Refresh()
+
+	if t.app.Connection() == nil {
+		t.preview.Set("Connect to preview the value")
+	} else {
+		t.preview.Set("Waiting for values from the live log")
+	}
+}
+
+// updatePreview is a logging processor that evaluates the edited formula against the session's values.
+func (t *FormulasTab) updatePreview(values map[string]ssm2.ParameterValue) {
+	t.editedMu.Lock()
+	p, err := t.edited, t.editedErr
+	t.editedMu.Unlock()
+	if err != nil {
+		t.preview.Set(err.Error())
+		return
+	}
+
+	v, err := formula.Evaluate(p, values, t.app.vehicleSpecs())
+	if err != nil {
+		t.preview.Set(err.Error())
+		return
+	}
+	t.preview.Set(fmt.Sprintf("%s %s", strconv.FormatFloat(float64(v.Value), 'f', 2, 32), v.Unit))
+}
+
+func (t *FormulasTab) onSaveTapped() {
+	f := t.current()
+	formulas := t.otherFormulas()
+	var oldID string
+	if t.selected >= 0 {
+		oldID = t.app.config.Formulas[t.selected].ID
+		// keep the formula's position in the list
+		formulas = append(formulas[:t.selected], append([]formula.Formula{f}, formulas[t.selected:]...)...)
+	} else {
+		formulas = append(formulas, f)
+	}
+
+	if err := t.app.config.SetFormulas(formulas); err != nil {
+		dialog.ShowError(err, t.app.window)
+		return
+	}
+	if oldID != "" && oldID != f.ID {
+		t.app.loggedParams.Remove(oldID)
+	}
+
+	t.list.Refresh()
+	for i, saved := range t.app.config.Formulas {
+		if saved.ID == f.ID {
+			t.list.Select(i)
+		}
+	}
+	t.app.onUserParametersChanged()
+}
+
+func (t *FormulasTab) onDeleteTapped() {
+	if t.selected < 0 {
+		return
+	}
+	f := t.app.config.Formulas[t.selected]
+	dialog.ShowConfirm("Delete Formula", fmt.Sprintf("Delete the %s formula?", f.Name), func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := t.app.config.SetFormulas(t.otherFormulas()); err != nil {
+			dialog.ShowError(err, t.app.window)
+			return
+		}
+		t.app.loggedParams.Remove(f.ID)
+
+		t.list.UnselectAll()
+		t.list.Refresh()
+		t.selected = -1
+		t.editor.Hide()
+		t.app.LoggingTab.removeLoggingProcessor(formulaPreviewProcessor)
+		t.app.onUserParametersChanged()
+	}, t.app.window)
+}
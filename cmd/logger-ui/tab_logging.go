@@ -2,11 +2,7 @@ package main
 
 import (
 	"context"
-	"encoding/hex"
-	"fmt"
 	"io"
-	"os"
-	"path"
 	"sort"
 	"strconv"
 	"strings"
@@ -16,10 +12,15 @@ import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	"github.com/gavinwade12/ecLogger/config"
+	"github.com/gavinwade12/ecLogger/logfile"
 	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+	"github.com/pkg/errors"
 )
 
 type LoggingTab struct {
@@ -48,7 +49,7 @@ func NewLoggingTab(app *App) *LoggingTab {
 		toolbar:           widget.NewToolbar(),
 		startBtn:          widget.NewToolbarAction(theme.MediaPlayIcon(), nil),
 		stopBtn:           widget.NewToolbarAction(theme.MediaStopIcon(), nil),
-		container:         container.New(layout.NewGridLayout(3)),
+		container:         container.New(layout.NewGridLayout(config.DefaultDashboardColumns)),
 		loggingProcessors: map[string]func(map[string]ssm2.ParameterValue){},
 	}
 	loggingTab.startBtn.OnActivated = loggingTab.startFileLogging
@@ -84,42 +85,44 @@ func (t *LoggingTab) DisableLogging() {
 }
 
 func (t *LoggingTab) startFileLogging() {
-	// create the log directory if it doesn't already exist
-	logDir := *t.app.config.LogDirectory
-	if err := os.MkdirAll(logDir, os.ModePerm); err != nil {
-		logger.Debugf("creating directory for file logging: %v\n", err)
+	// open the log file
+	ecu := t.app.ECU()
+	if ecu == nil {
+		logger.Error("opening file for logging", "error", errors.New("not connected to an ECU"))
 		return
 	}
-
-	// determine the log file name
-	logFileName := strings.NewReplacer(
-		"{{romId}}", hex.EncodeToString(t.app.ecu.ROM_ID),
-		"{{timestamp}}", time.Now().Format("20060102_150405"), //yyyyMMdd_hhmmss
-	).Replace(*t.app.config.LogFileNameFormat)
-
-	// open the log file
+	started := time.Now()
+	path := t.app.config.LogFilePath(ecu.ROM_ID, started)
 	var err error
-	t.logFile, err = os.OpenFile(
-		path.Join(logDir, logFileName),
-		os.O_CREATE|os.O_TRUNC|os.O_RDWR, os.ModePerm)
+	t.logFile, err = logfile.Create(path)
 	if err != nil {
-		logger.Debugf("opening file for logging: %v\n", err)
+		logger.Error("opening file for logging", "error", err)
 		return
 	}
+	if err = logfile.WriteMetadata(path, logfile.NewMetadata(t.app.config, ecu, started)); err != nil {
+		logger.Error("writing log file metadata", "error", err)
+	}
 
 	// don't allow parameter changes while logging to file
 	// to keep file results consistent
 	t.app.ParametersTab.toggleParameterChanges(false)
 
 	// write the file header
-	params, derived := t.app.loggedParams.CurrentLists()
-	t.writeLogFileHeader(params, derived)
+	params, derived := t.app.loggedParams.CurrentLists(ecu)
+	w := logfile.NewWriter(t.logFile, logfile.Columns(params, derived, t.app.loggedParams.List()))
+	if err = w.WriteHeader(); err != nil {
+		logger.Error("writing log file header", "error", err)
+	}
 
 	// remove the start button from the toolbar and add the stop button
 	t.toolbar.Items = []widget.ToolbarItem{}
 	t.toolbar.Append(t.stopBtn)
 
-	t.setLoggingProcessor("fileLogging", t.updateFileLogValues(params, derived))
+	t.setLoggingProcessor("fileLogging", func(values map[string]ssm2.ParameterValue) {
+		if err := w.WriteRow(time.Now(), values); err != nil {
+			logger.Error("writing log file row", "error", err)
+		}
+	})
 }
 
 func (t *LoggingTab) stopFileLogging() {
@@ -136,39 +139,10 @@ func (t *LoggingTab) stopFileLogging() {
 	t.toolbar.Append(t.startBtn)
 }
 
-func (t *LoggingTab) writeLogFileHeader(params []ssm2.Parameter, derived []ssm2.DerivedParameter) {
-	t.logFile.Write([]byte("Timestamp,"))
-	loggedParams := t.app.loggedParams.CopyData()
-	for i, p := range params {
-		u := p.DefaultUnit
-		lp := loggedParams[p.Id]
-		if lp != nil {
-			u = lp.Unit
-		}
-		val := fmt.Sprintf("%s (%s)", p.Name, u)
-
-		if len(derived) > 0 || i < len(params)-1 {
-			val += ","
-		} else {
-			val += "\n"
-		}
-		t.logFile.Write([]byte(val))
-	}
-	for i, p := range derived {
-		u := p.DefaultUnit
-		lp := loggedParams[p.Id]
-		if lp != nil {
-			u = lp.Unit
-		}
-		val := fmt.Sprintf("%s (%s)", p.Name, u)
-
-		if i < len(derived)-1 {
-			val += ","
-		} else {
-			val += "\n"
-		}
-		t.logFile.Write([]byte(val))
-	}
+// setDashboardColumns changes the number of columns in the live log.
+func (t *LoggingTab) setDashboardColumns(columns int) {
+	t.container.Layout = layout.NewGridLayout(columns)
+	t.container.Refresh()
 }
 
 func (t *LoggingTab) setLoggingProcessor(key string, p func(map[string]ssm2.ParameterValue)) {
@@ -188,32 +162,81 @@ type liveLogModel struct {
 	Name                string
 	CurrentValueBinding binding.String
 	UnitBinding         binding.String
+	Format              ssm2.Format
 
 	MaxValue        float32
 	MaxValueBinding binding.String
 	MinValue        float32
 	MinValueBinding binding.String
+
+	// Alert highlights the current value when it's outside the thresholds.
+	Alert        config.Alert
+	CurrentLabel *widget.Label
+
+	// Range is the parameter's plausible range, which scales the gauge. Values outside
+	// of it are flagged. Warning highlights values outside of the parameter's normal range
+	// when they aren't outside the alert's thresholds. Both are in the logged unit.
+	Range         ssm2.Range
+	Warning       ssm2.Range
+	Gauge         *widget.ProgressBar
+	GaugeBinding  binding.Float
+	StatusBinding binding.String
 }
 
-func newLiveLogModel(id, name string) *liveLogModel {
+func newLiveLogModel(id, name string, format ssm2.Format, rng, warning ssm2.Range) *liveLogModel {
 	m := &liveLogModel{
 		Id:                  id,
 		Name:                name,
+		Format:              format,
 		CurrentValueBinding: binding.NewString(),
 		UnitBinding:         binding.NewString(),
 		MaxValueBinding:     binding.NewString(),
 		MinValueBinding:     binding.NewString(),
+		Range:               rng,
+		Warning:             warning,
+		GaugeBinding:        binding.NewFloat(),
+		StatusBinding:       binding.NewString(),
 	}
+	m.CurrentLabel = widget.NewLabelWithData(m.CurrentValueBinding)
 	m.CurrentValueBinding.Set("0")
 	m.MaxValueBinding.Set("0")
 	m.MinValueBinding.Set("0")
+
+	if rng.Bounded() {
+		m.Gauge = widget.NewProgressBarWithData(m.GaugeBinding)
+		m.Gauge.Min, m.Gauge.Max = float64(*rng.Min), float64(*rng.Max)
+		m.Gauge.TextFormatter = func() string { return "" } // the value is already displayed
+	}
 	return m
 }
 
 func (m *liveLogModel) Update(val ssm2.ParameterValue) {
-	f := strconv.FormatFloat(float64(val.Value), 'f', 2, 32)
+	f := m.Format.String(val.Value)
 	m.CurrentValueBinding.Set(f)
-	m.UnitBinding.Set(string(val.Unit))
+	if _, ok := m.Format.Label(val.Value); ok {
+		// the label describes the value on its own, e.g. "3rd" instead of "3rd gear"
+		m.UnitBinding.Set("")
+	} else {
+		m.UnitBinding.Set(string(val.Unit))
+	}
+
+	importance := widget.MediumImportance
+	if m.Alert.Triggered(val.Value) {
+		importance = widget.DangerImportance
+	} else if !m.Warning.Contains(val.Value) {
+		importance = widget.WarningImportance
+	}
+	if m.CurrentLabel.Importance != importance {
+		m.CurrentLabel.Importance = importance
+		m.CurrentLabel.Refresh()
+	}
+
+	m.GaugeBinding.Set(float64(val.Value))
+	if m.Range.Contains(val.Value) {
+		m.StatusBinding.Set("")
+	} else {
+		m.StatusBinding.Set("(out of range)")
+	}
 
 	if val.Value > m.MaxValue {
 		m.MaxValue = val.Value
@@ -231,47 +254,75 @@ func (t *LoggingTab) updateLiveLogParameters() {
 
 	t.liveLogModelsMu.Lock()
 	t.liveLogModels = []*liveLogModel{}
-	if t.app.connection == nil {
+	if t.app.Connection() == nil {
 		t.liveLogModelsMu.Unlock()
 		t.container.Refresh()
 		return
 	}
 
+	var (
+		alerts    map[string]config.Alert
+		converter = ssm2.DefaultVehicle().Fuel.Converter()
+	)
+	if v := t.app.vehicle(); v != nil {
+		alerts, converter = v.Alerts, v.Specs.Fuel.Converter()
+	}
+
+	catalog, derivedCatalog := ssm2.Catalog()
 	loggedParams := t.app.loggedParams.CopyData()
 	for id, param := range loggedParams {
 		if !param.LiveLog {
 			continue
 		}
 
-		var name string
+		var (
+			name         string
+			format       ssm2.Format
+			unit         units.Unit
+			rng, warning ssm2.Range
+		)
 		if param.Derived {
-			name = ssm2.DerivedParameters[id].Name
+			p := derivedCatalog[id]
+			name, format, unit, rng, warning = p.Name, p.Format, p.DefaultUnit, p.Range, p.Warning
 		} else {
-			name = ssm2.Parameters[id].Name
+			p := catalog[id]
+			name, format, unit, rng, warning = p.Name, p.Format, p.DefaultUnit, p.Range, p.Warning
+		}
+		if param.Unit != "" {
+			rng, warning = rng.ConvertWith(unit, param.Unit, converter), warning.ConvertWith(unit, param.Unit, converter)
 		}
 
-		t.liveLogModels = append(t.liveLogModels, newLiveLogModel(id, name))
+		m := newLiveLogModel(id, name, format, rng, warning)
+		m.Alert = alerts[id]
+		t.liveLogModels = append(t.liveLogModels, m)
 	}
 	sort.Sort(sortableLiveLogModels(t.liveLogModels))
 	liveLogModelsLen := len(t.liveLogModels)
 	t.liveLogModelsMu.Unlock()
 
 	for _, m := range t.liveLogModels {
+		m := m
 		label := widget.NewLabel(m.Name)
 		label.Wrapping = fyne.TextWrapWord
-		t.container.Objects = append(t.container.Objects,
-			container.NewVBox(
-				label,
-				container.NewHBox(
-					widget.NewLabelWithData(m.CurrentValueBinding),
-					widget.NewLabelWithData(m.UnitBinding),
-				),
-				container.NewHBox(
-					widget.NewLabelWithData(m.MinValueBinding),
-					widget.NewLabel("/"),
-					widget.NewLabelWithData(m.MaxValueBinding),
-				),
-			))
+		box := container.NewVBox(
+			container.NewBorder(nil, nil, nil,
+				widget.NewButtonWithIcon("", theme.WarningIcon(), func() { t.showAlertDialog(m) }),
+				label),
+			container.NewHBox(
+				m.CurrentLabel,
+				widget.NewLabelWithData(m.UnitBinding),
+				widget.NewLabelWithData(m.StatusBinding),
+			),
+			container.NewHBox(
+				widget.NewLabelWithData(m.MinValueBinding),
+				widget.NewLabel("/"),
+				widget.NewLabelWithData(m.MaxValueBinding),
+			),
+		)
+		if m.Gauge != nil {
+			box.Add(m.Gauge)
+		}
+		t.container.Objects = append(t.container.Objects, box)
 	}
 
 	t.container.Refresh()
@@ -281,37 +332,86 @@ func (t *LoggingTab) updateLiveLogParameters() {
 	}
 }
 
+// showAlertDialog lets the user edit the thresholds for the model's parameter,
+// which are saved for the connected vehicle.
+func (t *LoggingTab) showAlertDialog(m *liveLogModel) {
+	min, max := widget.NewEntry(), widget.NewEntry()
+	for _, e := range []struct {
+		entry *widget.Entry
+		value *float32
+	}{{min, m.Alert.Min}, {max, m.Alert.Max}} {
+		if e.value != nil {
+			e.entry.SetText(formatFloat(*e.value))
+		}
+		e.entry.SetPlaceHolder("None")
+		e.entry.Validator = func(s string) error {
+			_, err := parseThreshold(s)
+			return err
+		}
+	}
+
+	dialog.ShowForm(m.Name+" Alert", "Save", "Cancel", []*widget.FormItem{
+		widget.NewFormItem("Minimum", min),
+		widget.NewFormItem("Maximum", max),
+	}, func(ok bool) {
+		v := t.app.vehicle()
+		if !ok || v == nil {
+			return
+		}
+
+		var a config.Alert
+		a.Min, _ = parseThreshold(min.Text)
+		a.Max, _ = parseThreshold(max.Text)
+		v.SetAlert(m.Id, a)
+
+		t.liveLogModelsMu.Lock()
+		m.Alert = a
+		t.liveLogModelsMu.Unlock()
+	}, t.app.window)
+}
+
+// parseThreshold parses an alert threshold, returning nil when s is empty.
+func parseThreshold(s string) (*float32, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return nil, errors.New("must be a number")
+	}
+	f32 := float32(f)
+	return &f32, nil
+}
+
 func (t *LoggingTab) openLoggingSession(ctx context.Context) {
+	conn, ecu := t.app.Connection(), t.app.ECU()
+	if conn == nil || ecu == nil {
+		return
+	}
+
 	var (
 		session               <-chan map[string]ssm2.ParameterValue
 		err                   error
-		params, derivedParams = t.app.loggedParams.CurrentLists()
+		params, derivedParams = t.app.loggedParams.CurrentLists(ecu)
+		specs                 = ssm2.DefaultVehicle()
 	)
+	if v := t.app.vehicleOf(ecu); v != nil {
+		specs = v.Specs
+	}
 	for {
-		session, err = ssm2.LoggingSession(ctx, t.app.connection, params, derivedParams)
+		session, err = ssm2.LoggingSessionForVehicle(ctx, conn, params, derivedParams, specs)
 		if err == nil {
 			break
 		}
 
-		logger.Debug(err.Error())
+		logger.Warn("opening logging session", "error", err)
 	}
 
+	converter := specs.Fuel.Converter()
 	for result := range session {
 		// convert the result values to the configured units
-		loggedParams := t.app.loggedParams.CopyData()
-		for id, val := range result {
-			lp := loggedParams[id]
-			if lp == nil || lp.Unit == val.Unit {
-				continue
-			}
-
-			vval, err := val.ConvertTo(lp.Unit)
-			if err != nil {
-				logger.Debugf("converting %s from %s to %s: %v\n", id, val.Unit, lp.Unit, err)
-				continue
-			}
-			result[id] = *vval
-		}
+		logfile.ConvertUnits(result, t.app.loggedParams.List(), converter, logger)
 
 		t.loggingProcessorsMu.Lock()
 		for _, p := range t.loggingProcessors {
@@ -339,35 +439,12 @@ func (t *LoggingTab) onLoggedParametersChanged() {
 func (t *LoggingTab) updateLiveLogModelValues(values map[string]ssm2.ParameterValue) {
 	t.liveLogModelsMu.Lock()
 	for _, m := range t.liveLogModels {
-		m.Update(values[m.Id])
-	}
-	t.liveLogModelsMu.Unlock()
-}
-
-func (t *LoggingTab) updateFileLogValues(params []ssm2.Parameter, derived []ssm2.DerivedParameter) func(values map[string]ssm2.ParameterValue) {
-	order := make([]string, len(params)+len(derived))
-	i := 0
-	for _, p := range params {
-		order[i] = p.Id
-		i++
-	}
-	for _, p := range derived {
-		order[i] = p.Id
-		i++
-	}
-
-	return func(values map[string]ssm2.ParameterValue) {
-		t.logFile.Write([]byte(time.Now().Format("2006-01-02 15:04:05.999999999") + ",")) // yyyy-MM-dd hh:mm:ss
-		for i, id := range order {
-			val := strconv.FormatFloat(float64(values[id].Value), 'f', 4, 32)
-			if i < len(order)-1 {
-				val += ","
-			} else {
-				val += "\n"
-			}
-			t.logFile.Write([]byte(val))
+		// keep showing the last value when one is missing, e.g. it couldn't be converted
+		if v, ok := values[m.Id]; ok {
+			m.Update(v)
 		}
 	}
+	t.liveLogModelsMu.Unlock()
 }
 
 type sortableLiveLogModels []*liveLogModel
@@ -1,34 +1,212 @@
 package main
 
 import (
+	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	"github.com/gavinwade12/ecLogger/config"
 	"github.com/gavinwade12/ecLogger/protocols/ssm2"
 	"github.com/gavinwade12/ecLogger/units"
 )
 
+// parameterColumns is the number of columns in each parameter's row.
+const parameterColumns = 5
+
+// priorityOptions are the labels for the named sample priorities.
+var priorityOptions = []string{"High", "Normal", "Low"}
+
+const (
+	// allCategories is the category filter option that shows every category.
+	allCategories = "All Categories"
+	// favoritesTitle is the title of the item pinned above the categories.
+	favoritesTitle = "Favorites"
+)
+
 type ParametersTab struct {
 	app *App
 
-	layout    fyne.Layout
-	container *fyne.Container
+	profileSelect *widget.Select
+	profileBar    *fyne.Container
+
+	// params are the available parameters, which are shown when they match the filter.
+	params      []parameterModel
+	search      *widget.Entry
+	loggedOnly  *widget.Check
+	derivedOnly *widget.Check
+	category    *widget.Select
+	filterBar   *fyne.Container
+
+	// categories has an item for each category with available parameters,
+	// and grids has each item's grid of parameter rows.
+	categories *widget.Accordion
+	grids      []*fyne.Container
+	// parametersLocked disables changes to the logged parameters, including in
+	// grids built after toggleParameterChanges, e.g. while logging to a file.
+	parametersLocked bool
 }
 
 func NewParametersTab(app *App) *ParametersTab {
-	paramsLayout := layout.NewGridLayoutWithColumns(4)
-	return &ParametersTab{
-		app:       app,
-		layout:    paramsLayout,
-		container: container.New(paramsLayout),
+	t := &ParametersTab{
+		app:        app,
+		categories: widget.NewAccordion(),
 	}
+	t.categories.MultiOpen = true
+
+	t.search = widget.NewEntry()
+	t.search.SetPlaceHolder("Search by name, ID, or description")
+	t.search.OnChanged = func(string) { t.refreshParameters() }
+	t.loggedOnly = widget.NewCheck("Logged", func(bool) { t.refreshParameters() })
+	t.derivedOnly = widget.NewCheck("Derived", func(bool) { t.refreshParameters() })
+	categoryOptions := []string{allCategories}
+	for _, c := range ssm2.Categories {
+		categoryOptions = append(categoryOptions, string(c))
+	}
+	t.category = widget.NewSelect(categoryOptions, func(string) { t.refreshParameters() })
+	t.category.Selected = allCategories
+	t.filterBar = container.NewBorder(nil, nil, nil,
+		container.NewHBox(t.loggedOnly, t.derivedOnly, t.category),
+		t.search,
+	)
+
+	t.profileSelect = widget.NewSelect(nil, func(s string) {
+		if s != t.app.config.Logging.Profile {
+			t.useProfile(s)
+		}
+	})
+	t.refreshProfiles()
+	t.profileBar = container.NewBorder(nil, nil,
+		widget.NewLabel("Profile"),
+		container.NewHBox(
+			widget.NewButtonWithIcon("New", theme.ContentAddIcon(), t.onNewProfileTapped),
+			widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), t.onDeleteProfileTapped),
+			widget.NewButtonWithIcon("Import", theme.FolderOpenIcon(), t.onImportProfileTapped),
+			widget.NewButtonWithIcon("Export", theme.DocumentSaveIcon(), t.onExportProfileTapped),
+		),
+		t.profileSelect,
+	)
+
+	return t
 }
 
 func (t *ParametersTab) Container() fyne.CanvasObject {
-	return container.NewVScroll(t.container)
+	return container.NewBorder(container.NewVBox(t.profileBar, t.filterBar), nil, nil, nil,
+		container.NewVScroll(t.categories))
+}
+
+// refreshProfiles updates the profile options and selects the active profile.
+func (t *ParametersTab) refreshProfiles() {
+	t.profileSelect.Options = t.app.config.Logging.ProfileNames()
+	t.profileSelect.Selected = t.app.config.Logging.ActiveProfile().Name
+	t.profileSelect.Refresh()
+}
+
+// useProfile saves the current logged params to the active profile
+// and replaces them with the params from the named profile.
+func (t *ParametersTab) useProfile(name string) {
+	t.app.saveLoggedParams()
+	if err := t.app.config.Logging.UseProfile(name); err != nil {
+		logger.Warn("selecting profile", "profile", name, "error", err)
+		return
+	}
+	params := append([]config.Parameter{}, t.app.config.Logging.ActiveProfile().Parameters...)
+	if v := t.app.vehicle(); v != nil {
+		v.Profile = name
+		v.ApplyUnits(params)
+	}
+	t.app.loggedParams.Set(params)
+
+	t.refreshProfiles()
+	t.setAvailableParameters(t.app.ECU())
+	t.app.LoggingTab.onLoggedParametersChanged()
+	t.app.LoggingTab.updateLiveLogParameters()
+}
+
+func (t *ParametersTab) onNewProfileTapped() {
+	name := widget.NewEntry()
+	dialog.ShowForm("New Profile", "Create", "Cancel",
+		[]*widget.FormItem{widget.NewFormItem("Name", name)},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			t.app.saveLoggedParams()
+			if err := t.app.config.Logging.AddProfile(config.Profile{Name: name.Text}); err != nil {
+				dialog.ShowError(err, t.app.window)
+				return
+			}
+			t.useProfile(strings.TrimSpace(name.Text))
+		}, t.app.window)
+}
+
+func (t *ParametersTab) onDeleteProfileTapped() {
+	name := t.app.config.Logging.Profile
+	dialog.ShowConfirm("Delete Profile", fmt.Sprintf("Delete the %s profile?", name), func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := t.app.config.Logging.RemoveProfile(name); err != nil {
+			dialog.ShowError(err, t.app.window)
+			return
+		}
+
+		// don't save the deleted profile's params to the newly active profile
+		t.app.loggedParams.Set(t.app.config.Logging.ActiveProfile().Parameters)
+		t.useProfile(t.app.config.Logging.Profile)
+	}, t.app.window)
+}
+
+func (t *ParametersTab) onImportProfileTapped() {
+	dialog.ShowFileOpen(func(r fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, t.app.window)
+			return
+		}
+		if r == nil {
+			return // canceled
+		}
+		defer r.Close()
+
+		p, err := config.ImportProfile(r)
+		if err == nil {
+			t.app.saveLoggedParams()
+			err = t.app.config.Logging.AddProfile(*p)
+		}
+		if err != nil {
+			dialog.ShowError(err, t.app.window)
+			return
+		}
+		t.useProfile(p.Name)
+	}, t.app.window)
+}
+
+func (t *ParametersTab) onExportProfileTapped() {
+	t.app.saveLoggedParams()
+	p := *t.app.config.Logging.ActiveProfile()
+
+	save := dialog.NewFileSave(func(w fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, t.app.window)
+			return
+		}
+		if w == nil {
+			return // canceled
+		}
+		defer w.Close()
+
+		if err = config.ExportProfile(w, p); err != nil {
+			dialog.ShowError(err, t.app.window)
+		}
+	}, t.app.window)
+	save.SetFileName(p.Name + ".yaml")
+	save.Show()
 }
 
 func (t *ParametersTab) setAvailableParameters(ecu *ssm2.ECU) {
@@ -44,6 +222,7 @@ func (t *ParametersTab) setAvailableParameters(ecu *ssm2.ECU) {
 			Name:        p.Name,
 			Description: p.Description,
 			Unit:        p.DefaultUnit,
+			Category:    categoryOrOther(p.Category),
 			Derived:     false,
 		}
 		i++
@@ -54,44 +233,129 @@ func (t *ParametersTab) setAvailableParameters(ecu *ssm2.ECU) {
 			Name:        p.Name,
 			Description: p.Description,
 			Unit:        p.DefaultUnit,
+			Category:    categoryOrOther(p.Category),
 			Derived:     true,
 		}
 		i++
 	}
 	sort.Sort(sortableParameters(params))
+	t.params = params
+	t.refreshParameters()
+}
 
-	t.container.RemoveAll()
+// filter returns the filter selected in the filter bar.
+func (t *ParametersTab) filter() parameterFilter {
+	f := parameterFilter{
+		Search:      t.search.Text,
+		LoggedOnly:  t.loggedOnly.Checked,
+		DerivedOnly: t.derivedOnly.Checked,
+	}
+	if t.category.Selected != allCategories {
+		f.Category = ssm2.Category(t.category.Selected)
+	}
+	return f
+}
 
+// refreshParameters shows the available parameters matching the filter, with
+// the favorites pinned above the categories.
+func (t *ParametersTab) refreshParameters() {
+	filter := t.filter()
 	loggedParams := t.app.loggedParams.CopyData()
+
+	var favorites []parameterModel
+	byCategory := map[ssm2.Category][]parameterModel{}
+	for _, p := range t.params {
+		if !filter.matches(p, loggedParams[p.Id] != nil) {
+			continue
+		}
+		if t.app.config.UI.IsFavorite(p.Id) {
+			favorites = append(favorites, p)
+		} else {
+			byCategory[p.Category] = append(byCategory[p.Category], p)
+		}
+	}
+
+	// keep the open items open, and open every item with search results
+	open := map[string]bool{}
+	for _, item := range t.categories.Items {
+		open[item.Title] = item.Open
+	}
+	t.categories.Items = nil
+	t.grids = nil
+	add := func(title string, params []parameterModel) {
+		if len(params) == 0 {
+			return
+		}
+		grid := t.parameterGrid(params, loggedParams)
+		if t.parametersLocked {
+			traverseObjectAndToggle(false, grid)
+		}
+		item := widget.NewAccordionItem(title, grid)
+		wasOpen, ok := open[title]
+		item.Open = wasOpen || (!ok && title == favoritesTitle) || strings.TrimSpace(filter.Search) != ""
+		t.categories.Items = append(t.categories.Items, item)
+		t.grids = append(t.grids, grid)
+	}
+	add(favoritesTitle, favorites)
+	for _, c := range ssm2.Categories {
+		add(string(c), byCategory[c])
+	}
+	t.categories.Refresh()
+}
+
+// parameterGrid returns a grid with a row for each parameter.
+func (t *ParametersTab) parameterGrid(params []parameterModel, loggedParams map[string]*LoggedParam) *fyne.Container {
+	grid := container.New(layout.NewGridLayoutWithColumns(parameterColumns))
 	for _, param := range params {
 		param := param
 
-		options := make([]string, 1+len(units.UnitConversions[param.Unit]))
-		options[0] = string(param.Unit)
-		i := 1
-		for u := range units.UnitConversions[param.Unit] {
-			options[i] = string(u)
-			i++
+		options := []string{string(param.Unit)}
+		for _, u := range units.ConvertibleUnits(param.Unit) {
+			options = append(options, string(u))
 		}
 
+		preferred := t.app.config.Units.Preferred(param.Unit)
+		// unitOverride is the selected unit when it isn't the unit system's
+		unitOverride := func(s string) units.Unit {
+			if units.Unit(s) == preferred {
+				return ""
+			}
+			return units.Unit(s)
+		}
 		unit := widget.NewSelect(options, func(s string) {
 			lp := t.app.loggedParams.Get(param.Id)
 			if lp != nil {
-				lp.Unit = units.Unit(s)
+				lp.Unit, lp.UnitOverride = units.Unit(s), unitOverride(s)
+			}
+			if v := t.app.vehicle(); v != nil {
+				t.app.config.SetVehicleUnit(v, config.Parameter{ID: param.Id, Derived: param.Derived}, units.Unit(s))
 			}
 		})
 		lp := loggedParams[param.Id]
 		if lp != nil {
 			unit.Selected = string(lp.Unit)
 		} else {
-			unit.Selected = options[0]
+			unit.Selected = string(preferred)
+		}
+
+		priority := widget.NewSelect(priorityOptions, func(s string) {
+			lp := t.app.loggedParams.Get(param.Id)
+			if lp != nil {
+				lp.Priority = priorityFromLabel(s)
+			}
+		})
+		if lp != nil {
+			priority.Selected = priorityLabel(lp.Priority)
+		} else {
+			priority.Selected = priorityLabel(config.PriorityNormal)
 		}
 
 		fileLogCheck := widget.NewCheck("Log To File", func(b bool) {
 			if b {
 				t.app.loggedParams.UpdateOrAdd(param.Id, func(lp *LoggedParam) {
 					lp.LogToFile = true
-				}, &LoggedParam{Derived: param.Derived, LogToFile: true, Unit: units.Unit(unit.Selected)})
+				}, &LoggedParam{Derived: param.Derived, LogToFile: true, Unit: units.Unit(unit.Selected),
+					UnitOverride: unitOverride(unit.Selected), Priority: priorityFromLabel(priority.Selected)})
 			} else {
 				lp := t.app.loggedParams.Get(param.Id)
 				if lp != nil && lp.LiveLog {
@@ -108,7 +372,8 @@ func (t *ParametersTab) setAvailableParameters(ecu *ssm2.ECU) {
 			if b {
 				t.app.loggedParams.UpdateOrAdd(param.Id, func(lp *LoggedParam) {
 					lp.LiveLog = true
-				}, &LoggedParam{Derived: param.Derived, LiveLog: true, Unit: units.Unit(unit.Selected)})
+				}, &LoggedParam{Derived: param.Derived, LiveLog: true, Unit: units.Unit(unit.Selected),
+					UnitOverride: unitOverride(unit.Selected), Priority: priorityFromLabel(priority.Selected)})
 			} else {
 				lp := t.app.loggedParams.Get(param.Id)
 				if lp != nil && lp.LogToFile {
@@ -125,25 +390,31 @@ func (t *ParametersTab) setAvailableParameters(ecu *ssm2.ECU) {
 		fileLogCheck.Checked = loggedParams[param.Id] != nil && loggedParams[param.Id].LogToFile
 		liveLogCheck.Checked = loggedParams[param.Id] != nil && loggedParams[param.Id].LiveLog
 
-		t.container.Objects = append(t.container.Objects,
-			NewWrappedLabel(param.Name),
+		favorite := widget.NewButtonWithIcon("", favoriteIcon(t.app.config.UI.IsFavorite(param.Id)), func() {
+			t.app.config.UI.SetFavorite(param.Id, !t.app.config.UI.IsFavorite(param.Id))
+			t.refreshParameters()
+		})
+		favorite.Importance = widget.LowImportance
+
+		grid.Objects = append(grid.Objects,
+			container.NewBorder(nil, nil, favorite, nil, NewWrappedLabel(param.Name)),
 			container.NewCenter(fileLogCheck),
 			container.NewCenter(liveLogCheck),
 			container.NewCenter(unit),
+			container.NewCenter(priority),
 		)
 	}
 
-	t.container.Resize(t.layout.MinSize(t.container.Objects))
-	t.container.Refresh()
+	return grid
 }
 
+// toggleParameterChanges enables or disables changes to the logged parameters,
+// the active profile, and the favorites.
 func (t *ParametersTab) toggleParameterChanges(enable bool) {
-	for i, o := range t.container.Objects {
-		if i%4 == 0 {
-			continue // skip the first column since it's just text
-		}
-
-		traverseObjectAndToggle(enable, o)
+	t.parametersLocked = !enable
+	traverseObjectAndToggle(enable, t.profileBar)
+	for _, grid := range t.grids {
+		traverseObjectAndToggle(enable, grid)
 	}
 }
 
@@ -166,12 +437,67 @@ func toggleEnable(enable bool, o fyne.Disableable) {
 	}
 }
 
+// priorityLabel returns the label for the priority, or the number for unnamed priorities.
+func priorityLabel(p int) string {
+	switch p {
+	case config.PriorityHigh:
+		return "High"
+	case config.PriorityNormal:
+		return "Normal"
+	case config.PriorityLow:
+		return "Low"
+	}
+	return strconv.Itoa(p)
+}
+
+func priorityFromLabel(s string) int {
+	switch s {
+	case "High":
+		return config.PriorityHigh
+	case "Low":
+		return config.PriorityLow
+	}
+	return config.PriorityNormal
+}
+
+// categoryOrOther returns the category, or the Other category when it's empty.
+func categoryOrOther(c ssm2.Category) ssm2.Category {
+	if c == "" {
+		return ssm2.CategoryOther
+	}
+	return c
+}
+
+// parameterFilter selects the parameters shown in the Parameters tab.
+type parameterFilter struct {
+	Search      string
+	LoggedOnly  bool
+	DerivedOnly bool
+	Category    ssm2.Category // empty for every category
+}
+
+// matches returns true if the parameter matches the filter. Every word in the
+// search has to be in the parameter's name, ID, or description.
+func (f parameterFilter) matches(p parameterModel, logged bool) bool {
+	if (f.LoggedOnly && !logged) || (f.DerivedOnly && !p.Derived) || (f.Category != "" && p.Category != f.Category) {
+		return false
+	}
+	text := strings.ToLower(p.Name + " " + p.Id + " " + p.Description)
+	for _, word := range strings.Fields(strings.ToLower(f.Search)) {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
 type parameterModel struct {
 	Id          string
 	Name        string
 	Description string
 	Derived     bool
 	Unit        units.Unit
+	Category    ssm2.Category
 }
 
 type sortableParameters []parameterModel
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/gavinwade12/ecLogger/formula"
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+	"github.com/pkg/errors"
+)
+
+// The byte orders of a RAM parameter's value.
+const (
+	bigEndian    = "Big Endian"
+	littleEndian = "Little Endian"
+)
+
+// RAMTab edits the custom parameters read from RAM addresses and previews
+// the value decoded from bytes entered for testing.
+type RAMTab struct {
+	app *App
+
+	list     *widget.List
+	selected int // the index of the parameter being edited, or -1 for a new one
+
+	id          *widget.Entry
+	name        *widget.Entry
+	address     *widget.Entry
+	length      *widget.Select
+	byteOrder   *widget.RadioGroup
+	signed      *widget.Check
+	mask        *widget.Entry
+	expression  *widget.Entry
+	unit        *widget.SelectEntry
+	description *widget.Entry
+	testBytes   *widget.Entry
+	preview     *widget.Label
+	status      *widget.Label
+	deleteBtn   *widget.Button
+	editor      *fyne.Container
+
+	container fyne.CanvasObject
+}
+
+func NewRAMTab(app *App) *RAMTab {
+	t := &RAMTab{
+		app:         app,
+		selected:    -1,
+		id:          widget.NewEntry(),
+		name:        widget.NewEntry(),
+		address:     widget.NewEntry(),
+		signed:      widget.NewCheck("Signed", nil),
+		mask:        widget.NewEntry(),
+		expression:  widget.NewEntry(),
+		unit:        widget.NewSelectEntry(unitOptions()),
+		description: widget.NewEntry(),
+		testBytes:   widget.NewEntry(),
+		preview:     widget.NewLabel(""),
+		status:      NewWrappedLabel(""),
+	}
+
+	t.list = widget.NewList(
+		func() int { return len(t.app.config.RAMParameters) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(i widget.ListItemID, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(t.app.config.RAMParameters[i].Name)
+		},
+	)
+	t.list.OnSelected = func(i widget.ListItemID) { t.edit(i) }
+
+	t.id.SetPlaceHolder("e.g. boost_target")
+	t.name.SetPlaceHolder("e.g. Boost Target")
+	t.address.SetPlaceHolder("e.g. FF6B1C")
+	t.mask.SetPlaceHolder("Every bit, or e.g. 0x80")
+	t.expression.SetPlaceHolder("The raw value x, or e.g. x * 0.01 - 40")
+	t.testBytes.SetPlaceHolder("e.g. 03 E8")
+	for _, e := range []*widget.Entry{t.id, t.name, t.address, t.mask, t.expression, t.description, t.testBytes} {
+		e.OnChanged = func(string) { t.onChanged() }
+	}
+	t.unit.OnChanged = func(string) { t.onChanged() }
+	t.signed.OnChanged = func(bool) { t.onChanged() }
+	t.length = widget.NewSelect([]string{"1", "2", "3", "4"}, func(string) { t.onChanged() })
+	t.byteOrder = widget.NewRadioGroup([]string{bigEndian, littleEndian}, func(string) { t.onChanged() })
+	t.byteOrder.Horizontal = true
+	t.byteOrder.Required = true
+
+	t.deleteBtn = widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), t.onDeleteTapped)
+	form := widget.NewForm(
+		widget.NewFormItem("ID", t.id),
+		widget.NewFormItem("Name", t.name),
+		widget.NewFormItem("Address", t.address),
+		widget.NewFormItem("Length (bytes)", t.length),
+		widget.NewFormItem("Byte Order", t.byteOrder),
+		widget.NewFormItem("", t.signed),
+		widget.NewFormItem("Mask", t.mask),
+		widget.NewFormItem("Expression", t.expression),
+		widget.NewFormItem("Unit", t.unit),
+		widget.NewFormItem("Description", t.description),
+		widget.NewFormItem("Test Bytes", t.testBytes),
+		widget.NewFormItem("Preview", t.preview),
+	)
+	help := NewWrappedLabel("The value's bytes are read starting at the address and combined in the byte order. " +
+		"The mask selects the value's bits, which are shifted down to the mask's lowest bit. " +
+		"The expression calculates the value from the raw value x using the same syntax as formulas. " +
+		"Enter bytes to test to preview their value. Add a saved parameter to the logged parameters to log it.")
+	t.editor = container.NewVBox(
+		form,
+		t.status,
+		container.NewHBox(
+			widget.NewButtonWithIcon("Save", theme.DocumentSaveIcon(), t.onSaveTapped),
+			t.deleteBtn,
+		),
+		help,
+	)
+	t.editor.Hide()
+
+	t.container = container.NewBorder(nil, nil,
+		container.NewBorder(
+			widget.NewButtonWithIcon("New", theme.ContentAddIcon(), t.onNewTapped),
+			nil, nil, nil, t.list),
+		nil,
+		container.NewVScroll(t.editor),
+	)
+	return t
+}
+
+func (t *RAMTab) Container() fyne.CanvasObject {
+	return t.container
+}
+
+func (t *RAMTab) onNewTapped() {
+	t.list.UnselectAll()
+	t.selected = -1
+	t.show(formula.RAMParameter{Length: 1})
+	t.deleteBtn.Hide()
+}
+
+// edit shows the parameter at index i in the editor.
+func (t *RAMTab) edit(i int) {
+	t.selected = i
+	t.show(t.app.config.RAMParameters[i])
+	t.deleteBtn.Show()
+}
+
+func (t *RAMTab) show(p formula.RAMParameter) {
+	t.id.SetText(p.ID)
+	t.name.SetText(p.Name)
+	t.address.SetText(p.Address)
+	t.length.SetSelected(strconv.Itoa(p.Length))
+	if p.LittleEndian {
+		t.byteOrder.SetSelected(littleEndian)
+	} else {
+		t.byteOrder.SetSelected(bigEndian)
+	}
+	t.signed.SetChecked(p.Signed)
+	if p.Mask != 0 {
+		t.mask.SetText(fmt.Sprintf("0x%X", p.Mask))
+	} else {
+		t.mask.SetText("")
+	}
+	t.expression.SetText(p.Expression)
+	t.unit.SetText(string(p.Unit))
+	t.description.SetText(p.Description)
+	t.testBytes.SetText("")
+	t.editor.Show()
+	t.onChanged()
+}
+
+// current returns the parameter in the editor, or an error if the mask can't be parsed.
+func (t *RAMTab) current() (formula.RAMParameter, error) {
+	p := formula.RAMParameter{
+		ID:           strings.TrimSpace(t.id.Text),
+		Name:         strings.TrimSpace(t.name.Text),
+		Description:  strings.TrimSpace(t.description.Text),
+		Address:      strings.ToUpper(strings.TrimSpace(t.address.Text)),
+		LittleEndian: t.byteOrder.Selected == littleEndian,
+		Signed:       t.signed.Checked,
+		Expression:   strings.TrimSpace(t.expression.Text),
+		Unit:         units.Unit(strings.TrimSpace(t.unit.Text)),
+	}
+	p.Length, _ = strconv.Atoi(t.length.Selected)
+
+	if mask := strings.TrimSpace(t.mask.Text); mask != "" {
+		m, err := strconv.ParseUint(mask, 0, 32)
+		if err != nil {
+			return p, errors.New("the mask must be a number, e.g. 0x80")
+		}
+		p.Mask = uint32(m)
+	}
+	return p, nil
+}
+
+// otherParameters returns the saved parameters other than the one being edited.
+func (t *RAMTab) otherParameters() []formula.RAMParameter {
+	others := make([]formula.RAMParameter, 0, len(t.app.config.RAMParameters))
+	for i, p := range t.app.config.RAMParameters {
+		if i != t.selected {
+			others = append(others, p)
+		}
+	}
+	return others
+}
+
+// onChanged validates the edited parameter and previews the value of the test bytes.
+func (t *RAMTab) onChanged() {
+	var compiled []ssm2.Parameter
+	p, err := t.current()
+	if err == nil {
+		compiled, err = formula.CompileRAMParameters(append([]formula.RAMParameter{p}, t.otherParameters()...))
+	}
+	if err != nil {
+		t.status.SetText(err.Error())
+		t.status.Importance = widget.DangerImportance
+	} else {
+		t.status.SetText("Valid")
+		t.status.Importance = widget.SuccessImportance
+	}
+	t.status.Refresh()
+
+	if err != nil {
+		t.preview.SetText("Fix the parameter to preview its value")
+		return
+	}
+	t.preview.SetText(t.previewText(p, compiled[0]))
+}
+
+// previewText returns the value of the test bytes decoded by the compiled parameter.
+func (t *RAMTab) previewText(p formula.RAMParameter, param ssm2.Parameter) string {
+	text := strings.Join(strings.Fields(t.testBytes.Text), "")
+	if text == "" {
+		return "Enter bytes to test"
+	}
+	b, err := hex.DecodeString(text)
+	if err != nil {
+		return "The test bytes must be hex, e.g. 03 E8"
+	}
+	if len(b) != p.Length {
+		return fmt.Sprintf("Enter %d byte(s)", p.Length)
+	}
+
+	v := param.Value(b)
+	return fmt.Sprintf("%s %s (raw %s)", param.Format.String(v.Value), v.Unit,
+		strconv.FormatFloat(p.Raw(b), 'f', -1, 64))
+}
+
+func (t *RAMTab) onSaveTapped() {
+	p, err := t.current()
+	if err != nil {
+		dialog.ShowError(err, t.app.window)
+		return
+	}
+	params := t.otherParameters()
+	var oldID string
+	if t.selected >= 0 {
+		oldID = t.app.config.RAMParameters[t.selected].ID
+		// keep the parameter's position in the list
+		params = append(params[:t.selected], append([]formula.RAMParameter{p}, params[t.selected:]...)...)
+	} else {
+		params = append(params, p)
+	}
+
+	if err := t.app.config.SetRAMParameters(params); err != nil {
+		dialog.ShowError(err, t.app.window)
+		return
+	}
+	if oldID != "" && oldID != p.ID {
+		t.app.loggedParams.Remove(oldID)
+	}
+
+	t.list.Refresh()
+	for i, saved := range t.app.config.RAMParameters {
+		if saved.ID == p.ID {
+			t.list.Select(i)
+		}
+	}
+	t.app.onUserParametersChanged()
+}
+
+func (t *RAMTab) onDeleteTapped() {
+	if t.selected < 0 {
+		return
+	}
+	p := t.app.config.RAMParameters[t.selected]
+	dialog.ShowConfirm("Delete RAM Parameter", fmt.Sprintf("Delete the %s parameter?", p.Name), func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := t.app.config.SetRAMParameters(t.otherParameters()); err != nil {
+			dialog.ShowError(err, t.app.window)
+			return
+		}
+		t.app.loggedParams.Remove(p.ID)
+
+		t.list.UnselectAll()
+		t.list.Refresh()
+		t.selected = -1
+		t.editor.Hide()
+		t.app.onUserParametersChanged()
+	}, t.app.window)
+}
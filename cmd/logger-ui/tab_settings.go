@@ -2,12 +2,19 @@ package main
 
 import (
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
+	"github.com/gavinwade12/ecLogger/config"
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
 )
 
 type SettingsTab struct {
-	form *widget.Form
+	form      *widget.Form
+	logViewer *widget.TextGrid
+	container fyne.CanvasObject
 }
 
 // a package-level variable so extras can be added in a dev build
@@ -15,15 +22,159 @@ var settingsFormItems = []func(app *App) []*widget.FormItem{
 	func(app *App) []*widget.FormItem {
 		return []*widget.FormItem{
 			widget.NewFormItem("Log Directory", widget.NewEntryWithData(
-				binding.BindString(app.config.LogDirectory))),
+				binding.BindString(&app.config.Logging.Directory))),
 			widget.NewFormItem("Log File Name Format", widget.NewEntryWithData(
-				binding.BindString(app.config.LogFileNameFormat))),
+				binding.BindString(&app.config.Logging.FileNameFormat))),
 			widget.NewFormItem("Auto Connect", widget.NewCheckWithData("",
-				binding.BindBool(&app.config.AutoConnect))),
+				binding.BindBool(&app.config.UI.AutoConnect))),
 			widget.NewFormItem("Default to Logging Tab", widget.NewCheckWithData(
-				"", binding.BindBool(&app.config.DefaultToLoggingTab))),
+				"", binding.BindBool(&app.config.UI.DefaultToLoggingTab))),
 		}
 	},
+	unitFormItems,
+	connectionFormItems,
+	logLevelFormItems,
+}
+
+// unitSystemLabels are the labels for the unit systems.
+var unitSystemLabels = map[string]string{
+	config.UnitSystemDefault:  "Parameter Defaults",
+	config.UnitSystemMetric:   "Metric",
+	config.UnitSystemImperial: "Imperial",
+	config.UnitSystemCustom:   "Custom",
+}
+
+// unitFormItems returns the form item for the unit system, which is applied to every parameter.
+func unitFormItems(app *App) []*widget.FormItem {
+	options := make([]string, len(config.UnitSystems))
+	systems := make(map[string]string, len(config.UnitSystems))
+	for i, s := range config.UnitSystems {
+		options[i] = unitSystemLabels[s]
+		systems[options[i]] = s
+	}
+
+	system := widget.NewSelect(options, nil)
+	selectCurrent := func() {
+		s := app.config.Units.System
+		if s == "" {
+			s = config.UnitSystemDefault
+		}
+		system.SetSelected(unitSystemLabels[s])
+	}
+	selectCurrent()
+
+	customBtn := widget.NewButton("Edit Custom Units", func() { showCustomUnitsDialog(app, selectCurrent) })
+	system.OnChanged = func(s string) {
+		p := app.config.Units
+		if p.System == systems[s] || (p.System == "" && systems[s] == config.UnitSystemDefault) {
+			return
+		}
+		p.System = systems[s]
+		if p.System == config.UnitSystemCustom && len(p.Custom) == 0 {
+			p.Custom = units.System{}
+			for d, u := range app.config.Units.Units() {
+				p.Custom[d] = u
+			}
+		}
+		if err := app.setUnitPreferences(p); err != nil {
+			dialog.ShowError(err, app.window)
+			selectCurrent()
+		}
+	}
+
+	return []*widget.FormItem{
+		widget.NewFormItem("Units", container.NewBorder(nil, nil, nil, customBtn, system)),
+	}
+}
+
+// showCustomUnitsDialog lets the user choose the unit for each dimension, switching
+// to the custom unit system. onSaved is called after the units are saved.
+func showCustomUnitsDialog(app *App, onSaved func()) {
+	const defaultOption = "(default)"
+	var items []*widget.FormItem
+	selects := map[units.Dimension]*widget.Select{}
+	for _, d := range units.ConvertibleDimensions() {
+		options := []string{defaultOption}
+		for _, u := range units.UnitsOf(d) {
+			options = append(options, string(u))
+		}
+		s := widget.NewSelect(options, nil)
+		s.Selected = defaultOption
+		if u, ok := app.config.Units.Custom[d]; ok {
+			s.Selected = string(u)
+		}
+		selects[d] = s
+		items = append(items, widget.NewFormItem(string(d), s))
+	}
+
+	dialog.ShowForm("Custom Units", "Save", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		custom := units.System{}
+		for d, s := range selects {
+			if s.Selected != defaultOption {
+				custom[d] = units.Unit(s.Selected)
+			}
+		}
+		if err := app.setUnitPreferences(config.UnitPreferences{System: config.UnitSystemCustom, Custom: custom}); err != nil {
+			dialog.ShowError(err, app.window)
+			return
+		}
+		onSaved()
+	}, app.window)
+}
+
+// logLevelFormItems returns the form item for the debug log's level.
+func logLevelFormItems(app *App) []*widget.FormItem {
+	levels := make([]string, len(ssm2.Levels))
+	for i, l := range ssm2.Levels {
+		levels[i] = l.String()
+	}
+
+	level := widget.NewSelect(levels, func(s string) {
+		app.config.UI.LogLevel = s
+		if debugLog != nil {
+			debugLog.SetLevel(app.config.UI.DebugLogLevel())
+		}
+	})
+	level.Selected = app.config.UI.DebugLogLevel().String()
+
+	return []*widget.FormItem{widget.NewFormItem("Log Level", level)}
+}
+
+// connectionFormItems returns the form items for the connection options.
+// Changes take effect the next time a connection is opened.
+func connectionFormItems(app *App) []*widget.FormItem {
+	opts := &app.config.Connection
+
+	echoHandlings := make([]string, len(ssm2.EchoHandlings))
+	for i, e := range ssm2.EchoHandlings {
+		echoHandlings[i] = string(e)
+	}
+	echoHandling := widget.NewSelect(echoHandlings, func(s string) {
+		opts.EchoHandling = ssm2.EchoHandling(s)
+	})
+	echoHandling.Selected = string(opts.EchoHandling)
+
+	wireTimings := make([]string, len(ssm2.WireTimings))
+	for i, w := range ssm2.WireTimings {
+		wireTimings[i] = string(w)
+	}
+	wireTiming := widget.NewSelect(wireTimings, func(s string) {
+		opts.WireTiming = ssm2.WireTiming(s)
+	})
+	wireTiming.Selected = string(opts.WireTiming)
+
+	return []*widget.FormItem{
+		widget.NewFormItem("Baud Rate", NewIntEntry(&opts.BaudRate)),
+		widget.NewFormItem("Read Timeout", NewDurationEntry(&opts.ReadTimeout)),
+		widget.NewFormItem("Total Read Timeout", NewDurationEntry(&opts.TotalReadTimeout)),
+		widget.NewFormItem("Max Read Attempts", NewIntEntry(&opts.MaxReadAttempts)),
+		widget.NewFormItem("Max Consecutive Errors", NewIntEntry(&opts.MaxConsecutiveErrors)),
+		widget.NewFormItem("Echo Handling", echoHandling),
+		widget.NewFormItem("Wire Timing", wireTiming),
+	}
 }
 
 func NewSettingsTab(app *App) *SettingsTab {
@@ -31,11 +182,43 @@ func NewSettingsTab(app *App) *SettingsTab {
 	for _, f := range settingsFormItems {
 		formItems = append(formItems, f(app)...)
 	}
-	return &SettingsTab{
-		form: widget.NewForm(formItems...),
+	tab := &SettingsTab{
+		form:      widget.NewForm(formItems...),
+		logViewer: widget.NewTextGrid(),
 	}
+	tab.container = container.NewVScroll(container.NewVBox(
+		tab.form,
+		widget.NewAccordion(widget.NewAccordionItem("Debug Log", tab.logViewerContainer(app))),
+	))
+	return tab
 }
 
 func (t *SettingsTab) Container() fyne.CanvasObject {
-	return t.form
+	return t.container
+}
+
+// logViewerContainer shows the most recent lines from the debug log so they
+// can be checked, or copied and sent along with a bug report.
+func (t *SettingsTab) logViewerContainer(app *App) fyne.CanvasObject {
+	if debugLog == nil {
+		return widget.NewLabel("The debug log file couldn't be opened.")
+	}
+
+	refresh := func() {
+		t.logViewer.SetText(debugLog.Recent())
+	}
+	refresh()
+
+	copyBtn := widget.NewButton("Copy", func() {
+		for _, w := range app.fyneApp.Driver().AllWindows() {
+			w.Clipboard().SetContent(debugLog.Recent())
+			return
+		}
+	})
+
+	return container.NewVBox(
+		NewWrappedLabel("Full log: "+debugLog.Path),
+		container.NewHBox(widget.NewButton("Refresh", refresh), copyBtn),
+		t.logViewer,
+	)
 }
@@ -10,11 +10,11 @@ import (
 func init() {
 	settingsFormItems = append(settingsFormItems,
 		func(app *App) []*widget.FormItem {
-			fakeConnection := binding.BindBool(&app.config.UseFakeConnection)
+			fakeConnection := binding.BindBool(&app.config.UI.UseFakeConnection)
 			fakeConnection.AddListener(binding.NewDataListener(func() {
 				val, err := fakeConnection.Get()
 				if err != nil {
-					logger.Debugf("getting fake connection value: %v", err)
+					logger.Warn("getting fake connection value", "error", err)
 					return
 				}
 
@@ -1,12 +1,68 @@
 package main
 
 import (
+	"strconv"
+	"time"
+
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
+// The star icons for favorites, from the Material Design icons.
+var (
+	favoriteOnIcon = theme.NewThemedResource(fyne.NewStaticResource("favorite-on.svg", []byte(
+		`<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">`+
+			`<path d="M12 17.27L18.18 21l-1.64-7.03L22 9.24l-7.19-.61L12 2 9.19 8.63 2 9.24l5.46 4.73L5.82 21z"/></svg>`)))
+	favoriteOffIcon = theme.NewThemedResource(fyne.NewStaticResource("favorite-off.svg", []byte(
+		`<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">`+
+			`<path d="M22 9.24l-7.19-.62L12 2 9.19 8.63 2 9.24l5.46 4.73L5.82 21 12 17.27 18.18 21l-1.63-7.03L22 9.24z`+
+			`M12 15.4l-3.76 2.27 1-4.28-3.32-2.88 4.38-.38L12 6.1l1.71 4.04 4.38.38-3.32 2.88 1 4.28L12 15.4z"/></svg>`)))
+)
+
+// favoriteIcon returns the icon for a favorite button.
+func favoriteIcon(favorite bool) fyne.Resource {
+	if favorite {
+		return favoriteOnIcon
+	}
+	return favoriteOffIcon
+}
+
 func NewWrappedLabel(text string) *widget.Label {
 	label := widget.NewLabel(text)
 	label.Wrapping = fyne.TextWrapWord
 	return label
 }
+
+// NewIntEntry returns an entry that updates i whenever a valid integer is entered.
+func NewIntEntry(i *int) *widget.Entry {
+	entry := widget.NewEntry()
+	entry.SetText(strconv.Itoa(*i))
+	entry.Validator = func(s string) error {
+		_, err := strconv.Atoi(s)
+		return err
+	}
+	entry.OnChanged = func(s string) {
+		if v, err := strconv.Atoi(s); err == nil {
+			*i = v
+		}
+	}
+	return entry
+}
+
+// NewDurationEntry returns an entry that updates d whenever a valid
+// duration (e.g. 1.5s or 500ms) is entered.
+func NewDurationEntry(d *time.Duration) *widget.Entry {
+	entry := widget.NewEntry()
+	entry.SetText(d.String())
+	entry.Validator = func(s string) error {
+		_, err := time.ParseDuration(s)
+		return err
+	}
+	entry.OnChanged = func(s string) {
+		if v, err := time.ParseDuration(s); err == nil {
+			*d = v
+		}
+	}
+	return entry
+}
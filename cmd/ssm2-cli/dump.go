@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var dumpStart string
+var dumpLength int
+var dumpOut string
+var dumpChunkSize int
+var dumpRetries int
+var dumpOverwrite bool
+
+func init() {
+	dumpCmd.Flags().StringVar(&dumpStart, "start", "", "The hex address of the first byte to dump, e.g. FF0000 (required)")
+	dumpCmd.Flags().IntVar(&dumpLength, "length", 0, "The number of bytes to dump (required)")
+	dumpCmd.Flags().StringVar(&dumpOut, "out", "", "The file to write the image to. The metadata is written next to it with a .json extension added (required)")
+	dumpCmd.Flags().IntVar(&dumpChunkSize, "chunk", ssm2.MaxReadBlockLength, "The number of bytes read by each request")
+	dumpCmd.Flags().IntVar(&dumpRetries, "retries", 3, "The number of times a chunk is read again after failing")
+	dumpCmd.Flags().BoolVar(&dumpOverwrite, "overwrite", false, "Start over instead of resuming an existing dump")
+	dumpCmd.MarkFlagRequired("start")
+	dumpCmd.MarkFlagRequired("length")
+	dumpCmd.MarkFlagRequired("out")
+
+	rootCmd.AddCommand(dumpCmd)
+}
+
+// dumpInfo is the metadata written next to a dump's image.
+type dumpInfo struct {
+	ROMID  string      `json:"romId"`
+	SSMID  string      `json:"ssmId"`
+	Ranges []dumpRange `json:"ranges"`
+	// Complete is false until every byte has been dumped.
+	Complete bool `json:"complete"`
+	// SHA256 is the checksum of the complete image.
+	SHA256  string    `json:"sha256,omitempty"`
+	Updated time.Time `json:"updated"`
+}
+
+// dumpRange is a range of memory in a dump's image.
+type dumpRange struct {
+	// Start is the hex address of the range's first byte.
+	Start  string `json:"start"`
+	Length int    `json:"length"`
+	// Offset is the position of the range's first byte in the image.
+	Offset int `json:"offset"`
+}
+
+var dumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Dump a range of the ECU's memory to a file",
+	Long: `Dump a range of the ECU's memory to a binary image with a JSON file of metadata next to it.
+
+The image is written as it's read, so an interrupted dump resumes where it stopped when the
+same command is run again with the same ECU.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		start, err := ssm2.ParseAddress(dumpStart)
+		if err != nil {
+			return err
+		}
+		if dumpLength < 1 {
+			return errors.New("the length must be at least 1 byte")
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		status := cmd.OutOrStdout()
+		conn, ecu, err := connectECU(ctx, status, ssm2Logger(cmd))
+		if err != nil || ecu == nil {
+			return err
+		}
+		defer conn.Close()
+
+		info := dumpInfo{
+			ROMID:  hex.EncodeToString(ecu.ROM_ID),
+			SSMID:  hex.EncodeToString(ecu.SSM_ID),
+			Ranges: []dumpRange{{Start: fmt.Sprintf("%X", start[:]), Length: dumpLength}},
+		}
+		infoFile := dumpOut + ".json"
+		offset, err := dumpOffset(info, infoFile)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.OpenFile(dumpOut, os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return errors.Wrap(err, "opening image file")
+		}
+		defer f.Close()
+		if err = f.Truncate(int64(offset)); err != nil {
+			return errors.Wrap(err, "truncating image file")
+		}
+		if _, err = f.Seek(int64(offset), io.SeekStart); err != nil {
+			return errors.Wrap(err, "seeking image file")
+		}
+		if err = writeDumpInfo(infoFile, info); err != nil {
+			return err
+		}
+		if offset > 0 && !quiet {
+			fmt.Fprintf(status, "resuming after %d of %d bytes\n", offset, dumpLength)
+		}
+
+		began := time.Now()
+		dumped, err := ssm2.Dump(ctx, conn, f, ssm2.DumpOptions{
+			Start:     start,
+			Length:    dumpLength,
+			Offset:    offset,
+			ChunkSize: dumpChunkSize,
+			Retries:   dumpRetries,
+			Progress: func(dumped int) {
+				if !quiet {
+					printDumpProgress(status, dumped, offset, time.Since(began))
+				}
+			},
+		})
+		if !quiet && dumped > offset {
+			fmt.Fprintln(status)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				fmt.Fprintf(status, "interrupted after %d of %d bytes. run the command again to resume\n", dumped, dumpLength)
+				next := ssm2.Address{Address: start}.Add(uint32(dumped))
+				return fmt.Errorf("dump interrupted at 0x%X", next[:])
+			}
+			return errors.Wrapf(err, "dumping (%d of %d bytes were dumped and can be resumed)", dumped, dumpLength)
+		}
+		if err = f.Close(); err != nil {
+			return errors.Wrap(err, "closing image file")
+		}
+
+		if info.SHA256, err = fileChecksum(dumpOut); err != nil {
+			return err
+		}
+		info.Complete = true
+		if err = writeDumpInfo(infoFile, info); err != nil {
+			return err
+		}
+		if !quiet {
+			fmt.Fprintf(status, "dumped %d bytes to %s (sha256 %s)\n", dumpLength, dumpOut, info.SHA256)
+		}
+		return nil
+	},
+}
+
+// dumpOffset returns the number of bytes already dumped to the image for the same
+// ECU and range, or 0 when there isn't an image or it's being overwritten.
+func dumpOffset(info dumpInfo, infoFile string) (int, error) {
+	stat, err := os.Stat(dumpOut)
+	if os.IsNotExist(err) || (err == nil && dumpOverwrite) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "checking image file")
+	}
+
+	b, err := os.ReadFile(infoFile)
+	if err != nil {
+		return 0, fmt.Errorf("%s exists without the metadata to resume it. use --overwrite to start over", dumpOut)
+	}
+	var existing dumpInfo
+	if err = json.Unmarshal(b, &existing); err != nil {
+		return 0, errors.Wrapf(err, "parsing %s", infoFile)
+	}
+	if existing.ROMID != info.ROMID || len(existing.Ranges) != 1 || existing.Ranges[0] != info.Ranges[0] {
+		return 0, fmt.Errorf("%s is a dump of a different ECU or range. use --overwrite to start over", dumpOut)
+	}
+	if stat.Size() > int64(dumpLength) {
+		return 0, fmt.Errorf("%s is longer than the range. use --overwrite to start over", dumpOut)
+	}
+	return int(stat.Size()), nil
+}
+
+func writeDumpInfo(file string, info dumpInfo) error {
+	info.Updated = time.Now()
+	b, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encoding dump metadata")
+	}
+	if err = os.WriteFile(file, append(b, '\n'), 0644); err != nil {
+		return errors.Wrap(err, "writing dump metadata")
+	}
+	return nil
+}
+
+// printDumpProgress overwrites the current line with the dump's progress, its throughput
+// since it started or resumed, and the estimated time remaining.
+func printDumpProgress(w io.Writer, dumped, offset int, elapsed time.Duration) {
+	rate := float64(dumped-offset) / elapsed.Seconds()
+	remaining := "-"
+	if rate > 0 {
+		remaining = time.Duration(float64(dumpLength-dumped) / rate * float64(time.Second)).Round(time.Second).String()
+	}
+	fmt.Fprintf(w, "\r%d/%d bytes (%.1f%%) %.1f B/s, %s remaining   ",
+		dumped, dumpLength, 100*float64(dumped)/float64(dumpLength), rate, remaining)
+}
+
+// fileChecksum returns the hex SHA-256 checksum of the file.
+func fileChecksum(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", errors.Wrap(err, "opening image file")
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", errors.Wrap(err, "reading image file")
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
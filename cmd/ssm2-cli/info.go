@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/romdb"
+	"github.com/spf13/cobra"
+)
+
+var infoJSON bool
+var infoAllBits bool
+
+func init() {
+	infoCmd.Flags().BoolVar(&infoJSON, "json", false, "Write the info as JSON")
+	infoCmd.Flags().BoolVar(&infoAllBits, "all-bits", false, "Include the capability bits that aren't set")
+
+	rootCmd.AddCommand(infoCmd)
+}
+
+// ecuInfo is what the info command reports about the connected ECU.
+type ecuInfo struct {
+	SSMID string `json:"ssmId"`
+	ROMID string `json:"romId"`
+	// Vehicle is the vehicle identified by the ROM ID database, or nil.
+	Vehicle           *romdb.Entry      `json:"vehicle"`
+	Capabilities      []capabilityByte  `json:"capabilities"`
+	Parameters        []parameterInfo   `json:"parameters"`
+	DerivedParameters []parameterInfo   `json:"derivedParameters"`
+	UnmappedBits      []capabilityBitID `json:"unmappedBits"`
+}
+
+// capabilityByte is a decoded byte of the capability bitmap.
+type capabilityByte struct {
+	// Index is the byte's index in the init response data, like Parameter.CapabilityByteIndex.
+	Index uint            `json:"index"`
+	Value string          `json:"value"`
+	Bits  []capabilityBit `json:"bits"`
+}
+
+// capabilityBit is a bit of a capability byte and the catalog parameters it flags.
+type capabilityBit struct {
+	Bit        uint8    `json:"bit"`
+	Set        bool     `json:"set"`
+	Parameters []string `json:"parameters"`
+}
+
+// capabilityBitID identifies a bit of the capability bitmap.
+type capabilityBitID struct {
+	Index uint  `json:"index"`
+	Bit   uint8 `json:"bit"`
+}
+
+// parameterInfo is a supported parameter.
+type parameterInfo struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Unit      string   `json:"unit"`
+	Category  string   `json:"category"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+var infoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Identify the connected ECU and list what it can log",
+	Long: `Identify the connected ECU and list what it can log.
+
+The SSM ID and ROM ID are printed in hex along with the vehicle they identify in the ROM ID
+database and the decoded capability bitmap. Set bits that no known parameter maps to are
+flagged as unmapped, since they're parameters the catalog is missing.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		// keep stdout clean for the JSON
+		status := cmd.OutOrStdout()
+		if infoJSON {
+			status = cmd.ErrOrStderr()
+		}
+		conn, ecu, err := connectECU(ctx, status, ssm2Logger(cmd))
+		if err != nil || ecu == nil {
+			return err
+		}
+		defer conn.Close()
+
+		info := newECUInfo(ecu)
+		if infoJSON {
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(info)
+		}
+		return printECUInfo(cmd.OutOrStdout(), info)
+	},
+}
+
+func newECUInfo(ecu *ssm2.ECU) ecuInfo {
+	info := ecuInfo{
+		SSMID:             hex.EncodeToString(ecu.SSM_ID),
+		ROMID:             hex.EncodeToString(ecu.ROM_ID),
+		Capabilities:      make([]capabilityByte, len(ecu.Capabilities)),
+		Parameters:        make([]parameterInfo, 0, len(ecu.SupportedParameters)),
+		DerivedParameters: make([]parameterInfo, 0, len(ecu.SupportedDerivedParameters)),
+		UnmappedBits:      make([]capabilityBitID, 0),
+	}
+
+	if e, ok := cfg.IdentifyVehicle(ecu.ROM_ID, ecu.SSM_ID); ok {
+		info.Vehicle = &e
+	}
+
+	mapped := make(map[capabilityBitID][]string)
+	for _, p := range ssm2.Parameters {
+		if !p.Custom {
+			bit := capabilityBitID{p.CapabilityByteIndex, p.CapabilityBitIndex}
+			mapped[bit] = append(mapped[bit], p.Id)
+		}
+	}
+
+	for i, b := range ecu.Capabilities {
+		c := capabilityByte{
+			Index: uint(i) + ssm2.CapabilityByteOffset,
+			Value: fmt.Sprintf("%02X", b),
+			Bits:  make([]capabilityBit, 8),
+		}
+		for bit := uint8(0); bit < 8; bit++ {
+			id := capabilityBitID{c.Index, 7 - bit}
+			params := mapped[id]
+			ssm2.SortIDs(params)
+			c.Bits[bit] = capabilityBit{Bit: id.Bit, Set: b&(1<<id.Bit) != 0, Parameters: params}
+			if c.Bits[bit].Parameters == nil {
+				c.Bits[bit].Parameters = []string{}
+				if c.Bits[bit].Set {
+					info.UnmappedBits = append(info.UnmappedBits, id)
+				}
+			}
+		}
+		info.Capabilities[i] = c
+	}
+
+	for _, p := range ecu.SupportedParameters {
+		info.Parameters = append(info.Parameters, parameterInfo{
+			ID: p.Id, Name: p.Name, Unit: string(p.DefaultUnit), Category: string(p.Category),
+		})
+	}
+	for _, p := range ecu.SupportedDerivedParameters {
+		info.DerivedParameters = append(info.DerivedParameters, parameterInfo{
+			ID: p.Id, Name: p.Name, Unit: string(p.DefaultUnit), Category: string(p.Category),
+			DependsOn: p.DependsOnParameters,
+		})
+	}
+	for _, params := range [][]parameterInfo{info.Parameters, info.DerivedParameters} {
+		sort.Slice(params, func(i, j int) bool { return ssm2.LessID(params[i].ID, params[j].ID) })
+	}
+	return info
+}
+
+func printECUInfo(out io.Writer, info ecuInfo) error {
+	vehicle := "unknown (it can be added to romIds in the config file)"
+	if info.Vehicle != nil {
+		vehicle = info.Vehicle.Label()
+		if info.Vehicle.Engine != "" {
+			vehicle += " (" + info.Vehicle.Engine + ")"
+		}
+	}
+	fmt.Fprintf(out, "SSM ID: %s\nROM ID: %s\nVehicle: %s\n\n", info.SSMID, info.ROMID, vehicle)
+
+	fmt.Fprintf(out, "Capabilities (%d bytes, %d unmapped bits set):\n", len(info.Capabilities), len(info.UnmappedBits))
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "BYTE\tVALUE\tBIT\tSET\tPARAMETERS")
+	for _, c := range info.Capabilities {
+		var bits strings.Builder
+		for _, b := range c.Bits {
+			if b.Set {
+				bits.WriteByte('1')
+			} else {
+				bits.WriteByte('0')
+			}
+		}
+		fmt.Fprintf(w, "%d\t%s %s\t\t\t\n", c.Index, c.Value, bits.String())
+		for _, b := range c.Bits {
+			if !b.Set && !infoAllBits {
+				continue
+			}
+			params := strings.Join(b.Parameters, ", ")
+			if params == "" {
+				params = "(unmapped)"
+			}
+			fmt.Fprintf(w, "\t\t%d\t%s\t%s\n", b.Bit, yesNo(b.Set), params)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, list := range []struct {
+		title  string
+		params []parameterInfo
+	}{{"Supported parameters", info.Parameters}, {"Supported derived parameters", info.DerivedParameters}} {
+		fmt.Fprintf(out, "\n%s (%d):\n", list.title, len(list.params))
+		w = tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tUNIT\tCATEGORY\tDEPENDS ON")
+		for _, p := range list.params {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", p.ID, p.Name, p.Unit, p.Category, strings.Join(p.DependsOn, ", "))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
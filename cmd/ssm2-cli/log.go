@@ -5,18 +5,22 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
-	"strconv"
 	"strings"
 	"time"
 
+	"github.com/gavinwade12/ecLogger/config"
+	"github.com/gavinwade12/ecLogger/logfile"
 	"github.com/gavinwade12/ecLogger/protocols/ssm2"
 	"github.com/gavinwade12/ecLogger/units"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 var logFileFormat string
+var logDuration time.Duration
+var logSamples int
+var logToStdout bool
+var logProfile string
 
 func init() {
 	addLoggedParamCmd.Flags().StringVar(&paramID, "paramID", "", "The parameter Id to add")
@@ -25,13 +29,11 @@ func init() {
 
 	rootCmd.AddCommand(logCmd)
 
-	logCmd.Flags().StringVar(&logFileFormat, "logFileFormat", "{{romID}}-{{timestamp}}.csv", "The format used for generating a log file name (path included). Variables can be injected using the format {{variableName}}. Supported variables: romID, timestamp.")
-}
-
-type loggedParameter struct {
-	Id      string     `mapstructure:"id"`
-	Derived bool       `mapstructure:"derived"`
-	Unit    units.Unit `mapstructure:"unit"`
+	logCmd.Flags().StringVar(&logFileFormat, "logFileFormat", "", "Overrides the configured format used for generating a log file name. Relative names are created in the configured log directory. Variables can be injected using the format {{variableName}}. Supported variables: romId, nickname, timestamp.")
+	logCmd.Flags().DurationVar(&logDuration, "duration", 0, "Stop logging after the duration (e.g. 30s or 5m). Logs until interrupted when 0.")
+	logCmd.Flags().IntVar(&logSamples, "samples", 0, "Stop logging after writing this many rows. Logs until interrupted when 0.")
+	logCmd.Flags().StringVar(&logProfile, "profile", "", "The profile to log (default is the profile last used with the connected vehicle, the identified vehicle's default profile the first time it's logged, or the active profile)")
+	logCmd.Flags().BoolVar(&logToStdout, "stdout", false, "Write the log to stdout instead of a file. Status messages are written to stderr.")
 }
 
 var logCmd = &cobra.Command{
@@ -42,159 +44,149 @@ var logCmd = &cobra.Command{
 		if port == "" {
 			return errors.New("the port setting is required for logging")
 		}
-		if logFileFormat == "" {
-			return errors.New("a log file name format is required")
+		if logDuration < 0 {
+			return errors.New("the duration can't be negative")
 		}
-
-		var cfgParams []loggedParameter
-		if err := viper.UnmarshalKey("logging.parameters", &cfgParams); err != nil {
-			return errors.Wrap(err, "getting parameters configured for logging")
-		}
-		if len(cfgParams) == 0 {
-			return errors.New("no parameters are configured for logging")
+		if logSamples < 0 {
+			return errors.New("the number of samples can't be negative")
 		}
 
-		conn, err := createSSM2Conn(port, ssm2Logger(cmd))
-		if err != nil {
-			return errors.Wrap(err, "creating new connection")
+		if logProfile != "" {
+			if err := cfg.Logging.UseProfile(logProfile); err != nil {
+				return err
+			}
 		}
-		defer conn.Close()
-
-		ctx, cancel := context.WithCancel(context.Background())
-		ctx, _ = signal.NotifyContext(ctx, os.Interrupt, os.Kill)
-		defer cancel()
 
-		// send an init command until a successful response or interruption is received
-		stdOut := cmd.OutOrStdout()
-		if !quiet {
-			fmt.Fprintln(stdOut, "initializing with ECU and determining supported parameters...")
+		// keep stdout clean for the log when it's written there
+		out := cmd.OutOrStdout()
+		if logToStdout {
+			cmd.SetOut(cmd.ErrOrStderr())
 		}
-		var ecu *ssm2.ECU
-		for ecu == nil {
-			ecu, err = conn.InitECU(ctx)
-			if err == nil {
-				break
-			}
+		status := cmd.OutOrStdout()
+		l := ssm2Logger(cmd)
 
-			if !errors.Is(err, ssm2.ErrReadTimeout) {
-				return errors.Wrap(err, "sending init request")
-			}
-			ecu = nil
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
 
-			if err = conn.Close(); err != nil {
-				return errors.Wrap(err, "closing ssm2 connection")
-			}
+		conn, ecu, err := connectECU(ctx, status, l)
+		if err != nil || ecu == nil {
+			return err
+		}
+		defer conn.Close()
 
-			conn, err = createSSM2Conn(port, ssm2Logger(cmd))
-			if err != nil {
-				return errors.Wrap(err, "creating new connection")
+		// restore the profile and units last used with the vehicle unless a profile was given.
+		// the first time an identified vehicle is logged, its default profile is used.
+		v := cfg.Vehicle(ecu.ROM_ID)
+		identified, ok := cfg.IdentifyVehicle(ecu.ROM_ID, ecu.SSM_ID)
+		if logProfile != "" {
+			v.Profile = logProfile
+		} else if ok {
+			cfg.UseDefaultProfile(v, identified)
+		}
+		var cfgParams []config.Parameter
+		for _, p := range cfg.UseVehicleProfile(v) {
+			if p.LogToFile {
+				cfgParams = append(cfgParams, p)
 			}
 		}
+		if err = saveConfig(); err != nil {
+			return errors.Wrap(err, "saving vehicle settings")
+		}
 		if !quiet {
-			fmt.Fprintln(stdOut, "initialized")
+			name := v.Name()
+			if ok {
+				name += " (" + identified.Label() + ")"
+			}
+			fmt.Fprintf(status, "vehicle: %s, profile: %s\n", name, v.Profile)
+		}
+		if len(cfgParams) == 0 {
+			return fmt.Errorf("no parameters in the %s profile are configured for logging to file", v.Profile)
 		}
 
-		logFileFormat = strings.NewReplacer(
-			"{{romID}}", string(ecu.ROM_ID),
-			"{{timestamp}}", time.Now().Format("20060102_150405"), //yyyyMMdd_hhmmss
-		).Replace(logFileFormat)
+		selection := config.SelectParameters(cfgParams, ecu)
 		if !quiet {
-			fmt.Fprintf(stdOut, "logging to file: %s\n", logFileFormat)
+			for _, id := range selection.Unsupported {
+				fmt.Fprintf(status, "skipping parameter %s: not supported by the ECU\n", id)
+			}
+			for _, id := range selection.Dropped {
+				fmt.Fprintf(status, "skipping parameter %s: too many addresses to read in one request\n", id)
+			}
+			if len(selection.Dependencies) > 0 {
+				fmt.Fprintf(status, "also reading %s: required by derived parameters\n", strings.Join(selection.Dependencies, ", "))
+			}
+		}
+		params, derived := selection.Parameters, selection.Derived
+		if len(params) == 0 {
+			return errors.New("none of the configured parameters are supported by the ECU")
 		}
 
-		f, err := os.OpenFile(logFileFormat, os.O_CREATE|os.O_TRUNC|os.O_RDWR, os.ModePerm)
-		if err != nil {
-			return errors.Wrap(err, "opening file for logging")
-		}
-		defer f.Close()
-
-		// gather the parameters to log. start with read parameters, then do derived parameters
-		loggedParams := []ssm2.Parameter{}
-		loggedDerivedParams := []ssm2.DerivedParameter{}
-		headers := []string{}
-		addressesToRead := [][3]byte{}
-		for _, cfgParam := range cfgParams {
-			if cfgParam.Derived {
-				continue
+		if !logToStdout {
+			logConfig := *cfg
+			if logFileFormat != "" {
+				logConfig.Logging.FileNameFormat = logFileFormat
+			}
+			logFilePath := logConfig.LogFilePath(ecu.ROM_ID, time.Now())
+			if !quiet {
+				fmt.Fprintf(status, "logging to file: %s\n", logFilePath)
 			}
 
-			p := ssm2.Parameters[cfgParam.Id]
-			headers = append(headers, fmt.Sprintf("%s (%s)", p.Name, cfgParam.Unit))
-			loggedParams = append(loggedParams, p)
-			addressesToRead = append(addressesToRead, p.Address.Address)
-		}
-		for _, cfgParam := range cfgParams {
-			if !cfgParam.Derived {
-				continue
+			f, err := logfile.Create(logFilePath)
+			if err != nil {
+				return err
 			}
+			defer f.Close()
+			out = f
 
-			dp := ssm2.DerivedParameters[cfgParam.Id]
-			headers = append(headers, fmt.Sprintf("%s (%s)", dp.Name, cfgParam.Unit))
-			loggedDerivedParams = append(loggedDerivedParams, dp)
+			if err = logfile.WriteMetadata(logFilePath, logfile.NewMetadata(cfg, ecu, time.Now())); err != nil {
+				return err
+			}
 		}
 
-		if len(loggedParams) == 0 {
-			return errors.New("no loggable parameters")
+		w := logfile.NewWriter(out, logfile.Columns(params, derived, cfgParams))
+		if err = w.WriteHeader(); err != nil {
+			return errors.Wrap(err, "writing log header")
 		}
 
-		_, err = f.WriteString(strings.Join(headers, ",") + "\n")
-		if err != nil {
-			return errors.Wrap(err, "writing header line to log file")
+		if logDuration > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, logDuration)
+			defer cancel()
 		}
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
 
-		// begin reading the parameter values
-		fmt.Fprintln(stdOut, "sending read addresses request")
-		_, err = conn.SendReadAddressesRequest(ctx, addressesToRead, true)
+		session, err := ssm2.LoggingSessionForVehicle(ctx, conn, params, derived, v.Specs)
 		if err != nil {
-			return errors.Wrap(err, "sending read address request")
+			return errors.Wrap(err, "starting logging session")
 		}
-
-		// read the packets in a go routine so we don't potentially block reads with our processing
-		type packetResult struct {
-			packet ssm2.Packet
-			err    error
-		}
-		results := make(chan packetResult, 5) // buffer the channel in case it takes us longer to process than it takes to read
-
-		go func() {
-			for {
-				p, err := conn.NextPacket(ctx)
-				results <- packetResult{p, err}
+		// drain the session so its goroutine can exit after canceling
+		defer func() {
+			cancel()
+			for range session {
 			}
 		}()
 
-		// process the read packets until cancelled
-		for {
-			select {
-			case result := <-results:
-				if result.err != nil {
-					if result.err == context.DeadlineExceeded {
-						return nil
-					}
-					return errors.Wrap(err, "reading next packet from connection")
-				}
-
-				data := result.packet.Data()
-				index := 0
-				for i, param := range loggedParams {
-					pv := param.Value(data[index : index+param.Address.Length])
-					val := strconv.FormatFloat(float64(pv.Value), 'f', 2, 32) + " " + string(pv.Unit)
-					if i < len(loggedParams)-1 {
-						val += ","
-					}
-					if _, err = f.WriteString(val); err != nil {
-						return errors.Wrap(err, "writing parameter value")
-					}
-					index += param.Address.Length
-				}
-			case <-ctx.Done():
-				err := ctx.Err()
-				if errors.Is(err, context.DeadlineExceeded) {
-					return nil
-				}
-				return err
+		samples := 0
+		for values := range session {
+			logfile.ConvertUnits(values, cfgParams, v.Specs.Fuel.Converter(), l)
+			if err = w.WriteRow(time.Now(), values); err != nil {
+				return errors.Wrap(err, "writing log row")
+			}
+
+			samples++
+			if logSamples > 0 && samples >= logSamples {
+				break
 			}
 		}
+		if !quiet {
+			fmt.Fprintf(status, "logged %d samples\n", samples)
+		}
+
+		// the session only closes on its own after too many errors
+		if ctx.Err() == nil && (logSamples == 0 || samples < logSamples) {
+			return errors.New("logging session closed after too many consecutive errors")
+		}
+		return nil
 	},
 }
 
@@ -203,7 +195,7 @@ var unit string
 
 var addLoggedParamCmd = &cobra.Command{
 	Use:   "add_param",
-	Short: "Adds a parameter to the logging config",
+	Short: "Adds a parameter to the active logging profile",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if paramID == "" {
 			return errors.New("no paramID set")
@@ -212,33 +204,18 @@ var addLoggedParamCmd = &cobra.Command{
 			return errors.New("no unit set")
 		}
 
-		var cfgParams []loggedParameter
-		if err := viper.UnmarshalKey("logging.parameters", &cfgParams); err != nil {
-			return errors.Wrap(err, "getting parameters configured for logging")
-		}
-		for _, p := range cfgParams {
-			if p.Id == paramID {
-				return errors.New("the parameter is already configured for logging")
-			}
+		profile := cfg.Logging.ActiveProfile()
+		if profile.Parameter(paramID) != nil {
+			return errors.New("the parameter is already configured for logging")
 		}
 
-		var derived bool
-		_, ok := ssm2.Parameters[paramID]
-		if !ok {
-			_, ok := ssm2.DerivedParameters[paramID]
-			if !ok {
-				return errors.New("invalid paramID")
-			}
-			derived = true
+		p, err := newProfileParameter(paramID, units.Unit(unit))
+		if err != nil {
+			return err
 		}
+		p.LogToFile = true
 
-		cfgParams = append(cfgParams, loggedParameter{
-			Id:      paramID,
-			Derived: derived,
-			Unit:    units.Unit(unit),
-		})
-
-		viper.Set("logging.parameters", cfgParams)
-		return viper.WriteConfig()
+		profile.SetParameters(append(profile.Parameters, *p))
+		return saveConfig()
 	},
 }
@@ -1,34 +1,33 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"log"
 	"os"
-	"path"
 
+	"github.com/gavinwade12/ecLogger/config"
 	"github.com/gavinwade12/ecLogger/protocols/ssm2"
-	"github.com/mitchellh/go-homedir"
+	"github.com/gavinwade12/ecLogger/serialport"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	"github.com/spf13/pflag"
-	"github.com/spf13/viper"
-	"go.bug.st/serial"
 )
 
 const portSettingName string = "port"
 
 var configFile string
-var parameterFile string
 var port string
 var quiet bool
 var verbose bool
 
+// cfg is the config shared with logger-ui, loaded before any command runs.
+var cfg *config.Config
+
 func init() {
-	cobra.OnInitialize(func() {
-		initConfig()
-		postInitCommands(rootCmd.Commands())
-	})
+	cobra.OnInitialize(initConfig)
 
-	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "config file (default is $HOME/.ssm2.yaml)")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "config file (default is $HOME/ssm2/config.yaml)")
 	rootCmd.PersistentFlags().StringVar(&port, portSettingName, "", "serial port to connect to. Example: /dev/ttyUSB0")
 	rootCmd.PersistentFlags().BoolVar(&quiet, "quiet", false, "quiet all log output")
 	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "provide verbose output")
@@ -47,76 +46,97 @@ var rootCmd = &cobra.Command{
 }
 
 func initConfig() {
-	if configFile != "" {
-		viper.SetConfigFile(path.Base(configFile))
-		viper.AddConfigPath(path.Dir(configFile))
-	} else {
-		home, err := homedir.Dir()
-		if err != nil {
-			log.Fatalf("finding home directory: %v\n", err)
+	if configFile == "" {
+		var err error
+		if configFile, err = config.DefaultPath(); err != nil {
+			log.Fatalf("finding config file: %v\n", err)
 		}
-
-		viper.AddConfigPath(home)
-		viper.SetConfigName(".ssm2")
-		viper.SetConfigType("yaml")
 	}
 
-	viper.AutomaticEnv()
+	_, statErr := os.Stat(configFile)
 
-	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); ok || os.IsNotExist(err) {
-			if err = viper.SafeWriteConfig(); err != nil {
-				log.Fatalf("creating config file: %v\n", err)
-			}
-		} else {
-			log.Fatalf("reading config file: %v\n", err)
-		}
+	var err error
+	if cfg, err = config.Load(configFile); err != nil {
+		log.Fatalf("reading config file: %v\n", err)
 	}
-}
 
-func postInitCommands(commands []*cobra.Command) {
-	for _, cmd := range commands {
-		presetRequiredFlags(cmd)
-		if cmd.HasSubCommands() {
-			postInitCommands(cmd.Commands())
+	// create the config file (migrating any legacy settings) if it doesn't exist yet
+	if os.IsNotExist(statErr) {
+		if err = saveConfig(); err != nil {
+			log.Fatalf("creating config file: %v\n", err)
 		}
 	}
+
+	if port == "" {
+		port = cfg.Port
+	}
 }
 
-func presetRequiredFlags(cmd *cobra.Command) {
-	viper.BindPFlags(cmd.Flags())
-	cmd.Flags().VisitAll(func(f *pflag.Flag) {
-		if viper.IsSet(f.Name) && viper.GetString(f.Name) != "" {
-			cmd.Flags().Set(f.Name, viper.GetString(f.Name))
-		}
-	})
+// saveConfig writes the current config to the config file.
+func saveConfig() error {
+	return cfg.Save(configFile)
 }
 
+// ssm2Logger returns a logger that writes warnings and errors to stderr,
+// or everything to stdout when verbose. Nothing is logged when quiet.
 func ssm2Logger(cmd *cobra.Command) ssm2.Logger {
-	if !verbose {
+	if quiet {
 		return ssm2.NopLogger
 	}
-	return ssm2.DefaultLogger(cmd.OutOrStdout())
+	if verbose {
+		return ssm2.DefaultLogger(cmd.OutOrStdout())
+	}
+	return ssm2.NewLeveledLogger(cmd.ErrOrStderr(), ssm2.LevelWarn)
+}
+
+// connectionOptions returns the connection options from the config file
+// with any unset values replaced by their defaults.
+func connectionOptions() ssm2.ConnectionOptions {
+	return cfg.Connection.WithDefaults()
 }
 
 func createSSM2Conn(port string, l ssm2.Logger) (ssm2.Connection, error) {
-	l.Debugf("opening serial port %s", port)
-	sp, err := serial.Open(port, &serial.Mode{
-		BaudRate: ssm2.ConnectionBaudRate,
-		DataBits: ssm2.ConnectionDataBits,
-		Parity:   serial.NoParity,
-		StopBits: serial.OneStopBit,
-	})
-	if err != nil {
-		return nil, errors.Wrapf(err, "opening serial port '%s'", port)
+	if port == "" {
+		return nil, errors.New("the port setting is required")
 	}
+	return serialport.OpenConnection(port, connectionOptions(), l)
+}
 
-	if err = sp.SetReadTimeout(ssm2.ConnectionReadTimeout); err != nil {
-		return nil, errors.Wrap(err, "setting serial port read timeout")
+// connectECU connects to the port and sends an init request until the ECU responds or
+// the context is canceled, reconnecting after each timeout. The status is written to
+// status unless quiet. The connection and ECU are nil when the context is canceled.
+func connectECU(ctx context.Context, status io.Writer, l ssm2.Logger) (ssm2.Connection, *ssm2.ECU, error) {
+	conn, err := createSSM2Conn(port, l)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "creating new connection")
 	}
-	if err = sp.ResetInputBuffer(); err != nil {
-		return nil, errors.Wrap(err, "resetting input buffer")
+
+	if !quiet {
+		fmt.Fprintln(status, "initializing with ECU and determining supported parameters...")
 	}
+	for {
+		ecu, err := conn.InitECU(ctx)
+		if err == nil {
+			if !quiet {
+				fmt.Fprintln(status, "initialized")
+			}
+			return conn, ecu, nil
+		}
+		if ctx.Err() != nil {
+			conn.Close()
+			return nil, nil, nil
+		}
+		if !errors.Is(err, ssm2.ErrReadTimeout) {
+			conn.Close()
+			return nil, nil, errors.Wrap(err, "sending init request")
+		}
 
-	return ssm2.NewConnection(sp, l), nil
+		if err = conn.Close(); err != nil {
+			return nil, nil, errors.Wrap(err, "closing ssm2 connection")
+		}
+		conn, err = createSSM2Conn(port, l)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "creating new connection")
+		}
+	}
 }
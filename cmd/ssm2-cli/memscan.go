@@ -0,0 +1,339 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gavinwade12/ecLogger/formula"
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// maxExportedCandidates is the most candidates exported at once, so a scan
+// that hasn't been narrowed down doesn't flood the config with parameters.
+const maxExportedCandidates = 20
+
+var memscanStart string
+var memscanOpts ssm2.ScanOptions
+var memscanReference string
+
+func init() {
+	memscanCmd.Flags().StringVar(&memscanStart, "start", "FF0000", "The hex address of the range's first byte")
+	memscanCmd.Flags().IntVar(&memscanOpts.Length, "length", 0x2000, "The number of bytes in the range")
+	memscanCmd.Flags().IntVar(&memscanOpts.Width, "width", 1, "The number of bytes in each value: 1, 2, or 4")
+	memscanCmd.Flags().BoolVar(&memscanOpts.LittleEndian, "little-endian", false, "Each value's least significant byte comes first")
+	memscanCmd.Flags().BoolVar(&memscanOpts.Signed, "signed", false, "The values are two's complement numbers")
+	memscanCmd.Flags().StringVar(&memscanReference, "reference", "", "The ID of a parameter to correlate the values with, e.g. P8 for engine speed")
+
+	rootCmd.AddCommand(memscanCmd)
+}
+
+var memscanCmd = &cobra.Command{
+	Use:   "memscan",
+	Short: "Find the RAM addresses of unknown values by snapshotting a range of RAM and filtering how the values change",
+	Long: `Find the RAM addresses of unknown values by snapshotting a range of RAM and filtering how the values change.
+
+The scan starts with every value in the range as a candidate. Take snapshots while changing the
+value being searched for, e.g. toggling the A/C, and filter the candidates until only a few remain.
+Then export them as RAM parameters to log them. Enter 'help' in the session for its commands.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		start, err := ssm2.ParseAddress(memscanStart)
+		if err != nil {
+			return err
+		}
+		opts := memscanOpts
+		opts.Start = start
+		if memscanReference != "" {
+			p, ok := ssm2.Parameters[memscanReference]
+			if !ok {
+				return fmt.Errorf("parameter '%s' doesn't exist", memscanReference)
+			}
+			opts.Reference = &p
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		out := cmd.OutOrStdout()
+		conn, ecu, err := connectECU(ctx, out, ssm2Logger(cmd))
+		if err != nil || ecu == nil {
+			return err
+		}
+		defer conn.Close()
+
+		if opts.Reference != nil {
+			supported := false
+			for _, p := range ecu.SupportedParameters {
+				supported = supported || p.Id == opts.Reference.Id
+			}
+			if !supported {
+				return fmt.Errorf("the ECU doesn't support the reference parameter %s", opts.Reference.Id)
+			}
+		}
+
+		s, err := ssm2.NewScanner(conn, opts)
+		if err != nil {
+			return err
+		}
+		session := &memscanSession{scanner: s, conn: conn, opts: opts, out: out}
+		return session.run(ctx, cmd.InOrStdin())
+	},
+}
+
+// memscanSession reads the interactive memscan commands.
+type memscanSession struct {
+	scanner *ssm2.Scanner
+	conn    ssm2.Connection
+	opts    ssm2.ScanOptions
+	out     io.Writer
+}
+
+func (m *memscanSession) run(ctx context.Context, in io.Reader) error {
+	fmt.Fprintf(m.out, "scanning %d value(s). enter 'help' for the commands\n", len(m.scanner.Candidates()))
+
+	lines := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(m.out, "> ")
+		if !lines.Scan() {
+			fmt.Fprintln(m.out)
+			return lines.Err()
+		}
+		fields := strings.Fields(lines.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		quit, err := m.exec(ctx, fields[0], fields[1:])
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			fmt.Fprintf(m.out, "error: %v\n", err)
+		}
+		if quit {
+			return nil
+		}
+	}
+}
+
+// exec runs a command and returns whether the session is over.
+func (m *memscanSession) exec(ctx context.Context, command string, args []string) (bool, error) {
+	switch command {
+	case "snapshot", "s":
+		n, err := optionalInt(args, 1)
+		if err != nil {
+			return false, err
+		}
+		for i := 0; i < n; i++ {
+			if err = m.scanner.Snapshot(ctx); err != nil {
+				return false, err
+			}
+		}
+		m.printStatus()
+	case string(ssm2.ScanChanged), string(ssm2.ScanUnchanged), string(ssm2.ScanIncreased),
+		string(ssm2.ScanDecreased), string(ssm2.ScanEqual):
+		var value float64
+		if command == string(ssm2.ScanEqual) {
+			if len(args) != 1 {
+				return false, errors.New("usage: equal <value>")
+			}
+			var err error
+			if value, err = strconv.ParseFloat(args[0], 64); err != nil {
+				return false, errors.Wrap(err, "parsing the value")
+			}
+		}
+		if err := m.scanner.Snapshot(ctx); err != nil {
+			return false, err
+		}
+		if err := m.scanner.Filter(ssm2.ScanFilter(command), value); err != nil {
+			return false, err
+		}
+		m.printStatus()
+	case string(ssm2.ScanCorrelated):
+		min := 0.9
+		if len(args) > 0 {
+			var err error
+			if min, err = strconv.ParseFloat(args[0], 64); err != nil || min < 0 || min > 1 {
+				return false, errors.New("the minimum correlation must be from 0 to 1")
+			}
+		}
+		if err := m.scanner.Filter(ssm2.ScanCorrelated, min); err != nil {
+			return false, err
+		}
+		m.printStatus()
+	case "list", "l":
+		n, err := optionalInt(args, 20)
+		if err != nil {
+			return false, err
+		}
+		m.list(n)
+	case "export":
+		if len(args) < 1 || len(args) > 2 {
+			return false, errors.New("usage: export <id> [address]")
+		}
+		return false, m.export(args[0], args[1:])
+	case "reset":
+		s, err := ssm2.NewScanner(m.conn, m.opts)
+		if err != nil {
+			return false, err
+		}
+		m.scanner = s
+		m.printStatus()
+	case "help", "h", "?":
+		m.help()
+	case "quit", "exit", "q":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown command '%s'. enter 'help' for the commands", command)
+	}
+	return false, nil
+}
+
+func (m *memscanSession) help() {
+	w := tabwriter.NewWriter(m.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "snapshot [n]\tTake n snapshots (default 1)")
+	fmt.Fprintln(w, "changed\tTake a snapshot and keep the values that changed since the previous one")
+	fmt.Fprintln(w, "unchanged\tTake a snapshot and keep the values that didn't change since the previous one")
+	fmt.Fprintln(w, "increased\tTake a snapshot and keep the values that increased since the previous one")
+	fmt.Fprintln(w, "decreased\tTake a snapshot and keep the values that decreased since the previous one")
+	fmt.Fprintln(w, "equal <value>\tTake a snapshot and keep the values equal to the value")
+	fmt.Fprintln(w, "correlated [min]\tKeep the values correlated with the reference parameter across the snapshots (default min 0.9)")
+	fmt.Fprintln(w, "list [n]\tList the first n candidates (default 20)")
+	fmt.Fprintln(w, "export <id> [address]\tSave the candidates, or the one at the address, as RAM parameters")
+	fmt.Fprintln(w, "reset\tStart over with every value in the range")
+	fmt.Fprintln(w, "quit\tEnd the session")
+	w.Flush()
+}
+
+func (m *memscanSession) printStatus() {
+	fmt.Fprintf(m.out, "%d candidate(s) after %d snapshot(s)\n", len(m.scanner.Candidates()), m.scanner.Snapshots())
+}
+
+// list prints the first n candidates with their latest values.
+func (m *memscanSession) list(n int) {
+	candidates := m.scanner.Candidates()
+	w := tabwriter.NewWriter(m.out, 0, 4, 2, ' ', 0)
+	header := "ADDRESS\tVALUE\tPREVIOUS"
+	if m.opts.Reference != nil {
+		header += "\tCORRELATION"
+	}
+	fmt.Fprintln(w, header)
+	for i, c := range candidates {
+		if i == n {
+			break
+		}
+		prev := "-"
+		if len(c.Values) > 1 {
+			prev = strconv.FormatFloat(c.Values[len(c.Values)-2], 'f', -1, 64)
+		}
+		value := "-"
+		if len(c.Values) > 0 {
+			value = strconv.FormatFloat(c.Value(), 'f', -1, 64)
+		}
+		fmt.Fprintf(w, "%X\t%s\t%s", c.Address[:], value, prev)
+		if m.opts.Reference != nil {
+			fmt.Fprintf(w, "\t%.3f", m.scanner.Correlation(c))
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+	if len(candidates) > n {
+		fmt.Fprintf(m.out, "... and %d more\n", len(candidates)-n)
+	}
+}
+
+// export saves the candidates, or the one at the given address, as RAM parameters. A single
+// parameter uses the ID. Otherwise, each ID is suffixed with its parameter's address.
+func (m *memscanSession) export(id string, address []string) error {
+	candidates := m.scanner.Candidates()
+	if len(address) > 0 {
+		a, err := ssm2.ParseAddress(address[0])
+		if err != nil {
+			return err
+		}
+		candidates = nil
+		for _, c := range m.scanner.Candidates() {
+			if c.Address == a {
+				candidates = append(candidates, c)
+			}
+		}
+		if len(candidates) == 0 {
+			return fmt.Errorf("0x%X isn't a candidate", a[:])
+		}
+	}
+	switch {
+	case len(candidates) == 0:
+		return errors.New("there aren't any candidates to export")
+	case len(candidates) > maxExportedCandidates:
+		return fmt.Errorf("there are %d candidates. narrow them down to %d or give an address", len(candidates), maxExportedCandidates)
+	}
+
+	exported := make([]formula.RAMParameter, len(candidates))
+	for i, c := range candidates {
+		p := formula.RAMParameter{
+			ID:           id,
+			Name:         id,
+			Description:  fmt.Sprintf("Found by memscan at 0x%X", c.Address[:]),
+			Address:      fmt.Sprintf("%X", c.Address[:]),
+			Length:       m.opts.Width,
+			LittleEndian: m.opts.LittleEndian,
+			Signed:       m.opts.Signed,
+		}
+		if len(candidates) > 1 {
+			p.ID = fmt.Sprintf("%s_%X", id, c.Address[:])
+			p.Name = fmt.Sprintf("%s (0x%X)", id, c.Address[:])
+		}
+		exported[i] = p
+	}
+
+	// replace the parameters with the same IDs
+	params := make([]formula.RAMParameter, 0, len(cfg.RAMParameters)+len(exported))
+	for _, existing := range cfg.RAMParameters {
+		replaced := false
+		for i, p := range exported {
+			if p.ID == existing.ID {
+				params = append(params, p)
+				exported[i].ID, replaced = "", true
+			}
+		}
+		if !replaced {
+			params = append(params, existing)
+		}
+	}
+	for _, p := range exported {
+		if p.ID != "" {
+			params = append(params, p)
+		}
+	}
+
+	if err := cfg.SetRAMParameters(params); err != nil {
+		return err
+	}
+	if err := saveConfig(); err != nil {
+		return err
+	}
+	fmt.Fprintf(m.out, "exported %d RAM parameter(s). add them to a profile to log them\n", len(candidates))
+	return nil
+}
+
+// optionalInt parses the first argument as a positive number, or returns def without one.
+func optionalInt(args []string, def int) (int, error) {
+	if len(args) == 0 {
+		return def, nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("'%s' isn't a positive number", args[0])
+	}
+	return n, nil
+}
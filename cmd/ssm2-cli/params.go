@@ -0,0 +1,422 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// The formats parameters are exported in.
+const (
+	exportJSON     = "json"
+	exportYAML     = "yaml"
+	exportCSV      = "csv"
+	exportMarkdown = "markdown"
+)
+
+// The kinds of catalog entries.
+const (
+	kindParameter = "parameter"
+	kindDerived   = "derived"
+)
+
+var paramsSupported bool
+var paramsCategory string
+var paramsUnit string
+var paramsFormat string
+var paramsOut string
+var paramsErrorsOnly bool
+
+func init() {
+	paramsCmd.PersistentFlags().BoolVar(&paramsSupported, "supported", false, "Only include the parameters supported by the connected ECU")
+	paramsCmd.PersistentFlags().StringVar(&paramsCategory, "category", "", "Only include the parameters in the category, e.g. Fueling")
+	paramsCmd.PersistentFlags().StringVar(&paramsUnit, "unit", "", "Only include the parameters with the default unit, e.g. psi")
+
+	paramsExportCmd.Flags().StringVar(&paramsFormat, "format", "", "The export format: json, yaml, csv, or markdown (default is from the file's extension, or json)")
+	paramsExportCmd.Flags().StringVar(&paramsOut, "out", "", "The file to export to (default is stdout)")
+
+	paramsCheckCmd.Flags().BoolVar(&paramsErrorsOnly, "errors", false, "Only report the errors, not the warnings")
+
+	paramsCmd.AddCommand(paramsListCmd, paramsSearchCmd, paramsShowCmd, paramsExportCmd, paramsCheckCmd)
+	rootCmd.AddCommand(paramsCmd)
+}
+
+// catalogEntry describes a parameter or derived parameter in the catalog.
+type catalogEntry struct {
+	ID          string `json:"id" yaml:"id"`
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+	Kind        string `json:"kind" yaml:"kind"`
+	Category    string `json:"category" yaml:"category"`
+	Unit        string `json:"unit" yaml:"unit"`
+	// Address is the hex address of a parameter's first byte.
+	Address string `json:"address,omitempty" yaml:"address,omitempty"`
+	Length  int    `json:"length,omitempty" yaml:"length,omitempty"`
+	// CapabilityByte and CapabilityBit flag the ECU's support for a built-in parameter.
+	CapabilityByte *uint  `json:"capabilityByte,omitempty" yaml:"capabilityByte,omitempty"`
+	CapabilityBit  *uint8 `json:"capabilityBit,omitempty" yaml:"capabilityBit,omitempty"`
+	// Formula describes how a user-defined parameter's value is calculated.
+	Formula   string   `json:"formula,omitempty" yaml:"formula,omitempty"`
+	DependsOn []string `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+	// Stateful derived parameters depend on the previous values, e.g. an average.
+	Stateful    bool `json:"stateful,omitempty" yaml:"stateful,omitempty"`
+	UserDefined bool `json:"userDefined,omitempty" yaml:"userDefined,omitempty"`
+}
+
+var paramsCmd = &cobra.Command{
+	Use:   "params",
+	Short: "Browse and export the parameter catalog",
+}
+
+var paramsListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List the parameters",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := filteredCatalog(cmd)
+		if err != nil {
+			return err
+		}
+		return printCatalog(cmd.OutOrStdout(), entries)
+	},
+}
+
+var paramsSearchCmd = &cobra.Command{
+	Use:          "search <text>",
+	Short:        "List the parameters with the text in their ID, name, or description",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := filteredCatalog(cmd)
+		if err != nil {
+			return err
+		}
+
+		text := strings.ToLower(args[0])
+		matches := entries[:0]
+		for _, e := range entries {
+			if strings.Contains(strings.ToLower(e.ID+"\n"+e.Name+"\n"+e.Description), text) {
+				matches = append(matches, e)
+			}
+		}
+		return printCatalog(cmd.OutOrStdout(), matches)
+	},
+}
+
+var paramsShowCmd = &cobra.Command{
+	Use:          "show <id>",
+	Short:        "Show the details of a parameter",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, e := range catalog() {
+			if strings.EqualFold(e.ID, args[0]) {
+				printCatalogEntry(cmd.OutOrStdout(), e)
+				return nil
+			}
+		}
+		return fmt.Errorf("parameter '%s' doesn't exist", args[0])
+	},
+}
+
+var paramsExportCmd = &cobra.Command{
+	Use:          "export",
+	Short:        "Export the parameters to JSON, YAML, CSV, or Markdown",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := strings.ToLower(paramsFormat)
+		if format == "" {
+			switch strings.ToLower(filepath.Ext(paramsOut)) {
+			case ".yaml", ".yml":
+				format = exportYAML
+			case ".csv":
+				format = exportCSV
+			case ".md", ".markdown":
+				format = exportMarkdown
+			default:
+				format = exportJSON
+			}
+		}
+		switch format {
+		case exportJSON, exportYAML, exportCSV, exportMarkdown:
+		default:
+			return fmt.Errorf("unknown export format '%s'", paramsFormat)
+		}
+
+		entries, err := filteredCatalog(cmd)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if paramsOut != "" {
+			f, err := os.Create(paramsOut)
+			if err != nil {
+				return errors.Wrap(err, "creating export file")
+			}
+			defer f.Close()
+			out = f
+		}
+		if err = exportCatalog(out, format, entries); err != nil {
+			return errors.Wrap(err, "exporting parameters")
+		}
+		if f, ok := out.(*os.File); ok && paramsOut != "" {
+			return f.Close()
+		}
+		return nil
+	},
+}
+
+var paramsCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check the parameter catalog, including the user-defined parameters, for mistakes",
+	Long: `Check the parameter catalog, including the user-defined parameters, for mistakes.
+
+Errors break a parameter, e.g. a value calculation that panics or a dependency that doesn't exist.
+Warnings are likely mistakes, e.g. parameters sharing a capability bit or address, duplicate names,
+and descriptions that are just the ID. The command fails when there are errors.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		issues := ssm2.CheckCatalog(ssm2.Parameters, ssm2.DerivedParameters)
+		errs := issues.Errors()
+		if paramsErrorsOnly {
+			issues = errs
+		}
+		if err := issues.Report(cmd.OutOrStdout()); err != nil {
+			return err
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("the catalog has %d error(s)", len(errs))
+		}
+		return nil
+	},
+}
+
+// catalog returns the parameters and derived parameters, including the user-defined
+// ones, ordered by ID.
+func catalog() []catalogEntry {
+	formulas := userFormulas()
+	entries := make([]catalogEntry, 0, len(ssm2.Parameters)+len(ssm2.DerivedParameters))
+	for _, p := range ssm2.Parameters {
+		e := catalogEntry{
+			ID: p.Id, Name: p.Name, Description: p.Description, Kind: kindParameter,
+			Category: string(p.Category), Unit: string(p.DefaultUnit),
+			Formula: formulas[p.Id], UserDefined: p.Custom,
+		}
+		if p.Address != nil {
+			e.Address = fmt.Sprintf("%X", p.Address.Address[:])
+			e.Length = p.Address.Length
+		}
+		if !p.Custom {
+			byteIndex, bit := p.CapabilityByteIndex, p.CapabilityBitIndex
+			e.CapabilityByte, e.CapabilityBit = &byteIndex, &bit
+		}
+		entries = append(entries, e)
+	}
+	for _, p := range ssm2.DerivedParameters {
+		_, userDefined := formulas[p.Id]
+		entries = append(entries, catalogEntry{
+			ID: p.Id, Name: p.Name, Description: p.Description, Kind: kindDerived,
+			Category: string(p.Category), Unit: string(p.DefaultUnit),
+			Formula: formulas[p.Id], DependsOn: p.DependsOnParameters,
+			Stateful: p.Stateful(), UserDefined: userDefined,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return ssm2.LessID(entries[i].ID, entries[j].ID) })
+	return entries
+}
+
+// userFormulas describes how each user-defined parameter in the config is calculated.
+func userFormulas() map[string]string {
+	formulas := make(map[string]string)
+	for _, f := range cfg.Formulas {
+		formulas[f.ID] = f.Expression
+	}
+	for _, p := range cfg.RAMParameters {
+		formulas[p.ID] = p.Expression
+		if p.Expression == "" {
+			formulas[p.ID] = "x"
+		}
+	}
+	for _, c := range cfg.Calibrations {
+		if len(c.Table) > 0 {
+			formulas[c.ID] = fmt.Sprintf("lookup table of %s with %d points", c.Source, len(c.Table))
+		} else {
+			formulas[c.ID] = fmt.Sprintf("%s * %s + %s", c.Source,
+				strconv.FormatFloat(c.Scale, 'g', -1, 64), strconv.FormatFloat(c.Offset, 'g', -1, 64))
+		}
+	}
+	return formulas
+}
+
+// filteredCatalog returns the catalog entries that match the filter flags. The
+// ECU is connected to when only its supported parameters are included.
+func filteredCatalog(cmd *cobra.Command) ([]catalogEntry, error) {
+	var supported map[string]bool
+	if paramsSupported {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		conn, ecu, err := connectECU(ctx, cmd.ErrOrStderr(), ssm2Logger(cmd))
+		if err != nil || ecu == nil {
+			return nil, err
+		}
+		conn.Close()
+
+		supported = make(map[string]bool)
+		for _, p := range ecu.SupportedParameters {
+			supported[p.Id] = true
+		}
+		for _, p := range ecu.SupportedDerivedParameters {
+			supported[p.Id] = true
+		}
+	}
+
+	entries := catalog()
+	filtered := entries[:0]
+	for _, e := range entries {
+		if supported != nil && !supported[e.ID] {
+			continue
+		}
+		if paramsCategory != "" && !strings.EqualFold(e.Category, paramsCategory) {
+			continue
+		}
+		if paramsUnit != "" && !strings.EqualFold(e.Unit, paramsUnit) {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	return filtered, nil
+}
+
+func printCatalog(out io.Writer, entries []catalogEntry) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tKIND\tCATEGORY\tUNIT")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.ID, e.Name, e.Kind, e.Category, e.Unit)
+	}
+	return w.Flush()
+}
+
+func printCatalogEntry(out io.Writer, e catalogEntry) {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	for _, field := range catalogFields(e) {
+		if field.value != "" {
+			fmt.Fprintf(w, "%s:\t%s\n", field.name, field.value)
+		}
+	}
+	w.Flush()
+}
+
+// catalogField is a named value of a catalog entry for the text formats.
+type catalogField struct {
+	name  string
+	value string
+}
+
+func catalogFields(e catalogEntry) []catalogField {
+	var capability string
+	if e.CapabilityByte != nil {
+		capability = fmt.Sprintf("byte %d, bit %d", *e.CapabilityByte, *e.CapabilityBit)
+	}
+	var length string
+	if e.Length > 0 {
+		length = strconv.Itoa(e.Length)
+	}
+	var flags []string
+	if e.Stateful {
+		flags = append(flags, "stateful")
+	}
+	if e.UserDefined {
+		flags = append(flags, "user-defined")
+	}
+	return []catalogField{
+		{"ID", e.ID},
+		{"Name", e.Name},
+		{"Kind", e.Kind},
+		{"Category", e.Category},
+		{"Unit", e.Unit},
+		{"Address", e.Address},
+		{"Length", length},
+		{"Capability", capability},
+		{"Formula", e.Formula},
+		{"Depends On", strings.Join(e.DependsOn, ", ")},
+		{"Flags", strings.Join(flags, ", ")},
+		{"Description", e.Description},
+	}
+}
+
+func exportCatalog(out io.Writer, format string, entries []catalogEntry) error {
+	switch format {
+	case exportYAML:
+		enc := yaml.NewEncoder(out)
+		enc.SetIndent(2)
+		if err := enc.Encode(entries); err != nil {
+			return err
+		}
+		return enc.Close()
+	case exportCSV:
+		w := csv.NewWriter(out)
+		for i, e := range entries {
+			fields := catalogFields(e)
+			if i == 0 {
+				header := make([]string, len(fields))
+				for j, f := range fields {
+					header[j] = f.name
+				}
+				w.Write(header)
+			}
+			record := make([]string, len(fields))
+			for j, f := range fields {
+				record[j] = f.value
+			}
+			w.Write(record)
+		}
+		w.Flush()
+		return w.Error()
+	case exportMarkdown:
+		for i, e := range entries {
+			fields := catalogFields(e)
+			if i == 0 {
+				var header, divider strings.Builder
+				for _, f := range fields {
+					header.WriteString("| " + f.name + " ")
+					divider.WriteString("| --- ")
+				}
+				fmt.Fprintf(out, "%s|\n%s|\n", header.String(), divider.String())
+			}
+			var row strings.Builder
+			for _, f := range fields {
+				row.WriteString("| " + markdownEscaper.Replace(f.value) + " ")
+			}
+			if _, err := fmt.Fprintf(out, "%s|\n", row.String()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+// markdownEscaper keeps a value in its Markdown table cell.
+var markdownEscaper = strings.NewReplacer("|", "\\|", "\n", " ")
@@ -2,13 +2,18 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"os"
+	"os/signal"
 	"strconv"
+	"time"
 
+	"github.com/gavinwade12/ecLogger/serialport"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 	"go.bug.st/serial/enumerator"
 )
 
@@ -16,6 +21,10 @@ func init() {
 	portsCmd.AddCommand(listPortsCmd)
 	portsCmd.AddCommand(selectPortCmd)
 
+	detectPortsCmd.Flags().DurationVar(&probeTimeout, "timeout", serialport.DefaultProbeTimeout, "The amount of time each port is given to answer")
+	detectPortsCmd.Flags().BoolVar(&setDetectedPort, "set", false, "Set the first port an ECU answered on in the config file")
+	portsCmd.AddCommand(detectPortsCmd)
+
 	rootCmd.AddCommand(portsCmd)
 }
 
@@ -78,10 +87,57 @@ var selectPortCmd = &cobra.Command{
 		}
 
 		portName := ports[i].PortName
-		viper.Set(portSettingName, portName)
+		cfg.Port = portName
 		fmt.Fprintf(cmd.OutOrStdout(), "Selected '%s'\n", portName)
 
-		return viper.WriteConfig()
+		return saveConfig()
+	},
+}
+
+var probeTimeout time.Duration
+var setDetectedPort bool
+
+var detectPortsCmd = &cobra.Command{
+	Use:          "detect",
+	Short:        "Probe the available ports for a connected ECU",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer cancel()
+
+		w := cmd.OutOrStdout()
+		if !quiet {
+			fmt.Fprintln(w, "probing serial ports...")
+		}
+		results, err := serialport.Probe(ctx, probeTimeout, connectionOptions(), ssm2Logger(cmd))
+		if err != nil {
+			return err
+		}
+
+		detected := ""
+		for _, r := range results {
+			if !r.Answered() {
+				fmt.Fprintf(w, "%s:\tno ECU (%v)\n", r.Port.Name, r.Err)
+				continue
+			}
+
+			fmt.Fprintf(w, "%s:\tSSM ID: %s\tROM ID: %s\n", r.Port.Name,
+				hex.EncodeToString(r.ECU.SSM_ID), hex.EncodeToString(r.ECU.ROM_ID))
+			if detected == "" {
+				detected = r.Port.Name
+			}
+		}
+
+		if detected == "" {
+			return errors.New("no ECU answered on any serial port")
+		}
+		if !setDetectedPort {
+			return nil
+		}
+
+		cfg.Port = detected
+		fmt.Fprintf(w, "Selected '%s'\n", detected)
+		return saveConfig()
 	},
 }
 
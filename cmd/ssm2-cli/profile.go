@@ -0,0 +1,289 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/gavinwade12/ecLogger/config"
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var profileName string
+var profileFrom string
+var profileUse bool
+var profileUnit string
+var profileLogToFile bool
+var profileLiveLog bool
+var profilePriority int
+
+func init() {
+	profileCreateCmd.Flags().StringVar(&profileFrom, "from", "", "Copy the parameters from an existing profile")
+	profileCreateCmd.Flags().BoolVar(&profileUse, "use", false, "Make the new profile the active profile")
+
+	profileAddCmd.Flags().StringVar(&profileName, "profile", "", "The profile to change (default is the active profile)")
+	profileAddCmd.Flags().StringVar(&profileUnit, "unit", "", "The unit to log the parameter in (default is the unit preferred by the unit system)")
+	profileAddCmd.Flags().BoolVar(&profileLogToFile, "file", true, "Log the parameter to file")
+	profileAddCmd.Flags().BoolVar(&profileLiveLog, "live", false, "Show the parameter in logger-ui's live log")
+	profileAddCmd.Flags().IntVar(&profilePriority, "priority", config.PriorityNormal, "The sample priority. Higher priorities are read first and kept when there are too many addresses to read at once. Named priorities: -1 (low), 0 (normal), 1 (high)")
+
+	profileRemoveCmd.Flags().StringVar(&profileName, "profile", "", "The profile to change (default is the active profile)")
+
+	profileImportCmd.Flags().StringVar(&profileName, "name", "", "Import the profile with this name instead of the name in the file")
+	profileImportCmd.Flags().BoolVar(&profileUse, "use", false, "Make the imported profile the active profile")
+
+	profileCmd.AddCommand(profileListCmd, profileCreateCmd, profileUseCmd, profileDeleteCmd,
+		profileAddCmd, profileRemoveCmd, profileExportCmd, profileImportCmd)
+	rootCmd.AddCommand(profileCmd)
+}
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage the named sets of parameters used for logging",
+}
+
+var profileListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List the profiles, or the parameters in a profile",
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		if len(args) == 0 {
+			for _, p := range cfg.Logging.Profiles {
+				active := " "
+				if p.Name == cfg.Logging.Profile {
+					active = "*"
+				}
+				fmt.Fprintf(out, "%s %s (%d parameters)\n", active, p.Name, len(p.Parameters))
+			}
+			return nil
+		}
+
+		p, err := findProfile(args[0])
+		if err != nil {
+			return err
+		}
+		listProfileParameters(out, p)
+		return nil
+	},
+}
+
+func listProfileParameters(out io.Writer, p *config.Profile) {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tUNIT\tFILE\tLIVE\tPRIORITY")
+	for _, lp := range p.Parameters {
+		name := ""
+		if lp.Derived {
+			name = ssm2.DerivedParameters[lp.ID].Name
+		} else {
+			name = ssm2.Parameters[lp.ID].Name
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%v\t%d\n", lp.ID, name, lp.Unit, lp.LogToFile, lp.LiveLog, lp.Priority)
+	}
+	w.Flush()
+}
+
+var profileCreateCmd = &cobra.Command{
+	Use:          "create <name>",
+	Short:        "Create a new profile",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p := config.Profile{Name: args[0]}
+		if profileFrom != "" {
+			from, err := findProfile(profileFrom)
+			if err != nil {
+				return err
+			}
+			p.Parameters = append(p.Parameters, from.Parameters...)
+		}
+
+		if err := cfg.Logging.AddProfile(p); err != nil {
+			return err
+		}
+		if profileUse {
+			cfg.Logging.Profile = p.Name
+		}
+		return saveConfig()
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:          "use <name>",
+	Short:        "Set the active profile used for logging",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Logging.UseProfile(args[0]); err != nil {
+			return err
+		}
+		return saveConfig()
+	},
+}
+
+var profileDeleteCmd = &cobra.Command{
+	Use:          "delete <name>",
+	Short:        "Delete a profile",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Logging.RemoveProfile(args[0]); err != nil {
+			return err
+		}
+		return saveConfig()
+	},
+}
+
+var profileAddCmd = &cobra.Command{
+	Use:          "add <paramID>...",
+	Short:        "Add parameters to a profile, or update them if they're already in it",
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, err := findProfile(profileName)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range args {
+			lp, err := newProfileParameter(id, units.Unit(profileUnit))
+			if err != nil {
+				return err
+			}
+			lp.LogToFile = profileLogToFile
+			lp.LiveLog = profileLiveLog
+			lp.Priority = profilePriority
+
+			if existing := p.Parameter(id); existing != nil {
+				*existing = *lp
+			} else {
+				p.SetParameters(append(p.Parameters, *lp))
+			}
+		}
+		return saveConfig()
+	},
+}
+
+var profileRemoveCmd = &cobra.Command{
+	Use:          "remove <paramID>...",
+	Short:        "Remove parameters from a profile",
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, err := findProfile(profileName)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range args {
+			if !p.RemoveParameter(id) {
+				return fmt.Errorf("parameter %s isn't in the %s profile", id, p.Name)
+			}
+		}
+		return saveConfig()
+	},
+}
+
+var profileExportCmd = &cobra.Command{
+	Use:          "export <name> [file]",
+	Short:        "Export a profile to a file, or stdout when no file is given",
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, err := findProfile(args[0])
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 1 {
+			return config.ExportProfile(cmd.OutOrStdout(), *p)
+		}
+
+		f, err := os.Create(args[1])
+		if err != nil {
+			return errors.Wrap(err, "creating profile file")
+		}
+		defer f.Close()
+		return config.ExportProfile(f, *p)
+	},
+}
+
+var profileImportCmd = &cobra.Command{
+	Use:          "import <file>",
+	Short:        "Import a profile exported by ssm2-cli or logger-ui. Use - to read from stdin",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r := cmd.InOrStdin()
+		if args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return errors.Wrap(err, "opening profile file")
+			}
+			defer f.Close()
+			r = f
+		}
+
+		p, err := config.ImportProfile(r)
+		if err != nil {
+			return err
+		}
+		if profileName != "" {
+			p.Name = profileName
+		}
+
+		if err = cfg.Logging.AddProfile(*p); err != nil {
+			return err
+		}
+		if profileUse {
+			cfg.Logging.Profile = p.Name
+		}
+		return saveConfig()
+	},
+}
+
+// findProfile returns the profile with the given name, or the active profile when name is empty.
+func findProfile(name string) (*config.Profile, error) {
+	if name == "" {
+		return cfg.Logging.ActiveProfile(), nil
+	}
+
+	p := cfg.Logging.FindProfile(name)
+	if p == nil {
+		return nil, fmt.Errorf("profile '%s' doesn't exist", name)
+	}
+	return p, nil
+}
+
+// newProfileParameter returns a profile parameter for the parameter with the given ID,
+// validating that the unit can be converted to from the parameter's default unit.
+// The unit preferred by the configured unit system is used when unit is empty, and
+// otherwise the unit overrides the unit system.
+func newProfileParameter(id string, unit units.Unit) (*config.Parameter, error) {
+	var defaultUnit units.Unit
+	p := &config.Parameter{ID: id}
+	if param, ok := ssm2.Parameters[id]; ok {
+		defaultUnit = param.DefaultUnit
+	} else if param, ok := ssm2.DerivedParameters[id]; ok {
+		defaultUnit = param.DefaultUnit
+		p.Derived = true
+	} else {
+		return nil, fmt.Errorf("invalid parameter ID '%s'", id)
+	}
+
+	if unit == "" {
+		unit = cfg.Units.Preferred(defaultUnit)
+	} else {
+		p.UnitOverride = unit
+	}
+	if !units.CanConvert(defaultUnit, unit) {
+		return nil, fmt.Errorf("parameter %s can't be logged in %s", id, unit)
+	}
+	p.Unit = unit
+	return p, nil
+}
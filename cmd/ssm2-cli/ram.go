@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/gavinwade12/ecLogger/formula"
+	"github.com/gavinwade12/ecLogger/units"
+	"github.com/spf13/cobra"
+)
+
+var ramParam formula.RAMParameter
+var ramUnit string
+
+func init() {
+	ramAddCmd.Flags().StringVar(&ramParam.Name, "name", "", "The parameter's name (required)")
+	ramAddCmd.Flags().StringVar(&ramParam.Description, "description", "", "The parameter's description")
+	ramAddCmd.Flags().StringVar(&ramParam.Address, "address", "", "The hex address of the value's first byte, e.g. FF6B1C (required)")
+	ramAddCmd.Flags().IntVar(&ramParam.Length, "length", 1, "The number of bytes in the value, from 1 to 4")
+	ramAddCmd.Flags().BoolVar(&ramParam.LittleEndian, "little-endian", false, "The value's least significant byte comes first")
+	ramAddCmd.Flags().BoolVar(&ramParam.Signed, "signed", false, "The value is a two's complement number")
+	ramAddCmd.Flags().Uint32Var(&ramParam.Mask, "mask", 0, "Select the value's bits, e.g. 0x80 for the highest bit of a byte (default is every bit)")
+	ramAddCmd.Flags().StringVar(&ramParam.Expression, "expr", "", "Calculate the value from the raw value x, e.g. 'x * 0.01 - 40' (default is the raw value)")
+	ramAddCmd.Flags().StringVar(&ramUnit, "unit", "", "The unit of the calculated value")
+	ramAddCmd.MarkFlagRequired("name")
+	ramAddCmd.MarkFlagRequired("address")
+
+	ramCmd.AddCommand(ramListCmd, ramAddCmd, ramRemoveCmd)
+	rootCmd.AddCommand(ramCmd)
+}
+
+var ramCmd = &cobra.Command{
+	Use:   "ram",
+	Short: "Manage the custom parameters read from RAM addresses",
+}
+
+var ramListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List the RAM parameters",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tADDRESS\tLENGTH\tENDIAN\tSIGNED\tMASK\tEXPRESSION\tUNIT")
+		for _, p := range cfg.RAMParameters {
+			endian, mask, expr := "big", "-", p.Expression
+			if p.LittleEndian {
+				endian = "little"
+			}
+			if p.Mask != 0 {
+				mask = fmt.Sprintf("0x%X", p.Mask)
+			}
+			if expr == "" {
+				expr = formula.RawValueID
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%v\t%s\t%s\t%s\n",
+				p.ID, p.Name, p.Address, p.Length, endian, p.Signed, mask, expr, p.Unit)
+		}
+		return w.Flush()
+	},
+}
+
+var ramAddCmd = &cobra.Command{
+	Use:          "add <id>",
+	Short:        "Add a RAM parameter, replacing the one with the same ID. Add it to a profile to log it",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p := ramParam
+		p.ID = args[0]
+		p.Unit = units.Unit(ramUnit)
+
+		params := make([]formula.RAMParameter, 0, len(cfg.RAMParameters)+1)
+		replaced := false
+		for _, existing := range cfg.RAMParameters {
+			if existing.ID == p.ID {
+				existing, replaced = p, true
+			}
+			params = append(params, existing)
+		}
+		if !replaced {
+			params = append(params, p)
+		}
+
+		if err := cfg.SetRAMParameters(params); err != nil {
+			return err
+		}
+		return saveConfig()
+	},
+}
+
+var ramRemoveCmd = &cobra.Command{
+	Use:          "remove <id>",
+	Short:        "Remove a RAM parameter",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params := make([]formula.RAMParameter, 0, len(cfg.RAMParameters))
+		for _, p := range cfg.RAMParameters {
+			if p.ID != args[0] {
+				params = append(params, p)
+			}
+		}
+		if len(params) == len(cfg.RAMParameters) {
+			return fmt.Errorf("RAM parameter '%s' doesn't exist", args[0])
+		}
+
+		if err := cfg.SetRAMParameters(params); err != nil {
+			return err
+		}
+		return saveConfig()
+	},
+}
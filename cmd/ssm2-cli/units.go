@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gavinwade12/ecLogger/config"
+	"github.com/gavinwade12/ecLogger/units"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	unitsCmd.AddCommand(unitsUseCmd, unitsSetCmd)
+	rootCmd.AddCommand(unitsCmd)
+}
+
+var unitsCmd = &cobra.Command{
+	Use:   "units",
+	Short: "Show the unit system and the unit preferred for each dimension",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		system := cfg.Units.System
+		if system == "" {
+			system = config.UnitSystemDefault
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Unit system: %s\n\n", system)
+
+		preferred := cfg.Units.Units()
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "DIMENSION\tUNIT\tUNITS")
+		for _, d := range units.ConvertibleDimensions() {
+			u := preferred[d]
+			if u == "" {
+				u = "(default)"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", d, u, joinUnits(units.UnitsOf(d)))
+		}
+		return w.Flush()
+	},
+}
+
+var unitsUseCmd = &cobra.Command{
+	Use:          "use <system>",
+	Short:        "Log every parameter in the units of a unit system: " + strings.Join(config.UnitSystems, ", ") + ". Parameters keep the units chosen for them",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p := cfg.Units
+		p.System = args[0]
+		if err := cfg.SetUnitPreferences(p); err != nil {
+			return err
+		}
+		return saveConfig()
+	},
+}
+
+var unitsSetCmd = &cobra.Command{
+	Use:          "set <unit>",
+	Short:        "Prefer a unit for its dimension (e.g. psi for pressure), switching to the custom unit system. Parameters keep the units chosen for them",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		u := units.Unit(args[0])
+		d, ok := units.Dimensions[u]
+		if !ok {
+			return fmt.Errorf("unknown unit '%s'", u)
+		}
+
+		// start from the current system's preferences
+		custom := units.System{}
+		for dim, pref := range cfg.Units.Units() {
+			custom[dim] = pref
+		}
+		custom[d] = u
+
+		if err := cfg.SetUnitPreferences(config.UnitPreferences{System: config.UnitSystemCustom, Custom: custom}); err != nil {
+			return err
+		}
+		return saveConfig()
+	},
+}
+
+func joinUnits(us []units.Unit) string {
+	s := make([]string, len(us))
+	for i, u := range us {
+		s[i] = string(u)
+	}
+	return strings.Join(s, ", ")
+}
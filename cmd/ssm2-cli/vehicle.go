@@ -0,0 +1,252 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	vehicleSpecsCmd.Flags().Float32Var(&specs.Displacement, "displacement", 0, "The engine displacement in liters")
+	vehicleSpecsCmd.Flags().IntVar(&specs.Cylinders, "cylinders", 0, "The number of cylinders")
+	vehicleSpecsCmd.Flags().Float32Var(&specs.InjectorSize, "injectorSize", 0, "The flow rate of each injector in cc/min")
+	vehicleSpecsCmd.Flags().StringVar((*string)(&specs.Fuel.Type), "fuel", "", "The fuel type: "+joinFuelTypes())
+	vehicleSpecsCmd.Flags().Float32Var(&specs.Fuel.EthanolPercent, "ethanol", 0, "The ethanol content of an ethanol blend, e.g. 85 for E85")
+	vehicleSpecsCmd.Flags().Float32Var(&specs.Fuel.StoichAFR, "stoich", 0, "Overrides the stoichiometric AFR calculated from the fuel. 0 removes the override")
+	vehicleSpecsCmd.Flags().StringVar(&specs.TireSize, "tireSize", "", "The tire size, e.g. 225/45R17")
+	vehicleSpecsCmd.Flags().Float32SliceVar(&specs.GearRatios, "gearRatios", nil, "The transmission gear ratios starting with first gear, e.g. 3.454,1.947,1.296,0.972,0.738")
+	vehicleSpecsCmd.Flags().Float32Var(&specs.FinalDrive, "finalDrive", 0, "The final drive ratio")
+
+	vehicleCmd.AddCommand(vehicleListCmd, vehicleIdentifyCmd, vehicleNicknameCmd, vehicleUnitCmd, vehicleSpecsCmd)
+	rootCmd.AddCommand(vehicleCmd)
+}
+
+var vehicleCmd = &cobra.Command{
+	Use:   "vehicle",
+	Short: "Manage the settings restored when a vehicle's ECU is connected",
+}
+
+var vehicleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the vehicles that have been connected",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ROM ID\tVEHICLE\tNICKNAME\tPROFILE\tUNITS\tALERTS")
+		for _, v := range cfg.Vehicles {
+			var label string
+			if romID, err := hex.DecodeString(v.ROMID); err == nil {
+				if e, ok := cfg.IdentifyVehicle(romID, nil); ok {
+					label = e.Label()
+				}
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\n", v.ROMID, label, v.Nickname, v.Profile, len(v.Units), len(v.Alerts))
+		}
+		return w.Flush()
+	},
+}
+
+var vehicleIdentifyCmd = &cobra.Command{
+	Use:   "identify <romId> [ssmId]",
+	Short: "Look up the vehicle with a ROM ID (and SSM ID) in the ROM ID database",
+	Long: `Look up the vehicle with a ROM ID (and SSM ID) in the ROM ID database.
+
+The database is built in, and entries can be added to romIds in the config file, e.g.
+
+  romIds:
+  - romId: 2F12785606
+    ssmId: A4100C
+    market: USDM
+    model: WRX
+    year: 2006
+    engine: EJ255
+    transmission: 5MT
+    profile: turbo
+
+The profile is selected the first time the vehicle is logged. When it doesn't exist, it's
+added from the database's default profiles: naturally-aspirated, turbo, or diesel.`,
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		romID, err := hex.DecodeString(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid ROM ID '%s': %v", args[0], err)
+		}
+		var ssmID []byte
+		if len(args) > 1 {
+			if ssmID, err = hex.DecodeString(args[1]); err != nil {
+				return fmt.Errorf("invalid SSM ID '%s': %v", args[1], err)
+			}
+		}
+
+		e, ok := cfg.IdentifyVehicle(romID, ssmID)
+		if !ok {
+			return fmt.Errorf("the ROM ID %s isn't in the ROM ID database", args[0])
+		}
+		var year string
+		if e.Year > 0 {
+			year = fmt.Sprint(e.Year)
+		}
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+		for _, f := range [][2]string{
+			{"Vehicle", e.Label()}, {"ROM ID", e.ROMID}, {"SSM ID", e.SSMID}, {"Market", e.Market}, {"Model", e.Model},
+			{"Year", year}, {"Engine", e.Engine}, {"Transmission", e.Transmission}, {"Profile", e.Profile},
+		} {
+			if f[1] != "" {
+				fmt.Fprintf(w, "%s:\t%s\n", f[0], f[1])
+			}
+		}
+		return w.Flush()
+	},
+}
+
+var vehicleNicknameCmd = &cobra.Command{
+	Use:          "nickname <romId> <nickname>",
+	Short:        "Set a vehicle's nickname, which can be used as {{nickname}} in log file names. An empty nickname removes it",
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		romID, err := hex.DecodeString(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid ROM ID '%s': %v", args[0], err)
+		}
+
+		cfg.Vehicle(romID).Nickname = args[1]
+		return saveConfig()
+	},
+}
+
+var vehicleUnitCmd = &cobra.Command{
+	Use:          "unit <romId> <paramID> <unit>",
+	Short:        "Set the unit a parameter is logged in for a vehicle",
+	Args:         cobra.ExactArgs(3),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		romID, err := hex.DecodeString(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid ROM ID '%s': %v", args[0], err)
+		}
+		p, err := newProfileParameter(args[1], units.Unit(args[2]))
+		if err != nil {
+			return err
+		}
+
+		cfg.SetVehicleUnit(cfg.Vehicle(romID), *p, p.Unit)
+		return saveConfig()
+	},
+}
+
+var specs ssm2.Vehicle
+
+var vehicleSpecsCmd = &cobra.Command{
+	Use:          "specs <romId>",
+	Short:        "Show or set the vehicle specs used to calculate derived parameters. Only the given flags are changed",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		romID, err := hex.DecodeString(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid ROM ID '%s': %v", args[0], err)
+		}
+		v := cfg.Vehicle(romID)
+
+		flags := cmd.Flags()
+		for _, f := range []struct {
+			name  string
+			value float32
+		}{
+			{"displacement", specs.Displacement},
+			{"injectorSize", specs.InjectorSize},
+			{"finalDrive", specs.FinalDrive},
+		} {
+			if flags.Changed(f.name) && f.value <= 0 {
+				return fmt.Errorf("%s must be positive", f.name)
+			}
+		}
+		if flags.Changed("cylinders") && specs.Cylinders <= 0 {
+			return errors.New("cylinders must be positive")
+		}
+		if flags.Changed("ethanol") && (specs.Fuel.EthanolPercent < 0 || specs.Fuel.EthanolPercent > 100) {
+			return errors.New("ethanol must be between 0 and 100")
+		}
+		if flags.Changed("stoich") && specs.Fuel.StoichAFR < 0 {
+			return errors.New("stoich can't be negative")
+		}
+		if flags.Changed("fuel") && !validFuelType(specs.Fuel.Type) {
+			return fmt.Errorf("invalid fuel type '%s'. valid types: %s", specs.Fuel.Type, joinFuelTypes())
+		}
+		if flags.Changed("tireSize") {
+			if _, err = ssm2.ParseTireSize(specs.TireSize); err != nil {
+				return err
+			}
+		}
+		for _, r := range specs.GearRatios {
+			if r <= 0 {
+				return errors.New("gear ratios must be positive")
+			}
+		}
+
+		s := &v.Specs
+		changed := false
+		set := func(name string, apply func()) {
+			if flags.Changed(name) {
+				apply()
+				changed = true
+			}
+		}
+		set("displacement", func() { s.Displacement = specs.Displacement })
+		set("cylinders", func() { s.Cylinders = specs.Cylinders })
+		set("injectorSize", func() { s.InjectorSize = specs.InjectorSize })
+		set("fuel", func() { s.Fuel.Type = specs.Fuel.Type })
+		set("ethanol", func() { s.Fuel.EthanolPercent = specs.Fuel.EthanolPercent })
+		set("stoich", func() { s.Fuel.StoichAFR = specs.Fuel.StoichAFR })
+		set("tireSize", func() { s.TireSize = specs.TireSize })
+		set("gearRatios", func() { s.GearRatios = specs.GearRatios })
+		set("finalDrive", func() { s.FinalDrive = specs.FinalDrive })
+		*s = s.WithDefaults()
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+		fmt.Fprintf(w, "displacement\t%g L\n", s.Displacement)
+		fmt.Fprintf(w, "cylinders\t%d\n", s.Cylinders)
+		fmt.Fprintf(w, "injector size\t%g cc/min\n", s.InjectorSize)
+		fuel := string(s.Fuel.Type)
+		if s.Fuel.Type == ssm2.FuelEthanolBlend {
+			fuel = fmt.Sprintf("%s (E%g)", fuel, s.Fuel.EthanolPercent)
+		}
+		fmt.Fprintf(w, "fuel\t%s\n", fuel)
+		fmt.Fprintf(w, "stoich AFR\t%.2f\n", s.Fuel.Stoich())
+		fmt.Fprintf(w, "tire size\t%s\n", s.TireSize)
+		fmt.Fprintf(w, "gear ratios\t%s\n", strings.Trim(fmt.Sprint(s.GearRatios), "[]"))
+		fmt.Fprintf(w, "final drive\t%g\n", s.FinalDrive)
+		if err = w.Flush(); err != nil {
+			return err
+		}
+
+		if !changed {
+			return nil
+		}
+		return saveConfig()
+	},
+}
+
+func validFuelType(t ssm2.FuelType) bool {
+	for _, ft := range ssm2.FuelTypes {
+		if ft == t {
+			return true
+		}
+	}
+	return false
+}
+
+func joinFuelTypes() string {
+	types := make([]string, len(ssm2.FuelTypes))
+	for i, ft := range ssm2.FuelTypes {
+		types[i] = string(ft)
+	}
+	return strings.Join(types, ", ")
+}
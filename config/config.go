@@ -0,0 +1,357 @@
+// Package config loads and saves the settings shared by ssm2-cli and logger-ui.
+package config
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gavinwade12/ecLogger/formula"
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/romdb"
+	"github.com/gavinwade12/ecLogger/units"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// CurrentVersion is the version of the config schema written by Save.
+	CurrentVersion = 3
+
+	directoryName = "ssm2"
+	fileName      = "config.yaml"
+
+	// DefaultLogFileNameFormat is the default format for log file names.
+	DefaultLogFileNameFormat = "ssm2_log_" + FileNameVarROMID + "_" + FileNameVarTimestamp + ".csv"
+	// DefaultLogLevel is the default level for the debug log.
+	DefaultLogLevel = "INFO"
+)
+
+// The variables that can be used in a log file name format.
+const (
+	FileNameVarROMID     = "{{romId}}"
+	FileNameVarTimestamp = "{{timestamp}}"
+	// FileNameVarNickname is the vehicle's nickname, or the ROM ID when it doesn't have one.
+	FileNameVarNickname = "{{nickname}}"
+
+	// fileNameVarLegacyROMID is the ROM ID variable previously used by ssm2-cli.
+	fileNameVarLegacyROMID = "{{romID}}"
+)
+
+// Config contains the settings shared by ssm2-cli and logger-ui.
+type Config struct {
+	Version    int                    `yaml:"version"`
+	Port       string                 `yaml:"port"`
+	Connection ssm2.ConnectionOptions `yaml:"connection"`
+	Logging    Logging                `yaml:"logging"`
+	UI         UI                     `yaml:"ui"`
+	// Vehicles are the settings for each ECU that has been connected, sorted by ROM ID.
+	Vehicles []Vehicle `yaml:"vehicles,omitempty"`
+	// Formulas are the user-defined derived parameters.
+	Formulas []formula.Formula `yaml:"formulas,omitempty"`
+	// Calibrations are the user-defined calibrations of other parameters.
+	Calibrations []formula.Calibration `yaml:"calibrations,omitempty"`
+	// RAMParameters are the user-defined parameters read from RAM addresses.
+	RAMParameters []formula.RAMParameter `yaml:"ramParameters,omitempty"`
+	// Units choose the units new parameters are logged in. See SetUnitPreferences.
+	Units UnitPreferences `yaml:"units,omitempty"`
+	// ROMIDs are the user-defined entries of the ROM ID database, which take
+	// precedence over the built-in ones. See IdentifyVehicle.
+	ROMIDs []romdb.Entry `yaml:"romIds,omitempty"`
+}
+
+// Logging contains the settings for logging parameters.
+type Logging struct {
+	// Directory is where log files are created.
+	Directory string `yaml:"directory"`
+	// FileNameFormat is used to generate log file names. See FormatLogFileName.
+	FileNameFormat string `yaml:"fileNameFormat"`
+	// Profile is the name of the active profile. See ActiveProfile.
+	Profile string `yaml:"profile"`
+	// Profiles are the named sets of parameters selected for logging.
+	Profiles []Profile `yaml:"profiles"`
+}
+
+// Parameter is a parameter selected for logging.
+type Parameter struct {
+	ID      string `yaml:"id"`
+	Derived bool   `yaml:"derived"`
+	// Unit is the unit the parameter is logged in: the UnitOverride when it's set,
+	// otherwise the unit preferred by the unit system. See SetUnitPreferences.
+	Unit units.Unit `yaml:"unit,omitempty"`
+	// UnitOverride is the unit chosen for the parameter itself, which is kept
+	// when the unit system changes.
+	UnitOverride units.Unit `yaml:"unitOverride,omitempty"`
+	LogToFile    bool       `yaml:"logToFile"`
+	LiveLog      bool       `yaml:"liveLog"`
+	// Priority orders the parameters in a read request. See SelectParameters.
+	Priority int `yaml:"priority,omitempty"`
+}
+
+// UI contains the settings only used by logger-ui.
+type UI struct {
+	AutoConnect         bool   `yaml:"autoConnect"`
+	DefaultToLoggingTab bool   `yaml:"defaultToLoggingTab"`
+	UseFakeConnection   bool   `yaml:"useFakeConnection"`
+	LogLevel            string `yaml:"logLevel"`
+	// Favorites are the IDs of the parameters pinned to the top of the Parameters tab.
+	Favorites []string `yaml:"favorites,omitempty"`
+}
+
+// IsFavorite returns true if the parameter is a favorite.
+func (u UI) IsFavorite(id string) bool {
+	for _, f := range u.Favorites {
+		if f == id {
+			return true
+		}
+	}
+	return false
+}
+
+// SetFavorite adds the parameter to or removes it from the favorites.
+func (u *UI) SetFavorite(id string, favorite bool) {
+	for i, f := range u.Favorites {
+		if f == id {
+			if !favorite {
+				u.Favorites = append(u.Favorites[:i], u.Favorites[i+1:]...)
+			}
+			return
+		}
+	}
+	if favorite {
+		u.Favorites = append(u.Favorites, id)
+	}
+}
+
+// DebugLogLevel returns the configured level for logger-ui's debug log.
+func (u UI) DebugLogLevel() ssm2.Level {
+	l, err := ssm2.ParseLevel(u.LogLevel)
+	if err != nil {
+		return ssm2.LevelInfo
+	}
+	return l
+}
+
+// Dir returns the directory containing the config file, debug logs, and
+// (by default) parameter logs.
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "finding user home directory")
+	}
+	return filepath.Join(home, directoryName), nil
+}
+
+// DefaultPath returns the path of the config file used when none is specified.
+func DefaultPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fileName), nil
+}
+
+// Default returns a config containing the default settings.
+func Default() *Config {
+	c := &Config{Version: CurrentVersion}
+	c.setDefaults()
+	return c
+}
+
+// setDefaults fills in any unset settings with their defaults.
+func (c *Config) setDefaults() {
+	c.Connection = c.Connection.WithDefaults()
+	if c.Logging.Directory == "" {
+		if dir, err := Dir(); err == nil {
+			c.Logging.Directory = filepath.Join(dir, "logs")
+		}
+	}
+	if c.Logging.FileNameFormat == "" {
+		c.Logging.FileNameFormat = DefaultLogFileNameFormat
+	}
+	c.Logging.ActiveProfile()
+	for i := range c.Vehicles {
+		c.Vehicles[i].Specs = c.Vehicles[i].Specs.WithDefaults()
+	}
+	if _, err := ssm2.ParseLevel(c.UI.LogLevel); err != nil {
+		c.UI.LogLevel = DefaultLogLevel
+	}
+}
+
+// Load reads the config file at path. If the file doesn't exist, the settings
+// from the legacy ssm2-cli and logger-ui config files are migrated when they
+// exist. Otherwise, the default config is returned. The ROM ID database entries are
+// validated, and the RAM parameters, formulas, and calibrations are validated and
+// registered as parameters.
+func Load(path string) (*Config, error) {
+	legacy, err := defaultLegacyPaths()
+	if err != nil {
+		return nil, err
+	}
+	return load(path, legacy)
+}
+
+func load(path string, legacy legacyPaths) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, errors.Wrap(err, "reading config file")
+		}
+
+		c, err := migrateLegacyFiles(legacy)
+		if err != nil {
+			return nil, errors.Wrap(err, "migrating legacy config files")
+		}
+		c.setDefaults()
+		return c, nil
+	}
+
+	c, err := parse(b)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing config file '%s'", path)
+	}
+	c.setDefaults()
+	for i, e := range c.ROMIDs {
+		if err = e.Validate(); err != nil {
+			return nil, errors.Wrapf(err, "invalid ROM ID database entry %d in config file '%s'", i+1, path)
+		}
+	}
+	if err = formula.RegisterAll(c.definitions()); err != nil {
+		return nil, errors.Wrapf(err, "loading the user-defined parameters in config file '%s'", path)
+	}
+	return c, nil
+}
+
+// SetFormulas validates the formulas and registers them as derived parameters,
+// replacing the current formulas. Nothing is changed when a formula is invalid.
+func (c *Config) SetFormulas(formulas []formula.Formula) error {
+	if err := formula.Register(formulas); err != nil {
+		return err
+	}
+	c.Formulas = formulas
+	return nil
+}
+
+// SetCalibrations validates the calibrations and registers them as derived parameters,
+// replacing the current calibrations. Nothing is changed when a calibration is invalid.
+func (c *Config) SetCalibrations(calibrations []formula.Calibration) error {
+	d := c.definitions()
+	d.Calibrations = calibrations
+	if err := formula.RegisterAll(d); err != nil {
+		return err
+	}
+	c.Calibrations = calibrations
+	return nil
+}
+
+// SetRAMParameters validates the RAM parameters and registers them as parameters,
+// replacing the current RAM parameters. Nothing is changed when one is invalid.
+func (c *Config) SetRAMParameters(params []formula.RAMParameter) error {
+	d := c.definitions()
+	d.RAMParameters = params
+	if err := formula.RegisterAll(d); err != nil {
+		return err
+	}
+	c.RAMParameters = params
+	return nil
+}
+
+// definitions returns the user-defined parameters.
+func (c *Config) definitions() formula.Definitions {
+	return formula.Definitions{
+		RAMParameters: c.RAMParameters,
+		Formulas:      c.Formulas,
+		Calibrations:  c.Calibrations,
+	}
+}
+
+// parse decodes a config of any version and migrates it to the current version.
+func parse(b []byte) (*Config, error) {
+	raw := map[string]interface{}{}
+	if err := yaml.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+
+	raw, err := migrate(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	// round-trip the migrated values to decode them into the current schema
+	b, err = yaml.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	if err = yaml.Unmarshal(b, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Save writes the config to path, creating the directory if needed.
+func (c *Config) Save(path string) error {
+	c.Version = CurrentVersion
+
+	b, err := yaml.Marshal(c)
+	if err != nil {
+		return errors.Wrap(err, "encoding config")
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return errors.Wrap(err, "creating config directory")
+	}
+	if err = os.WriteFile(path, b, 0644); err != nil {
+		return errors.Wrap(err, "writing config file")
+	}
+	return nil
+}
+
+// FormatLogFileName replaces the variables in format with the hex-encoded ROM
+// ID, the nickname, and the timestamp. {{romID}} is accepted as an alias for
+// {{romId}}. The nickname is reduced to a safe file name by fileNamePart, and
+// the ROM ID is used for it when nothing is left.
+func FormatLogFileName(format string, romID []byte, nickname string, t time.Time) string {
+	id := hex.EncodeToString(romID)
+	if nickname = fileNamePart(nickname); nickname == "" {
+		nickname = id
+	}
+	return strings.NewReplacer(
+		FileNameVarROMID, id,
+		fileNameVarLegacyROMID, id,
+		FileNameVarNickname, nickname,
+		FileNameVarTimestamp, t.Format("20060102_150405"), //yyyyMMdd_hhmmss
+	).Replace(format)
+}
+
+// fileNamePart replaces the path separators and characters that aren't allowed in
+// file names with underscores, so s can't name another directory. Names of only
+// dots, like "..", are removed.
+func fileNamePart(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r < ' ' || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(s))
+	if strings.Trim(s, ".") == "" {
+		return ""
+	}
+	return s
+}
+
+// LogFilePath returns the path for a new log file. The file name format may
+// include its own directory, which is used instead of the log directory when absolute.
+func (c *Config) LogFilePath(romID []byte, t time.Time) string {
+	var nickname string
+	if v := c.FindVehicle(romID); v != nil {
+		nickname = v.Nickname
+	}
+	name := FormatLogFileName(c.Logging.FileNameFormat, romID, nickname, t)
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(c.Logging.Directory, name)
+}
@@ -0,0 +1,450 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gavinwade12/ecLogger/formula"
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+const legacyCLIFile = `config: ""
+connection:
+  baudrate: 9600
+  readtimeout: 2s
+logfileformat: '{{romID}}-{{timestamp}}.csv'
+logging:
+  parameters:
+  - derived: false
+    id: P8
+    unit: rpm
+  - derived: true
+    id: P200
+    unit: g/rev
+port: /dev/ttyUSB0
+quiet: false
+verbose: false
+`
+
+const legacyUIFile = `{"SelectedPort":"/dev/ttyUSB1","LogDirectory":"/home/pi/ssm2/logs",` +
+	`"LogFileNameFormat":"ssm2_log_{{romId}}_{{timestamp}}.csv",` +
+	`"LoggedParams":{"P8":{"LogToFile":false,"LiveLog":true,"Derived":false,"Unit":"rpm"},` +
+	`"P12":{"LogToFile":true,"LiveLog":true,"Derived":false,"Unit":"g/s"}},` +
+	`"UseFakeConnection":false,"AutoConnect":true,"DefaultToLoggingTab":true}`
+
+func writeFile(t *testing.T, dir, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("DefaultWithoutAnyFiles", func(t *testing.T) {
+		dir := t.TempDir()
+		c, err := load(filepath.Join(dir, fileName), legacyPaths{
+			cli: filepath.Join(dir, ".ssm2.yaml"),
+			ui:  filepath.Join(dir, ".ssm2"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Connection != ssm2.DefaultConnectionOptions() {
+			t.Fatalf("want default connection options. got: %+v", c.Connection)
+		}
+		if c.Logging.FileNameFormat != DefaultLogFileNameFormat {
+			t.Fatalf("want default file name format. got: %s", c.Logging.FileNameFormat)
+		}
+	})
+
+	t.Run("MigratesLegacyCLIFile", func(t *testing.T) {
+		dir := t.TempDir()
+		c, err := load(filepath.Join(dir, fileName), legacyPaths{
+			cli: writeFile(t, dir, ".ssm2.yaml", legacyCLIFile),
+			ui:  filepath.Join(dir, ".ssm2"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Port != "/dev/ttyUSB0" {
+			t.Fatalf("want port /dev/ttyUSB0. got: %s", c.Port)
+		}
+		if c.Connection.BaudRate != 9600 || c.Connection.ReadTimeout != time.Second*2 {
+			t.Fatalf("connection options weren't migrated: %+v", c.Connection)
+		}
+		if c.Connection.TotalReadTimeout != ssm2.DefaultConnectionOptions().TotalReadTimeout {
+			t.Fatalf("unset connection options should use defaults: %+v", c.Connection)
+		}
+		if c.Logging.FileNameFormat != DefaultLogFileNameFormat {
+			t.Fatalf("the legacy default format shouldn't be migrated. got: %s", c.Logging.FileNameFormat)
+		}
+
+		want := []Parameter{
+			{ID: "P8", Unit: units.RPM, LogToFile: true},
+			{ID: "P200", Derived: true, Unit: units.GramsPerRev, LogToFile: true},
+		}
+		if got := c.Logging.ActiveProfile().Parameters; !reflect.DeepEqual(got, want) {
+			t.Fatalf("want parameters %+v. got: %+v", want, got)
+		}
+	})
+
+	t.Run("MergesLegacyFiles", func(t *testing.T) {
+		dir := t.TempDir()
+		c, err := load(filepath.Join(dir, fileName), legacyPaths{
+			cli: writeFile(t, dir, ".ssm2.yaml", legacyCLIFile),
+			ui:  writeFile(t, dir, ".ssm2", legacyUIFile),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Port != "/dev/ttyUSB1" {
+			t.Fatalf("want the logger-ui port /dev/ttyUSB1. got: %s", c.Port)
+		}
+		if !c.UI.AutoConnect || !c.UI.DefaultToLoggingTab {
+			t.Fatalf("ui settings weren't migrated: %+v", c.UI)
+		}
+		if c.Logging.Directory != "/home/pi/ssm2/logs" {
+			t.Fatalf("want log directory /home/pi/ssm2/logs. got: %s", c.Logging.Directory)
+		}
+
+		want := []Parameter{
+			{ID: "P12", Unit: units.GS, LogToFile: true, LiveLog: true},
+			{ID: "P200", Derived: true, Unit: units.GramsPerRev, LogToFile: true},
+			{ID: "P8", Unit: units.RPM, LiveLog: true},
+		}
+		if got := c.Logging.ActiveProfile().Parameters; !reflect.DeepEqual(got, want) {
+			t.Fatalf("want parameters %+v. got: %+v", want, got)
+		}
+	})
+
+	t.Run("RenamesLegacyUIParameters", func(t *testing.T) {
+		dir := t.TempDir()
+		c, err := load(filepath.Join(dir, fileName), legacyPaths{
+			ui: writeFile(t, dir, ".ssm2", `{"LoggedParams":{`+
+				`"P243":{"LogToFile":true,"LiveLog":false,"Derived":true,"Unit":"%"},`+
+				`"P8":{"LogToFile":true,"LiveLog":true,"Derived":false,"Unit":"rpm"}}}`),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []Parameter{
+			{ID: "P242", Derived: true, Unit: units.Percent, LogToFile: true},
+			{ID: "P8", Unit: units.RPM, LogToFile: true, LiveLog: true},
+		}
+		if got := c.Logging.ActiveProfile().Parameters; !reflect.DeepEqual(got, want) {
+			t.Fatalf("want parameters %+v. got: %+v", want, got)
+		}
+	})
+
+	t.Run("RoundTrips", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "sub", fileName)
+
+		c := Default()
+		c.Port = "/dev/ttyUSB0"
+		c.Connection.EchoHandling = ssm2.EchoHandlingDiscard
+		c.Logging.ActiveProfile().SetParameters([]Parameter{{ID: "P8", Unit: units.RPM, LiveLog: true}})
+		if err := c.Save(path); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := load(path, legacyPaths{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, c) {
+			t.Fatalf("want %+v. got: %+v", c, got)
+		}
+	})
+
+	t.Run("MigratesV1Parameters", func(t *testing.T) {
+		dir := t.TempDir()
+		c, err := load(writeFile(t, dir, fileName, `version: 1
+logging:
+  parameters:
+  - id: P8
+    unit: rpm
+    liveLog: true
+`), legacyPaths{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Logging.Profile != DefaultProfileName || len(c.Logging.Profiles) != 1 {
+			t.Fatalf("want only the default profile. got: %+v", c.Logging)
+		}
+		want := []Parameter{{ID: "P8", Unit: units.RPM, LiveLog: true}}
+		if got := c.Logging.ActiveProfile().Parameters; !reflect.DeepEqual(got, want) {
+			t.Fatalf("want parameters %+v. got: %+v", want, got)
+		}
+	})
+
+	t.Run("MigratesV2RenamedParameters", func(t *testing.T) {
+		dir := t.TempDir()
+		c, err := load(writeFile(t, dir, fileName, `version: 2
+logging:
+  profile: default
+  profiles:
+  - name: default
+    parameters:
+    - id: P242
+      derived: true
+      unit: '%'
+      liveLog: true
+    - id: P243
+      derived: true
+      unit: '%'
+      logToFile: true
+  - name: track
+    parameters:
+    - id: P243
+      derived: true
+      unit: '%'
+      logToFile: true
+vehicles:
+- romId: 1040a132b1
+  units:
+    P243: '%'
+`), legacyPaths{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []Parameter{{ID: "P242", Derived: true, Unit: units.Percent, LiveLog: true}}
+		if got := c.Logging.FindProfile(DefaultProfileName).Parameters; !reflect.DeepEqual(got, want) {
+			t.Fatalf("want parameters %+v. got: %+v", want, got)
+		}
+		want = []Parameter{{ID: "P242", Derived: true, Unit: units.Percent, LogToFile: true}}
+		if got := c.Logging.FindProfile("track").Parameters; !reflect.DeepEqual(got, want) {
+			t.Fatalf("want parameters %+v. got: %+v", want, got)
+		}
+		if got := c.Vehicles[0].Units; !reflect.DeepEqual(got, map[string]units.Unit{"P242": units.Percent}) {
+			t.Fatalf("want the vehicle unit renamed. got: %+v", got)
+		}
+		if c.Vehicles[0].Specs.Displacement != ssm2.DefaultVehicle().Displacement {
+			t.Fatalf("want the default specs. got: %+v", c.Vehicles[0].Specs)
+		}
+	})
+
+	t.Run("MigratesV2LegacyVEDisplacement", func(t *testing.T) {
+		dir := t.TempDir()
+		c, err := load(writeFile(t, dir, fileName, `version: 2
+logging:
+  profile: default
+  profiles:
+  - name: default
+    parameters:
+    - id: P242
+      derived: true
+      liveLog: true
+vehicles:
+- romId: 1040a132b1
+- romId: 2040a132b1
+  specs:
+    displacement: 1.6
+`), legacyPaths{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the old P242 calculated the VE of a 2.0L engine
+		if got := c.Vehicles[0].Specs.Displacement; got != 2 {
+			t.Fatalf("want a 2.0L displacement. got: %v", got)
+		}
+		if got := c.Vehicles[1].Specs.Displacement; got != 1.6 {
+			t.Fatalf("want the configured displacement kept. got: %v", got)
+		}
+		if got := c.Vehicles[0].Specs.Cylinders; got != ssm2.DefaultVehicle().Cylinders {
+			t.Fatalf("want the other specs defaulted. got: %+v", c.Vehicles[0].Specs)
+		}
+	})
+
+	t.Run("RegistersFormulas", func(t *testing.T) {
+		defer formula.Register(nil)
+
+		dir := t.TempDir()
+		c, err := load(writeFile(t, dir, fileName, `version: 3
+formulas:
+- id: boost_error
+  name: Boost Error
+  expression: P25[psi] - P7[psi]
+  unit: psi
+`), legacyPaths{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(c.Formulas) != 1 {
+			t.Fatalf("want the formula loaded. got: %+v", c.Formulas)
+		}
+		if p, ok := ssm2.DerivedParameters["boost_error"]; !ok || p.DefaultUnit != units.PSI {
+			t.Fatalf("want the formula registered as a derived parameter. got: %+v", p)
+		}
+
+		_, err = load(writeFile(t, dir, fileName, `version: 3
+formulas:
+- id: boost_error
+  name: Boost Error
+  expression: P25[psi] -
+`), legacyPaths{})
+		if err == nil {
+			t.Fatal("expected an error for an invalid formula")
+		}
+	})
+
+	t.Run("RegistersCalibrations", func(t *testing.T) {
+		defer formula.RegisterAll(formula.Definitions{})
+
+		dir := t.TempDir()
+		c, err := load(writeFile(t, dir, fileName, `version: 3
+formulas:
+- id: fuel_used
+  name: Fuel Used
+  expression: 16 - fuel_remaining
+  unit: gal (US)
+calibrations:
+- id: fuel_remaining
+  name: Fuel Remaining
+  source: P119
+  table:
+  - {in: 5, out: 16}
+  - {in: 90, out: 0}
+  unit: gal (US)
+`), legacyPaths{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(c.Calibrations) != 1 || len(c.Calibrations[0].Table) != 2 {
+			t.Fatalf("want the calibration loaded. got: %+v", c.Calibrations)
+		}
+		for _, id := range []string{"fuel_remaining", "fuel_used"} {
+			if _, ok := ssm2.DerivedParameters[id]; !ok {
+				t.Fatalf("want %s registered as a derived parameter", id)
+			}
+		}
+
+		// the formula references the calibration
+		if err = c.SetCalibrations(nil); err == nil {
+			t.Fatal("expected an error")
+		}
+		if len(c.Calibrations) != 1 {
+			t.Fatal("want the calibrations kept after an invalid change")
+		}
+	})
+
+	t.Run("RegistersRAMParameters", func(t *testing.T) {
+		defer formula.RegisterAll(formula.Definitions{})
+
+		dir := t.TempDir()
+		c, err := load(writeFile(t, dir, fileName, `version: 3
+ramParameters:
+- id: launch_active
+  name: Launch Control Active
+  address: FF6B1E
+  length: 1
+  mask: 0x80
+`), legacyPaths{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(c.RAMParameters) != 1 || c.RAMParameters[0].Mask != 0x80 {
+			t.Fatalf("want the RAM parameter loaded. got: %+v", c.RAMParameters)
+		}
+		if p, ok := ssm2.Parameters["launch_active"]; !ok || !p.Custom {
+			t.Fatalf("want the RAM parameter registered as a parameter. got: %+v", p)
+		}
+
+		if err = c.SetRAMParameters([]formula.RAMParameter{{ID: "launch_active", Name: "Launch Control Active", Address: "FF6B1E"}}); err == nil {
+			t.Fatal("expected an error for a RAM parameter without a length")
+		}
+		if err = c.SetRAMParameters(nil); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := ssm2.Parameters["launch_active"]; ok {
+			t.Fatal("want the RAM parameter removed")
+		}
+	})
+
+	t.Run("ValidatesROMIDs", func(t *testing.T) {
+		dir := t.TempDir()
+		c, err := load(writeFile(t, dir, fileName, `version: 3
+romIds:
+- romId: 2F12785606
+  model: WRX
+`), legacyPaths{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e, ok := c.IdentifyVehicle([]byte{0x2F, 0x12, 0x78, 0x56, 0x06}, nil); !ok || e.Model != "WRX" {
+			t.Fatalf("want the vehicle identified. got: %+v", e)
+		}
+
+		_, err = load(writeFile(t, dir, fileName, `version: 3
+romIds:
+- romId: 2F1278
+  model: WRX
+`), legacyPaths{})
+		if err == nil {
+			t.Fatal("expected an error for an invalid ROM ID")
+		}
+	})
+
+	t.Run("RejectsNewerVersions", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := load(writeFile(t, dir, fileName, "version: 99\n"), legacyPaths{})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
+
+func TestFormatLogFileName(t *testing.T) {
+	ts := time.Date(2024, 3, 9, 14, 5, 6, 0, time.UTC)
+	romID := []byte{0x10, 0x40, 0xA1, 0x32, 0xB1}
+
+	for _, format := range []string{"{{romId}}-{{timestamp}}.csv", "{{romID}}-{{timestamp}}.csv", "{{nickname}}-{{timestamp}}.csv"} {
+		if got := FormatLogFileName(format, romID, "", ts); got != "1040a132b1-20240309_140506.csv" {
+			t.Fatalf("FormatLogFileName(%q) = %s", format, got)
+		}
+	}
+
+	if got := FormatLogFileName("{{nickname}}-{{timestamp}}.csv", romID, "wrx", ts); got != "wrx-20240309_140506.csv" {
+		t.Fatalf("want the nickname in the file name. got: %s", got)
+	}
+
+	nicknames := map[string]string{
+		"../../etc/wrx": ".._.._etc_wrx",
+		`c:\wrx`:        "c__wrx",
+		"..":            "1040a132b1",
+		" . ":           "1040a132b1",
+		"sti.v2":        "sti.v2",
+	}
+	for nickname, want := range nicknames {
+		if got := FormatLogFileName("{{nickname}}.csv", romID, nickname, ts); got != want+".csv" {
+			t.Errorf("nickname %q: want %s.csv. got: %s", nickname, want, got)
+		}
+	}
+}
+
+func TestUI_SetFavorite(t *testing.T) {
+	var u UI
+	u.SetFavorite("P8", true)
+	u.SetFavorite("P23", true)
+	u.SetFavorite("P8", true)
+	if !reflect.DeepEqual(u.Favorites, []string{"P8", "P23"}) {
+		t.Fatalf("want each favorite added once. got: %v", u.Favorites)
+	}
+
+	u.SetFavorite("P8", false)
+	if u.IsFavorite("P8") || !u.IsFavorite("P23") {
+		t.Fatalf("want only P8 removed. got: %v", u.Favorites)
+	}
+}
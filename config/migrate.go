@@ -0,0 +1,320 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+	"github.com/pkg/errors"
+)
+
+// legacyPaths are the config files used before the config package existed.
+type legacyPaths struct {
+	// cli is the YAML file ssm2-cli managed through viper (version 0).
+	cli string
+	// ui is the JSON file logger-ui saved its Config struct to.
+	ui string
+}
+
+func defaultLegacyPaths() (legacyPaths, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return legacyPaths{}, errors.Wrap(err, "finding user home directory")
+	}
+	return legacyPaths{
+		cli: filepath.Join(home, ".ssm2.yaml"),
+		ui:  filepath.Join(home, directoryName, ".ssm2"),
+	}, nil
+}
+
+// migrations[i] migrates a decoded config file from version i to version i+1.
+var migrations = []func(raw map[string]interface{}) (map[string]interface{}, error){
+	migrateV0,
+	migrateV1,
+	migrateV2,
+}
+
+// migrate runs the migrations needed to bring raw up to the CurrentVersion.
+// Files without a version are treated as version 0.
+func migrate(raw map[string]interface{}) (map[string]interface{}, error) {
+	version := 0
+	if v, ok := raw["version"]; ok {
+		i, ok := v.(int)
+		if !ok {
+			return nil, fmt.Errorf("invalid config version '%v'", v)
+		}
+		version = i
+	}
+	if version > CurrentVersion {
+		return nil, fmt.Errorf("config version %d is newer than the supported version %d", version, CurrentVersion)
+	}
+
+	var err error
+	for ; version < CurrentVersion; version++ {
+		if raw, err = migrations[version](raw); err != nil {
+			return nil, errors.Wrapf(err, "migrating config from version %d", version)
+		}
+	}
+	return raw, nil
+}
+
+// legacyCLILogFileFormat is the default ssm2-cli log file format, which viper
+// wrote to the config file along with the other flags.
+const legacyCLILogFileFormat = "{{romID}}-{{timestamp}}.csv"
+
+// migrateV0 converts the ssm2-cli config file written by viper. Viper
+// lower-cases every key, so keys are matched without regard to case.
+func migrateV0(raw map[string]interface{}) (map[string]interface{}, error) {
+	raw = lowerKeys(raw)
+
+	logging := map[string]interface{}{}
+	if f, ok := raw["logfileformat"].(string); ok && f != "" && f != legacyCLILogFileFormat {
+		logging["fileNameFormat"] = strings.ReplaceAll(f, fileNameVarLegacyROMID, FileNameVarROMID)
+	}
+
+	legacyLogging, _ := raw["logging"].(map[string]interface{})
+	if params, ok := legacyLogging["parameters"].([]interface{}); ok {
+		migrated := make([]interface{}, 0, len(params))
+		for _, p := range params {
+			p, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			migrated = append(migrated, map[string]interface{}{
+				"id":        p["id"],
+				"derived":   p["derived"],
+				"unit":      p["unit"],
+				"logToFile": true, // the CLI only ever logged to file
+			})
+		}
+		logging["parameters"] = migrated
+	}
+
+	out := map[string]interface{}{
+		"version": 1,
+		"port":    raw["port"],
+		"logging": logging,
+	}
+
+	if conn, ok := raw["connection"].(map[string]interface{}); ok {
+		migrated := map[string]interface{}{}
+		for _, key := range []string{"baudRate", "readTimeout", "totalReadTimeout", "maxReadAttempts",
+			"maxConsecutiveErrors", "echoHandling", "wireTiming"} {
+			if v, ok := conn[strings.ToLower(key)]; ok {
+				migrated[key] = v
+			}
+		}
+		out["connection"] = migrated
+	}
+
+	return out, nil
+}
+
+// migrateV1 moves the logged parameters into the default profile.
+func migrateV1(raw map[string]interface{}) (map[string]interface{}, error) {
+	logging, _ := raw["logging"].(map[string]interface{})
+	if logging == nil {
+		logging = map[string]interface{}{}
+	}
+
+	params := logging["parameters"]
+	if params == nil {
+		params = []interface{}{}
+	}
+	delete(logging, "parameters")
+	logging["profile"] = DefaultProfileName
+	logging["profiles"] = []interface{}{
+		map[string]interface{}{"name": DefaultProfileName, "parameters": params},
+	}
+
+	raw["logging"] = logging
+	raw["version"] = 2
+	return raw, nil
+}
+
+// renamedParameters maps the IDs of removed parameters to the parameters replacing them.
+var renamedParameters = map[string]string{
+	// the 2.0L and 2.5L VE parameters were replaced by P242, which uses the vehicle's displacement
+	"P243": "P242",
+}
+
+// legacyVEDisplacement is the displacement the old P242 calculated the volumetric efficiency for.
+const legacyVEDisplacement = 2.0
+
+// migrateV2 replaces the IDs of renamed parameters in the profiles and vehicle settings.
+// When the profiles logged the old 2.0L P242 but not the 2.5L P243, the vehicles without
+// a displacement are given 2.0L so P242 keeps calculating the same volumetric efficiency.
+func migrateV2(raw map[string]interface{}) (map[string]interface{}, error) {
+	logging, _ := raw["logging"].(map[string]interface{})
+	profiles, _ := logging["profiles"].([]interface{})
+	logged := map[string]bool{}
+	for _, p := range profiles {
+		p, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		params, _ := p["parameters"].([]interface{})
+
+		seen := map[interface{}]bool{}
+		migrated := make([]interface{}, 0, len(params))
+		for _, param := range params {
+			param, ok := param.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if id, ok := param["id"].(string); ok {
+				logged[id] = true
+				if renamedParameters[id] != "" {
+					param["id"] = renamedParameters[id]
+				}
+			}
+			if seen[param["id"]] {
+				continue
+			}
+			seen[param["id"]] = true
+			migrated = append(migrated, param)
+		}
+		if params != nil {
+			p["parameters"] = migrated
+		}
+	}
+
+	vehicles, _ := raw["vehicles"].([]interface{})
+	for _, v := range vehicles {
+		v, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, key := range []string{"units", "alerts"} {
+			m, _ := v[key].(map[string]interface{})
+			for from, to := range renamedParameters {
+				if val, ok := m[from]; ok {
+					if _, exists := m[to]; !exists {
+						m[to] = val
+					}
+					delete(m, from)
+				}
+			}
+		}
+
+		if logged["P242"] && !logged["P243"] {
+			specs, _ := v["specs"].(map[string]interface{})
+			if specs == nil {
+				specs = map[string]interface{}{}
+				v["specs"] = specs
+			}
+			if _, ok := specs["displacement"]; !ok {
+				specs["displacement"] = legacyVEDisplacement
+			}
+		}
+	}
+
+	raw["version"] = 3
+	return raw, nil
+}
+
+// lowerKeys returns a copy of m with all keys (including nested ones) lower-cased.
+func lowerKeys(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if vm, ok := v.(map[string]interface{}); ok {
+			v = lowerKeys(vm)
+		}
+		out[strings.ToLower(k)] = v
+	}
+	return out
+}
+
+// legacyUIConfig is the Config struct logger-ui encoded to JSON.
+type legacyUIConfig struct {
+	SelectedPort      string
+	Connection        *ssm2.ConnectionOptions
+	LogDirectory      *string
+	LogFileNameFormat *string
+	LoggedParams      map[string]*struct {
+		LogToFile bool
+		LiveLog   bool
+		Derived   bool
+		Unit      units.Unit
+	}
+	UseFakeConnection   bool
+	AutoConnect         bool
+	DefaultToLoggingTab bool
+	LogLevel            string
+}
+
+// migrateLegacyFiles merges the legacy ssm2-cli and logger-ui config files
+// into a single config. The logger-ui settings win when both set the same value.
+func migrateLegacyFiles(paths legacyPaths) (*Config, error) {
+	c := &Config{Version: CurrentVersion}
+
+	if b, err := os.ReadFile(paths.cli); err == nil {
+		if c, err = parse(b); err != nil {
+			return nil, errors.Wrapf(err, "parsing ssm2-cli config file '%s'", paths.cli)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "reading ssm2-cli config file")
+	}
+
+	b, err := os.ReadFile(paths.ui)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return nil, errors.Wrap(err, "reading logger-ui config file")
+	}
+
+	var ui legacyUIConfig
+	if err = json.Unmarshal(b, &ui); err != nil {
+		return nil, errors.Wrapf(err, "parsing logger-ui config file '%s'", paths.ui)
+	}
+
+	if ui.SelectedPort != "" {
+		c.Port = ui.SelectedPort
+	}
+	if ui.Connection != nil {
+		c.Connection = *ui.Connection
+	}
+	if ui.LogDirectory != nil {
+		c.Logging.Directory = *ui.LogDirectory
+	}
+	if ui.LogFileNameFormat != nil {
+		c.Logging.FileNameFormat = *ui.LogFileNameFormat
+	}
+	c.UI = UI{
+		AutoConnect:         ui.AutoConnect,
+		DefaultToLoggingTab: ui.DefaultToLoggingTab,
+		UseFakeConnection:   ui.UseFakeConnection,
+		LogLevel:            ui.LogLevel,
+	}
+
+	profile := c.Logging.ActiveProfile()
+	params := map[string]Parameter{}
+	for _, p := range profile.Parameters {
+		params[p.ID] = p
+	}
+	for id, p := range ui.LoggedParams {
+		if p == nil {
+			continue
+		}
+		// rename the parameters like migrateV2, keeping the replacement when both are logged
+		if renamed, ok := renamedParameters[id]; ok {
+			if ui.LoggedParams[renamed] != nil {
+				continue
+			}
+			id = renamed
+		}
+		params[id] = Parameter{ID: id, Derived: p.Derived, Unit: p.Unit, LogToFile: p.LogToFile, LiveLog: p.LiveLog}
+	}
+	merged := make([]Parameter, 0, len(params))
+	for _, p := range params {
+		merged = append(merged, p)
+	}
+	profile.SetParameters(merged)
+
+	return c, nil
+}
@@ -0,0 +1,303 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultProfileName is the name of the profile created when there are none.
+const DefaultProfileName = "default"
+
+// The named sample priorities. Any integer can be used; higher values are read first.
+const (
+	PriorityLow    = -1
+	PriorityNormal = 0
+	PriorityHigh   = 1
+)
+
+// ProfileFileVersion is the version of the file format written by ExportProfile.
+const ProfileFileVersion = 1
+
+// Profile is a named set of parameters selected for logging.
+type Profile struct {
+	Name       string      `yaml:"name"`
+	Parameters []Parameter `yaml:"parameters"`
+}
+
+// Parameter returns the profile's parameter with the given ID, or nil.
+func (p *Profile) Parameter(id string) *Parameter {
+	for i := range p.Parameters {
+		if p.Parameters[i].ID == id {
+			return &p.Parameters[i]
+		}
+	}
+	return nil
+}
+
+// SetParameters replaces the profile's parameters, sorting them by ID
+// so the file has a stable order.
+func (p *Profile) SetParameters(params []Parameter) {
+	sorted := append([]Parameter{}, params...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	p.Parameters = sorted
+}
+
+// RemoveParameter removes the parameter with the given ID, returning false if
+// the profile doesn't contain it.
+func (p *Profile) RemoveParameter(id string) bool {
+	for i := range p.Parameters {
+		if p.Parameters[i].ID == id {
+			p.Parameters = append(p.Parameters[:i], p.Parameters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// FindProfile returns the profile with the given name, or nil.
+func (l *Logging) FindProfile(name string) *Profile {
+	for i := range l.Profiles {
+		if l.Profiles[i].Name == name {
+			return &l.Profiles[i]
+		}
+	}
+	return nil
+}
+
+// ActiveProfile returns the profile selected for logging. If the selected profile
+// doesn't exist, the first profile is selected, and the default profile is
+// added when there are none. The returned pointer is invalidated by AddProfile.
+func (l *Logging) ActiveProfile() *Profile {
+	if p := l.FindProfile(l.Profile); p != nil {
+		return p
+	}
+	if len(l.Profiles) == 0 {
+		l.Profiles = []Profile{{Name: DefaultProfileName}}
+	}
+	l.Profile = l.Profiles[0].Name
+	return &l.Profiles[0]
+}
+
+// ProfileNames returns the names of the profiles in order.
+func (l *Logging) ProfileNames() []string {
+	names := make([]string, len(l.Profiles))
+	for i, p := range l.Profiles {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// AddProfile adds p to the profiles. It returns an error if the name is
+// empty or already used.
+func (l *Logging) AddProfile(p Profile) error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return errors.New("the profile name is required")
+	}
+	if l.FindProfile(p.Name) != nil {
+		return fmt.Errorf("profile '%s' already exists", p.Name)
+	}
+
+	p.SetParameters(p.Parameters)
+	l.Profiles = append(l.Profiles, p)
+	return nil
+}
+
+// RemoveProfile removes the profile with the given name. The first
+// remaining profile is selected if the active profile is removed.
+func (l *Logging) RemoveProfile(name string) error {
+	for i := range l.Profiles {
+		if l.Profiles[i].Name == name {
+			l.Profiles = append(l.Profiles[:i], l.Profiles[i+1:]...)
+			l.ActiveProfile()
+			return nil
+		}
+	}
+	return fmt.Errorf("profile '%s' doesn't exist", name)
+}
+
+// UseProfile selects the profile with the given name for logging.
+func (l *Logging) UseProfile(name string) error {
+	if l.FindProfile(name) == nil {
+		return fmt.Errorf("profile '%s' doesn't exist", name)
+	}
+	l.Profile = name
+	return nil
+}
+
+// profileFile is the portable format profiles are exported to and imported from.
+type profileFile struct {
+	Version int `yaml:"version"`
+	Profile `yaml:",inline"`
+}
+
+// ExportProfile writes p to w in a format that can be read by ImportProfile.
+func ExportProfile(w io.Writer, p Profile) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(profileFile{ProfileFileVersion, p}); err != nil {
+		return errors.Wrap(err, "encoding profile")
+	}
+	return enc.Close()
+}
+
+// ImportProfile reads a profile written by ExportProfile. The parameter IDs
+// are validated against the known parameters.
+func ImportProfile(r io.Reader) (*Profile, error) {
+	var f profileFile
+	if err := yaml.NewDecoder(r).Decode(&f); err != nil {
+		return nil, errors.Wrap(err, "decoding profile")
+	}
+	if f.Version > ProfileFileVersion {
+		return nil, fmt.Errorf("profile version %d is newer than the supported version %d", f.Version, ProfileFileVersion)
+	}
+
+	catalog, derivedCatalog := ssm2.Catalog()
+	params := make([]Parameter, 0, len(f.Parameters))
+	seen := map[string]bool{}
+	for _, p := range f.Parameters {
+		if id, ok := renamedParameters[p.ID]; ok {
+			p.ID = id
+		}
+		_, isParam := catalog[p.ID]
+		_, isDerived := derivedCatalog[p.ID]
+		if !isParam && !isDerived {
+			return nil, fmt.Errorf("unknown parameter '%s'", p.ID)
+		}
+		if seen[p.ID] {
+			continue
+		}
+		seen[p.ID] = true
+		p.Derived = isDerived
+		params = append(params, p)
+	}
+
+	f.Profile.SetParameters(params)
+	return &f.Profile, nil
+}
+
+// Selection contains the parameters selected to be read in a logging session.
+type Selection struct {
+	// Parameters and Derived are ordered by priority, highest first.
+	Parameters []ssm2.Parameter
+	Derived    []ssm2.DerivedParameter
+
+	// Unsupported contains the IDs of the parameters the ECU doesn't support.
+	Unsupported []string
+	// Dropped contains the IDs of the lowest priority parameters that
+	// didn't fit into a single read request.
+	Dropped []string
+	// Dependencies contains the IDs of the parameters that weren't selected but will
+	// be read since selected derived parameters depend on them.
+	Dependencies []string
+}
+
+// SelectParameters selects the logged parameters supported by the ECU, or all
+// of them when ecu is nil. Parameters with a higher priority are read first,
+// and those with the lowest priority are dropped when more than
+// ssm2.MaxReadAddresses addresses would need to be read, counting the
+// parameters derived parameters depend on.
+func SelectParameters(logged []Parameter, ecu *ssm2.ECU) Selection {
+	sorted := append([]Parameter{}, logged...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority > sorted[j].Priority
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	var supported map[string]bool
+	if ecu != nil {
+		supported = map[string]bool{}
+		for _, p := range ecu.SupportedParameters {
+			supported[p.Id] = true
+		}
+		for _, p := range ecu.SupportedDerivedParameters {
+			supported[p.Id] = true
+		}
+	}
+
+	catalog, derivedCatalog := ssm2.Catalog()
+	s := Selection{
+		Parameters: []ssm2.Parameter{},
+		Derived:    []ssm2.DerivedParameter{},
+	}
+	var (
+		addresses = 0
+		read      = map[string]bool{}
+		selected  = map[string]bool{}
+		// reads are all the parameters that will be read in the order they were added
+		reads []string
+	)
+	// fits adds the addresses of the parameters that aren't already being read,
+	// returning false when they don't fit into the request.
+	fits := func(params ...ssm2.Parameter) bool {
+		n := 0
+		for _, p := range params {
+			if !read[p.Id] {
+				n += p.Address.Length
+			}
+		}
+		if addresses+n > ssm2.MaxReadAddresses {
+			return false
+		}
+
+		addresses += n
+		for _, p := range params {
+			if !read[p.Id] {
+				read[p.Id] = true
+				reads = append(reads, p.Id)
+			}
+		}
+		return true
+	}
+
+	for _, p := range sorted {
+		if supported != nil && !supported[p.ID] {
+			s.Unsupported = append(s.Unsupported, p.ID)
+			continue
+		}
+
+		if p.Derived {
+			dp, ok := derivedCatalog[p.ID]
+			if !ok {
+				continue
+			}
+			_, deps, err := ssm2.ResolveDerivedParameters([]ssm2.DerivedParameter{dp})
+			if err != nil {
+				s.Unsupported = append(s.Unsupported, p.ID)
+				continue
+			}
+			if !fits(deps...) {
+				s.Dropped = append(s.Dropped, p.ID)
+				continue
+			}
+			s.Derived = append(s.Derived, dp)
+			continue
+		}
+
+		param, ok := catalog[p.ID]
+		if !ok || param.Address == nil {
+			continue
+		}
+		if !fits(param) {
+			s.Dropped = append(s.Dropped, p.ID)
+			continue
+		}
+		selected[p.ID] = true
+		s.Parameters = append(s.Parameters, param)
+	}
+
+	for _, id := range reads {
+		if !selected[id] {
+			s.Dependencies = append(s.Dependencies, id)
+		}
+	}
+	return s
+}
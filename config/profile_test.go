@@ -0,0 +1,117 @@
+package config
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+func TestProfiles(t *testing.T) {
+	l := Default().Logging
+	if l.ActiveProfile().Name != DefaultProfileName {
+		t.Fatalf("want the default profile to be active. got: %s", l.Profile)
+	}
+
+	if err := l.AddProfile(Profile{Name: "knock"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.AddProfile(Profile{Name: "knock"}); err == nil {
+		t.Fatal("expected an error adding a duplicate profile")
+	}
+	if err := l.UseProfile("boost"); err == nil {
+		t.Fatal("expected an error using a missing profile")
+	}
+	if err := l.UseProfile("knock"); err != nil {
+		t.Fatal(err)
+	}
+	if l.ActiveProfile().Name != "knock" {
+		t.Fatalf("want the knock profile to be active. got: %s", l.Profile)
+	}
+
+	if err := l.RemoveProfile("knock"); err != nil {
+		t.Fatal(err)
+	}
+	if l.ActiveProfile().Name != DefaultProfileName {
+		t.Fatalf("want the default profile to be active after removing knock. got: %s", l.Profile)
+	}
+}
+
+func TestExportImportProfile(t *testing.T) {
+	p := Profile{Name: "fueling", Parameters: []Parameter{
+		{ID: "P8", Unit: units.RPM, LiveLog: true, Priority: PriorityHigh},
+		{ID: "P200", Derived: true, Unit: units.GramsPerRev, LogToFile: true},
+	}}
+
+	var b bytes.Buffer
+	if err := ExportProfile(&b, p); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ImportProfile(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.SetParameters(p.Parameters)
+	if !reflect.DeepEqual(*got, p) {
+		t.Fatalf("want %+v. got: %+v", p, *got)
+	}
+
+	if _, err = ImportProfile(bytes.NewBufferString("name: bad\nparameters:\n- id: P9999\n")); err == nil {
+		t.Fatal("expected an error importing an unknown parameter")
+	}
+}
+
+func TestSelectParameters(t *testing.T) {
+	logged := []Parameter{
+		{ID: "P2"},
+		{ID: "P8", Priority: PriorityHigh},
+		{ID: "P200", Derived: true},
+	}
+	ecu := &ssm2.ECU{
+		SupportedParameters:        []ssm2.Parameter{ssm2.Parameters["P2"], ssm2.Parameters["P8"]},
+		SupportedDerivedParameters: []ssm2.DerivedParameter{},
+	}
+
+	s := SelectParameters(logged, ecu)
+	if len(s.Parameters) != 2 || s.Parameters[0].Id != "P8" || s.Parameters[1].Id != "P2" {
+		t.Fatalf("want P8 then P2. got: %+v", s.Parameters)
+	}
+	if !reflect.DeepEqual(s.Unsupported, []string{"P200"}) {
+		t.Fatalf("want P200 to be unsupported. got: %v", s.Unsupported)
+	}
+
+	// the parameters derived parameters depend on are read even when they aren't selected
+	s = SelectParameters([]Parameter{{ID: "P8"}, {ID: "P242", Derived: true}}, nil)
+	if len(s.Parameters) != 1 || len(s.Derived) != 1 {
+		t.Fatalf("want only the selected parameters. got: %+v", s)
+	}
+	if want := []string{"P12", "P11", "P7"}; !reflect.DeepEqual(s.Dependencies, want) {
+		t.Fatalf("want dependencies %v. got: %v", want, s.Dependencies)
+	}
+
+	// fill the request with more addresses than can be read at once
+	logged = nil
+	for id, p := range ssm2.Parameters {
+		if p.Address != nil && id != "P8" {
+			logged = append(logged, Parameter{ID: id})
+		}
+	}
+	logged = append(logged, Parameter{ID: "P8", Priority: PriorityHigh})
+	s = SelectParameters(logged, nil)
+	if len(s.Dropped) == 0 {
+		t.Fatal("expected parameters to be dropped")
+	}
+	if s.Parameters[0].Id != "P8" {
+		t.Fatalf("want the high priority parameter first. got: %s", s.Parameters[0].Id)
+	}
+	addresses := 0
+	for _, p := range s.Parameters {
+		addresses += p.Address.Length
+	}
+	if addresses > ssm2.MaxReadAddresses {
+		t.Fatalf("want at most %d addresses. got: %d", ssm2.MaxReadAddresses, addresses)
+	}
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+	"github.com/pkg/errors"
+)
+
+// The unit systems parameters can be logged in.
+const (
+	// UnitSystemDefault logs each parameter in its default unit.
+	UnitSystemDefault  = "default"
+	UnitSystemMetric   = "metric"
+	UnitSystemImperial = "imperial"
+	// UnitSystemCustom logs parameters in the units chosen for each dimension.
+	UnitSystemCustom = "custom"
+)
+
+// UnitSystems are the names of the unit systems.
+var UnitSystems = []string{UnitSystemDefault, UnitSystemMetric, UnitSystemImperial, UnitSystemCustom}
+
+// UnitPreferences choose the units parameters are logged in when a unit
+// isn't chosen for the parameter itself.
+type UnitPreferences struct {
+	// System is one of the UnitSystems. Empty is the same as UnitSystemDefault.
+	System string `yaml:"system,omitempty"`
+	// Custom are the units preferred for each dimension by UnitSystemCustom.
+	Custom units.System `yaml:"custom,omitempty"`
+}
+
+// Units returns the unit preferred for each dimension by the system.
+// It's nil for UnitSystemDefault.
+func (p UnitPreferences) Units() units.System {
+	switch p.System {
+	case UnitSystemMetric:
+		return units.Metric
+	case UnitSystemImperial:
+		return units.Imperial
+	case UnitSystemCustom:
+		return p.Custom
+	}
+	return nil
+}
+
+// Preferred returns the unit a parameter with the default unit is logged in.
+func (p UnitPreferences) Preferred(defaultUnit units.Unit) units.Unit {
+	return p.Units().Preferred(defaultUnit)
+}
+
+// Validate returns an error if the system is unknown or a custom unit isn't a unit of its dimension.
+func (p UnitPreferences) Validate() error {
+	switch p.System {
+	case "", UnitSystemDefault, UnitSystemMetric, UnitSystemImperial, UnitSystemCustom:
+	default:
+		return fmt.Errorf("unknown unit system '%s'", p.System)
+	}
+	return errors.Wrap(p.Custom.Validate(), "invalid custom unit")
+}
+
+// SetUnitPreferences sets the unit preferences and applies them to the parameters
+// in every profile. Parameters with a UnitOverride keep it, and the units chosen for
+// parameters while logging each vehicle still override the preferences for the vehicle.
+func (c *Config) SetUnitPreferences(p UnitPreferences) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	c.Units = p
+
+	for i := range c.Logging.Profiles {
+		params := c.Logging.Profiles[i].Parameters
+		for j := range params {
+			if params[j].UnitOverride != "" {
+				params[j].Unit = params[j].UnitOverride
+			} else if def, ok := defaultUnit(params[j]); ok {
+				params[j].Unit = p.Preferred(def)
+			}
+		}
+	}
+	return nil
+}
+
+// defaultUnit returns the default unit of the parameter, or false if it isn't a known parameter.
+func defaultUnit(p Parameter) (units.Unit, bool) {
+	params, derived := ssm2.Catalog()
+	if p.Derived {
+		d, ok := derived[p.ID]
+		return d.DefaultUnit, ok
+	}
+	param, ok := params[p.ID]
+	return param.DefaultUnit, ok
+}
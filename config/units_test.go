@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+func TestSetUnitPreferences(t *testing.T) {
+	c := &Config{
+		Logging: Logging{Profiles: []Profile{{Name: "Default", Parameters: []Parameter{
+			{ID: "P2", Unit: units.C},
+			{ID: "P8", Unit: units.RPM},
+			{ID: "P202", Derived: true, Unit: units.PSI},
+			{ID: "P9", Unit: units.MPH, UnitOverride: units.MPH},
+		}}}},
+		Vehicles: []Vehicle{{ROMID: "1040a132b1", Units: map[string]units.Unit{"P2": units.F}}},
+	}
+
+	if err := c.SetUnitPreferences(UnitPreferences{System: UnitSystemMetric}); err != nil {
+		t.Fatal(err)
+	}
+	// the overridden unit is kept
+	want := []units.Unit{units.C, units.RPM, units.KPA, units.MPH}
+	for i, p := range c.Logging.Profiles[0].Parameters {
+		if p.Unit != want[i] {
+			t.Errorf("want %s in %s. got: %s", p.ID, want[i], p.Unit)
+		}
+	}
+	if got := c.Vehicles[0].Units["P2"]; got != units.F {
+		t.Errorf("want the vehicle's unit kept. got: %s", got)
+	}
+	params := append([]Parameter{}, c.Logging.Profiles[0].Parameters...)
+	c.Vehicles[0].ApplyUnits(params)
+	if params[0].Unit != units.F {
+		t.Errorf("want the vehicle's unit to override the unit system. got: %s", params[0].Unit)
+	}
+
+	err := c.SetUnitPreferences(UnitPreferences{System: UnitSystemCustom, Custom: units.System{units.Temperature: units.F}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Logging.Profiles[0].Parameters[0].Unit; got != units.F {
+		t.Errorf("want P2 in F. got: %s", got)
+	}
+	if got := c.Units.Preferred(units.KPA); got != units.KPA {
+		t.Errorf("want kPa without a custom pressure unit. got: %s", got)
+	}
+
+	for _, p := range []UnitPreferences{
+		{System: "nautical"},
+		{System: UnitSystemCustom, Custom: units.System{units.Pressure: units.F}},
+	} {
+		if err = c.SetUnitPreferences(p); err == nil {
+			t.Errorf("expected an error for %+v", p)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package config
+
+import (
+	"encoding/hex"
+	"sort"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/romdb"
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+// DefaultDashboardColumns is the number of columns in the live log when a vehicle doesn't set one.
+const DefaultDashboardColumns = 3
+
+// Vehicle contains the settings restored when an ECU with the vehicle's ROM ID is connected.
+type Vehicle struct {
+	// ROMID is the hex-encoded ROM ID returned by the ECU.
+	ROMID string `yaml:"romId"`
+	// Nickname can be used in log file names instead of the ROM ID.
+	Nickname string `yaml:"nickname,omitempty"`
+	// Profile is the name of the profile last used with the vehicle.
+	Profile string `yaml:"profile,omitempty"`
+	// Units are the units chosen for parameters while logging the vehicle that differ
+	// from the unit preferences. They override the preferences and the profile's units.
+	Units map[string]units.Unit `yaml:"units,omitempty"`
+	// Dashboard is the layout of the live log.
+	Dashboard Dashboard `yaml:"dashboard,omitempty"`
+	// Alerts are the thresholds for parameter values, keyed by parameter ID.
+	Alerts map[string]Alert `yaml:"alerts,omitempty"`
+	// Specs are the facts about the vehicle used to calculate derived parameters.
+	Specs ssm2.Vehicle `yaml:"specs"`
+}
+
+// Dashboard describes the layout of the live log.
+type Dashboard struct {
+	Columns int `yaml:"columns,omitempty"`
+}
+
+// ColumnCount returns the number of columns, or DefaultDashboardColumns when it isn't set.
+func (d Dashboard) ColumnCount() int {
+	if d.Columns <= 0 {
+		return DefaultDashboardColumns
+	}
+	return d.Columns
+}
+
+// Alert contains the thresholds a parameter's value is expected to stay within.
+// The thresholds are in the unit the parameter is logged in.
+type Alert struct {
+	Min *float32 `yaml:"min,omitempty"`
+	Max *float32 `yaml:"max,omitempty"`
+}
+
+// Triggered returns true if v is outside of the alert's thresholds.
+func (a Alert) Triggered(v float32) bool {
+	return (a.Min != nil && v < *a.Min) || (a.Max != nil && v > *a.Max)
+}
+
+// Name returns the vehicle's nickname, or its ROM ID when it doesn't have one.
+func (v *Vehicle) Name() string {
+	if v.Nickname != "" {
+		return v.Nickname
+	}
+	return v.ROMID
+}
+
+// SetUnit records the unit chosen for a parameter, which overrides the unit
+// preferences while logging the vehicle. An empty unit removes it.
+func (v *Vehicle) SetUnit(id string, u units.Unit) {
+	if u == "" {
+		delete(v.Units, id)
+		return
+	}
+	if v.Units == nil {
+		v.Units = map[string]units.Unit{}
+	}
+	v.Units[id] = u
+}
+
+// SetAlert sets the alert for a parameter, removing it when it has no thresholds.
+func (v *Vehicle) SetAlert(id string, a Alert) {
+	if a.Min == nil && a.Max == nil {
+		delete(v.Alerts, id)
+		return
+	}
+	if v.Alerts == nil {
+		v.Alerts = map[string]Alert{}
+	}
+	v.Alerts[id] = a
+}
+
+// ApplyUnits sets the units of params to the units chosen for the vehicle.
+func (v *Vehicle) ApplyUnits(params []Parameter) {
+	for i, p := range params {
+		if u, ok := v.Units[p.ID]; ok {
+			params[i].Unit = u
+		}
+	}
+}
+
+// SetVehicleUnit records the unit chosen for a parameter while logging the vehicle.
+// A unit the unit preferences already log the parameter in is removed instead, so
+// changing the unit preferences later still changes the parameter's unit.
+func (c *Config) SetVehicleUnit(v *Vehicle, p Parameter, u units.Unit) {
+	if def, ok := defaultUnit(p); ok && c.Units.Preferred(def) == u {
+		u = ""
+	}
+	v.SetUnit(p.ID, u)
+}
+
+// FindVehicle returns the vehicle with the ROM ID, or nil.
+func (c *Config) FindVehicle(romID []byte) *Vehicle {
+	id := hex.EncodeToString(romID)
+	for i := range c.Vehicles {
+		if c.Vehicles[i].ROMID == id {
+			return &c.Vehicles[i]
+		}
+	}
+	return nil
+}
+
+// Vehicle returns the vehicle with the ROM ID, adding it if it hasn't been
+// seen before. The returned pointer is invalidated when another vehicle is added.
+func (c *Config) Vehicle(romID []byte) *Vehicle {
+	if v := c.FindVehicle(romID); v != nil {
+		return v
+	}
+
+	c.Vehicles = append(c.Vehicles, Vehicle{ROMID: hex.EncodeToString(romID), Specs: ssm2.DefaultVehicle()})
+	sort.Slice(c.Vehicles, func(i, j int) bool { return c.Vehicles[i].ROMID < c.Vehicles[j].ROMID })
+	return c.FindVehicle(romID)
+}
+
+// UseVehicleProfile selects the profile last used with the vehicle, if it still exists,
+// and returns the active profile's parameters with the vehicle's units applied.
+func (c *Config) UseVehicleProfile(v *Vehicle) []Parameter {
+	if v.Profile != "" && c.Logging.FindProfile(v.Profile) != nil {
+		c.Logging.Profile = v.Profile
+	}
+	v.Profile = c.Logging.ActiveProfile().Name
+
+	params := append([]Parameter{}, c.Logging.ActiveProfile().Parameters...)
+	v.ApplyUnits(params)
+	return params
+}
+
+// VehicleDatabase returns the built-in ROM ID database with the user-defined entries added.
+func (c *Config) VehicleDatabase() *romdb.Database {
+	return romdb.Builtin().With(c.ROMIDs)
+}
+
+// IdentifyVehicle looks up the vehicle with the ROM ID and SSM ID in the ROM ID
+// database. The SSM ID can be nil when it isn't known.
+func (c *Config) IdentifyVehicle(romID, ssmID []byte) (romdb.Entry, bool) {
+	return c.VehicleDatabase().Lookup(romID, ssmID)
+}
+
+// UseDefaultProfile sets the profile of a vehicle that hasn't been logged yet to the
+// identified vehicle's default profile, adding it from the ROM ID database's profiles
+// when there isn't a profile with its name. Vehicles that already have a profile are
+// left alone.
+func (c *Config) UseDefaultProfile(v *Vehicle, e romdb.Entry) {
+	if v.Profile != "" || e.Profile == "" {
+		return
+	}
+	if c.Logging.FindProfile(e.Profile) == nil {
+		p := c.VehicleDatabase().FindProfile(e.Profile)
+		if p == nil {
+			return
+		}
+
+		_, derived := ssm2.Catalog()
+		params := make([]Parameter, 0, len(p.Parameters))
+		for _, id := range p.Parameters {
+			param := Parameter{ID: id, LogToFile: true, LiveLog: true}
+			if _, ok := derived[id]; ok {
+				param.Derived = true
+			}
+			def, ok := defaultUnit(param)
+			if !ok {
+				continue
+			}
+			param.Unit = c.Units.Preferred(def)
+			params = append(params, param)
+		}
+		if err := c.Logging.AddProfile(Profile{Name: p.Name, Parameters: params}); err != nil {
+			return
+		}
+	}
+	v.Profile = e.Profile
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/gavinwade12/ecLogger/romdb"
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+func TestVehicle(t *testing.T) {
+	c := Default()
+	c.Logging.ActiveProfile().SetParameters([]Parameter{{ID: "P2", Unit: units.C}, {ID: "P8", Unit: units.RPM}})
+	if err := c.Logging.AddProfile(Profile{Name: "knock", Parameters: []Parameter{{ID: "P2", Unit: units.C}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	romID := []byte{0x10, 0x40, 0xA1, 0x32, 0xB1}
+	if c.FindVehicle(romID) != nil {
+		t.Fatal("the vehicle shouldn't exist before it's added")
+	}
+	v := c.Vehicle(romID)
+	if v.ROMID != "1040a132b1" || v.Name() != "1040a132b1" {
+		t.Fatalf("unexpected vehicle: %+v", v)
+	}
+
+	// a new vehicle uses the active profile
+	if params := c.UseVehicleProfile(v); len(params) != 2 || v.Profile != DefaultProfileName {
+		t.Fatalf("want the default profile's parameters. got: %+v", params)
+	}
+
+	v.Profile = "knock"
+	v.SetUnit("P2", units.F)
+	params := c.UseVehicleProfile(v)
+	if c.Logging.Profile != "knock" {
+		t.Fatalf("want the vehicle's profile to be active. got: %s", c.Logging.Profile)
+	}
+	if len(params) != 1 || params[0].Unit != units.F {
+		t.Fatalf("want the vehicle's units to be applied. got: %+v", params)
+	}
+	if c.Logging.ActiveProfile().Parameters[0].Unit != units.C {
+		t.Fatal("applying the vehicle's units shouldn't change the profile")
+	}
+
+	v.Nickname = "wrx"
+	if v.Name() != "wrx" {
+		t.Fatalf("want the nickname as the name. got: %s", v.Name())
+	}
+}
+
+func TestSetVehicleUnit(t *testing.T) {
+	c := Default()
+	c.Units.System = UnitSystemImperial
+	v := c.Vehicle([]byte{0x10, 0x40, 0xA1, 0x32, 0xB1})
+
+	c.SetVehicleUnit(v, Parameter{ID: "P2"}, units.C)
+	if got := v.Units["P2"]; got != units.C {
+		t.Fatalf("want the unit differing from the preferences kept. got: %s", got)
+	}
+	c.SetVehicleUnit(v, Parameter{ID: "P2"}, units.F)
+	if _, ok := v.Units["P2"]; ok {
+		t.Fatal("want the preferred unit removed")
+	}
+
+	// a later switch to metric isn't undone by the unit chosen while imperial
+	if err := c.SetUnitPreferences(UnitPreferences{System: UnitSystemMetric}); err != nil {
+		t.Fatal(err)
+	}
+	c.Logging.ActiveProfile().SetParameters([]Parameter{{ID: "P2", Unit: units.C}})
+	if params := c.UseVehicleProfile(v); params[0].Unit != units.C {
+		t.Fatalf("want P2 in C. got: %s", params[0].Unit)
+	}
+}
+
+func TestUseDefaultProfile(t *testing.T) {
+	c := Default()
+	c.Units.System = UnitSystemImperial
+	c.ROMIDs = []romdb.Entry{
+		{ROMID: "2f12785606", Model: "WRX", Profile: "turbo"},
+		{ROMID: "1111111111", Model: "Impreza", Profile: "knock"},
+	}
+	if err := c.Logging.AddProfile(Profile{Name: "knock"}); err != nil {
+		t.Fatal(err)
+	}
+
+	romID := []byte{0x2F, 0x12, 0x78, 0x56, 0x06}
+	e, ok := c.IdentifyVehicle(romID, []byte{0xA4, 0x10, 0x0C})
+	if !ok || e.Model != "WRX" {
+		t.Fatalf("want the vehicle identified. got: %+v", e)
+	}
+	v := c.Vehicle(romID)
+	c.UseDefaultProfile(v, e)
+	c.UseVehicleProfile(v)
+	if v.Profile != "turbo" || c.Logging.Profile != "turbo" {
+		t.Fatalf("want the turbo profile selected. got: %s", v.Profile)
+	}
+	p := c.Logging.FindProfile("turbo")
+	if p == nil || len(p.Parameters) != len(romdb.Builtin().FindProfile("turbo").Parameters) {
+		t.Fatalf("want the turbo profile added from the database. got: %+v", p)
+	}
+	if speed := p.Parameter("P9"); speed == nil || speed.Unit != units.MPH || !speed.LogToFile || !speed.LiveLog {
+		t.Fatalf("want the preferred unit used. got: %+v", speed)
+	}
+	if duty := p.Parameter("P201"); duty == nil || !duty.Derived {
+		t.Fatalf("want derived parameters flagged. got: %+v", duty)
+	}
+
+	// an existing profile with the name is used as is
+	v = c.Vehicle([]byte{0x11, 0x11, 0x11, 0x11, 0x11})
+	e, _ = c.IdentifyVehicle([]byte{0x11, 0x11, 0x11, 0x11, 0x11}, nil)
+	c.UseDefaultProfile(v, e)
+	if v.Profile != "knock" || len(c.Logging.FindProfile("knock").Parameters) != 0 {
+		t.Fatalf("want the existing knock profile. got: %s", v.Profile)
+	}
+
+	// vehicles that have been logged keep their profile
+	v = c.Vehicle(romID)
+	v.Profile = DefaultProfileName
+	c.UseDefaultProfile(v, romdb.Entry{Model: "WRX", Profile: "turbo"})
+	if v.Profile != DefaultProfileName {
+		t.Fatalf("want the vehicle's profile kept. got: %s", v.Profile)
+	}
+}
+
+func TestAlert(t *testing.T) {
+	min, max := float32(10), float32(20)
+	a := Alert{Min: &min, Max: &max}
+	for v, want := range map[float32]bool{5: true, 10: false, 15: false, 20: false, 25: true} {
+		if got := a.Triggered(v); got != want {
+			t.Fatalf("Triggered(%v) = %v", v, got)
+		}
+	}
+
+	v := &Vehicle{}
+	v.SetAlert("P8", a)
+	v.SetAlert("P8", Alert{})
+	if len(v.Alerts) != 0 {
+		t.Fatal("an alert without thresholds should be removed")
+	}
+}
@@ -0,0 +1,192 @@
+package formula
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+// Calibration is a user-defined derived parameter that calibrates the value of
+// another parameter, e.g. a fuel level sensor's resistance to the fuel remaining.
+// The value is calibrated with the Table when it has points. Otherwise, it's
+// scaled linearly: value * Scale + Offset.
+type Calibration struct {
+	// ID identifies the calibration's parameter, e.g. in profiles and formulas.
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	// Source is the ID of the calibrated parameter.
+	Source string `yaml:"source"`
+	// SourceUnit is the unit the source's value is converted to before it's
+	// calibrated. Empty is the source's default unit.
+	SourceUnit units.Unit `yaml:"sourceUnit,omitempty"`
+	Scale      float64    `yaml:"scale,omitempty"`
+	Offset     float64    `yaml:"offset,omitempty"`
+	// Table is sorted by increasing input. Values between two points are
+	// interpolated, and values outside of the table are clamped to its ends.
+	Table []Point `yaml:"table,omitempty"`
+	// Unit is the unit of the calibrated value.
+	Unit units.Unit `yaml:"unit,omitempty"`
+}
+
+// Point maps a source value to its calibrated value.
+type Point struct {
+	In  float64 `yaml:"in"`
+	Out float64 `yaml:"out"`
+}
+
+// Validate returns an error if the calibration is incomplete or its table isn't
+// sorted. References to other parameters are checked when it's compiled.
+func (c Calibration) Validate() error {
+	if !idRegexp.MatchString(c.ID) {
+		return fmt.Errorf("invalid calibration ID '%s': it must start with a letter and only contain letters, numbers, and underscores", c.ID)
+	}
+	if strings.TrimSpace(c.Name) == "" {
+		return fmt.Errorf("calibration %s: the name is required", c.ID)
+	}
+	if c.Source == "" {
+		return fmt.Errorf("calibration %s: the source parameter is required", c.ID)
+	}
+
+	if len(c.Table) == 0 {
+		if c.Scale == 0 || !isFinite(c.Scale) || !isFinite(c.Offset) {
+			return fmt.Errorf("calibration %s: a table or a non-zero scale is required", c.ID)
+		}
+		return nil
+	}
+	if len(c.Table) < 2 {
+		return fmt.Errorf("calibration %s: the table needs at least 2 points", c.ID)
+	}
+	for i, p := range c.Table {
+		if !isFinite(p.In) || !isFinite(p.Out) {
+			return fmt.Errorf("calibration %s: the table's values must be finite numbers", c.ID)
+		}
+		if i > 0 && p.In <= c.Table[i-1].In {
+			return fmt.Errorf("calibration %s: the table's inputs must increase", c.ID)
+		}
+	}
+	return nil
+}
+
+// Calibrate returns the calibrated value of the source's value v.
+func (c Calibration) Calibrate(v float64) float64 {
+	t := c.Table
+	if len(t) == 0 {
+		return v*c.Scale + c.Offset
+	}
+	if v <= t[0].In {
+		return t[0].Out
+	}
+	if v >= t[len(t)-1].In {
+		return t[len(t)-1].Out
+	}
+	// t[i-1].In < v <= t[i].In
+	i := sort.Search(len(t), func(i int) bool { return t[i].In >= v })
+	a, b := t[i-1], t[i]
+	return a.Out + (v-a.In)*(b.Out-a.Out)/(b.In-a.In)
+}
+
+// SortTable sorts the table by increasing input.
+func (c *Calibration) SortTable() {
+	sort.SliceStable(c.Table, func(i, j int) bool { return c.Table[i].In < c.Table[j].In })
+}
+
+// PreviewCalibration compiles the calibration along with the registered calibrations (minus
+// any with the same ID) and evaluates it against the values, e.g. from a logging session, for the
+// vehicle. To preview a calibration repeatedly, compile it once with CompileCalibrations and use Evaluate.
+func PreviewCalibration(c Calibration, registeredCalibrations []Calibration, values map[string]ssm2.ParameterValue, v ssm2.Vehicle) (*ssm2.ParameterValue, error) {
+	calibrations := []Calibration{c}
+	for _, rc := range registeredCalibrations {
+		if rc.ID != c.ID {
+			calibrations = append(calibrations, rc)
+		}
+	}
+
+	derived, err := CompileCalibrations(calibrations)
+	if err != nil {
+		return nil, err
+	}
+	return Evaluate(derived[0], values, v)
+}
+
+// CompileCalibrations validates the calibrations and compiles them into DerivedParameters.
+// The calibrations can calibrate the built-in parameters, the registered RAM parameters and
+// formulas, and each other, but the references can't form a cycle.
+func CompileCalibrations(calibrations []Calibration) ([]ssm2.DerivedParameter, error) {
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+
+	c, err := compile(Definitions{RAMParameters: active.RAMParameters, Formulas: active.Formulas, Calibrations: calibrations})
+	if err != nil {
+		return nil, err
+	}
+	return c.calibrations, nil
+}
+
+// IsCalibration returns true if the derived parameter with the ID was registered from a calibration.
+func IsCalibration(id string) bool {
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+	return calibrated[id]
+}
+
+// derivedParameter compiles the calibration. The caller must hold registeredMu.
+func (c Calibration) derivedParameter(unitOf func(id string) (units.Unit, bool)) (ssm2.DerivedParameter, error) {
+	sourceUnit, ok := unitOf(c.Source)
+	if !ok {
+		return ssm2.DerivedParameter{}, fmt.Errorf("unknown parameter '%s'", c.Source)
+	}
+	in := c.SourceUnit
+	if in == "" {
+		in = sourceUnit
+	} else if !units.CanConvert(sourceUnit, in) {
+		return ssm2.DerivedParameter{}, fmt.Errorf("can't convert %s from '%s' to '%s'", c.Source, sourceUnit, in)
+	}
+
+	description := c.Description
+	if description == "" {
+		description = fmt.Sprintf("%s-Calibrated %s", c.ID, c.Source)
+	}
+
+	// a table's values are clamped to its outputs
+	var rng ssm2.Range
+	if len(c.Table) > 0 {
+		lo, hi := c.Table[0].Out, c.Table[0].Out
+		for _, p := range c.Table[1:] {
+			lo, hi = math.Min(lo, p.Out), math.Max(hi, p.Out)
+		}
+		if lo < hi {
+			min, max := float32(lo), float32(hi)
+			rng = ssm2.Range{Min: &min, Max: &max}
+		}
+	}
+
+	return ssm2.DerivedParameter{
+		Id:                  c.ID,
+		Name:                c.Name,
+		Description:         description,
+		DefaultUnit:         c.Unit,
+		Category:            builtinCategory(c.Source),
+		Range:               rng,
+		DependsOnParameters: []string{c.Source},
+		Value: func(params map[string]ssm2.ParameterValue, vehicle ssm2.Vehicle) (*ssm2.ParameterValue, error) {
+			v, ok := params[c.Source]
+			if !ok {
+				return nil, fmt.Errorf("missing the value of %s", c.Source)
+			}
+			converted, err := v.ConvertWith(in, vehicle.Fuel.Converter())
+			if err != nil {
+				return nil, err
+			}
+			return &ssm2.ParameterValue{Value: float32(c.Calibrate(float64(converted.Value))), Unit: c.Unit}, nil
+		},
+	}, nil
+}
+
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
@@ -0,0 +1,136 @@
+package formula
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+// fuelLevel calibrates a fuel level sender's resistance to the gallons remaining.
+var fuelLevel = Calibration{
+	ID: "fuel_remaining", Name: "Fuel Remaining", Source: "P119", Unit: units.GallonsUS,
+	Table: []Point{{In: 5, Out: 16}, {In: 30, Out: 8}, {In: 90, Out: 0}},
+}
+
+func TestCalibration_Calibrate(t *testing.T) {
+	linear := Calibration{Scale: 37.5, Offset: -18.75}
+	tests := []struct {
+		name string
+		c    Calibration
+		in   float64
+		want float64
+	}{
+		{"linear", linear, 2.5, 75},
+		{"table point", fuelLevel, 30, 8},
+		{"table interpolation", fuelLevel, 60, 4},
+		{"table interpolation between the first points", fuelLevel, 17.5, 12},
+		{"below the table", fuelLevel, 0, 16},
+		{"above the table", fuelLevel, 200, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Calibrate(tt.in); math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("Calibrate(%v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileCalibrations(t *testing.T) {
+	tests := []struct {
+		name         string
+		calibrations []Calibration
+		wantErr      bool
+	}{
+		{"valid", []Calibration{
+			fuelLevel,
+			{ID: "oil_pressure", Name: "Oil Pressure", Source: "P17", Scale: 37.5, Offset: -18.75, Unit: units.PSI},
+			{ID: "fuel_remaining_l", Name: "Fuel Remaining (L)", Source: "fuel_remaining", SourceUnit: units.Liters, Scale: 1, Unit: units.Liters},
+		}, false},
+		{"invalid ID", []Calibration{{ID: "oil pressure", Name: "Oil Pressure", Source: "P17", Scale: 1}}, true},
+		{"built-in ID", []Calibration{{ID: "P17", Name: "Oil Pressure", Source: "P17", Scale: 1}}, true},
+		{"duplicate ID", []Calibration{
+			{ID: "a", Name: "A", Source: "P17", Scale: 1},
+			{ID: "a", Name: "A", Source: "P17", Scale: 2},
+		}, true},
+		{"missing name", []Calibration{{ID: "a", Source: "P17", Scale: 1}}, true},
+		{"missing source", []Calibration{{ID: "a", Name: "A", Scale: 1}}, true},
+		{"unknown source", []Calibration{{ID: "a", Name: "A", Source: "P99999", Scale: 1}}, true},
+		{"invalid source unit", []Calibration{{ID: "a", Name: "A", Source: "P17", SourceUnit: units.PSI, Scale: 1}}, true},
+		{"zero scale", []Calibration{{ID: "a", Name: "A", Source: "P17"}}, true},
+		{"single point", []Calibration{{ID: "a", Name: "A", Source: "P17", Table: []Point{{0, 0}}}}, true},
+		{"unsorted table", []Calibration{{ID: "a", Name: "A", Source: "P17", Table: []Point{{1, 0}, {0, 1}}}}, true},
+		{"cycle", []Calibration{
+			{ID: "a", Name: "A", Source: "b", Scale: 1},
+			{ID: "b", Name: "B", Source: "a", Scale: 1},
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CompileCalibrations(tt.calibrations)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CompileCalibrations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegisterAll(t *testing.T) {
+	defer RegisterAll(Definitions{})
+
+	err := RegisterAll(Definitions{
+		Formulas:     []Formula{{ID: "fuel_used", Name: "Fuel Used", Expression: "16 - fuel_remaining", Unit: units.GallonsUS}},
+		Calibrations: []Calibration{fuelLevel},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsCalibration("fuel_remaining") || IsCalibration("fuel_used") || IsFormula("fuel_remaining") {
+		t.Fatal("want the calibrations and formulas registered separately")
+	}
+
+	p, ok := ssm2.DerivedParameters["fuel_remaining"]
+	if !ok {
+		t.Fatal("want the calibration added to the derived parameters")
+	}
+	if p.Category != ssm2.Parameters["P119"].Category || !p.Range.Bounded() || *p.Range.Min != 0 || *p.Range.Max != 16 {
+		t.Fatalf("want the source's category and the table's range. got: %s, %+v", p.Category, p.Range)
+	}
+	v, err := p.Value(map[string]ssm2.ParameterValue{"P119": {Value: 60, Unit: units.Ohms}}, ssm2.DefaultVehicle())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Value != 4 || v.Unit != units.GallonsUS {
+		t.Fatalf("want 4 gal. got: %+v", v)
+	}
+
+	// a calibration used by a formula can't be removed
+	if err = RegisterAll(Definitions{Formulas: active.Formulas}); err == nil {
+		t.Fatal("expected an error")
+	}
+	// registering the formulas keeps the calibrations
+	if err = Register([]Formula{{ID: "a", Name: "A", Expression: "fuel_remaining * 2"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ssm2.DerivedParameters["fuel_used"]; ok || !IsCalibration("fuel_remaining") {
+		t.Fatal("want only the formulas replaced")
+	}
+}
+
+func TestPreviewCalibration(t *testing.T) {
+	values := map[string]ssm2.ParameterValue{"P17": {Value: 2.5, Unit: units.Volts}}
+
+	v, err := PreviewCalibration(Calibration{ID: "a", Name: "A", Source: "P17", Scale: 37.5, Offset: -18.75, Unit: units.PSI}, nil, values, ssm2.DefaultVehicle())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Value != 75 {
+		t.Fatalf("want 75. got: %v", v.Value)
+	}
+
+	if _, err = PreviewCalibration(fuelLevel, nil, values, ssm2.DefaultVehicle()); err == nil {
+		t.Fatal("expected an error for a parameter that isn't being logged")
+	}
+}
@@ -0,0 +1,552 @@
+package formula
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+// Reference is a reference to another parameter's value in an expression.
+type Reference struct {
+	ID string
+	// Unit is the unit the value is converted to before it's used. It's empty
+	// when the expression uses the value in whatever unit it's in.
+	Unit units.Unit
+}
+
+// Expression is a parsed formula expression. The syntax supports:
+//
+//   - numbers (e.g. 14.7 or 1e3)
+//   - references to parameters by ID, optionally with a unit to convert to (e.g. P8 or P9[mph])
+//   - arithmetic: + - * / and parentheses
+//   - comparisons, which evaluate to 1 or 0: < <= > >= == !=
+//   - logic, treating non-zero values as true: && || !
+//   - functions: min(a, b, ...), max(a, b, ...), abs(a), if(condition, then, else)
+type Expression struct {
+	src  string
+	root node
+	refs []Reference
+}
+
+// Parse parses an expression.
+func Parse(src string) (*Expression, error) {
+	p := &parser{src: src}
+	p.next()
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.tok.kind != tokenEOF {
+		return nil, p.unexpected()
+	}
+
+	e := &Expression{src: src, root: root}
+	seen := map[Reference]bool{}
+	walk(root, func(n node) {
+		if r, ok := n.(*refNode); ok && !seen[r.ref] {
+			seen[r.ref] = true
+			e.refs = append(e.refs, r.ref)
+		}
+	})
+	return e, nil
+}
+
+// String returns the expression's source.
+func (e *Expression) String() string {
+	return e.src
+}
+
+// References returns the references in the expression in the order they first appear.
+func (e *Expression) References() []Reference {
+	return append([]Reference{}, e.refs...)
+}
+
+// Dependencies returns the unique IDs of the referenced parameters in the order they first appear.
+func (e *Expression) Dependencies() []string {
+	seen := map[string]bool{}
+	var ids []string
+	for _, r := range e.refs {
+		if !seen[r.ID] {
+			seen[r.ID] = true
+			ids = append(ids, r.ID)
+		}
+	}
+	return ids
+}
+
+// Eval evaluates the expression using the referenced parameters' values. An error
+// is returned when a value is missing, can't be converted, or a division by zero occurs.
+func (e *Expression) Eval(values map[string]ssm2.ParameterValue) (float64, error) {
+	return e.EvalWith(values, units.DefaultConverter)
+}
+
+// EvalWith evaluates the expression like Eval, converting the referenced values with
+// the converter, e.g. to convert between AFR and Lambda for the vehicle's fuel.
+func (e *Expression) EvalWith(values map[string]ssm2.ParameterValue, c units.Converter) (float64, error) {
+	return e.root.eval(&evalContext{values: values, converter: c})
+}
+
+// evalRaw evaluates the expression of a RAM parameter with its raw value. The raw value is
+// kept as a float64 since a float32 can't hold every 4-byte value.
+func (e *Expression) evalRaw(x float64) (float64, error) {
+	return e.root.eval(&evalContext{raw: &x})
+}
+
+// evalContext is what the nodes are evaluated with.
+type evalContext struct {
+	values    map[string]ssm2.ParameterValue
+	converter units.Converter
+	// raw is the raw value of a RAM parameter referenced by RawValueID.
+	raw *float64
+}
+
+// bind sets the unit of the references that don't have one.
+func (e *Expression) bind(unitOf func(id string) units.Unit) {
+	walk(e.root, func(n node) {
+		if r, ok := n.(*refNode); ok && r.ref.Unit == "" {
+			r.ref.Unit = unitOf(r.ref.ID)
+		}
+	})
+}
+
+type node interface {
+	eval(ctx *evalContext) (float64, error)
+}
+
+type numberNode float64
+
+func (n numberNode) eval(*evalContext) (float64, error) {
+	return float64(n), nil
+}
+
+type refNode struct {
+	ref Reference
+}
+
+func (n *refNode) eval(ctx *evalContext) (float64, error) {
+	if ctx.raw != nil && n.ref.ID == RawValueID {
+		return *ctx.raw, nil
+	}
+	v, ok := ctx.values[n.ref.ID]
+	if !ok {
+		return 0, fmt.Errorf("no value for %s", n.ref.ID)
+	}
+	if n.ref.Unit == "" {
+		return float64(v.Value), nil
+	}
+
+	converted, err := v.ConvertWith(n.ref.Unit, ctx.converter)
+	if err != nil {
+		return 0, fmt.Errorf("converting %s from %s to %s", n.ref.ID, v.Unit, n.ref.Unit)
+	}
+	return float64(converted.Value), nil
+}
+
+type unaryNode struct {
+	op      string
+	operand node
+}
+
+func (n *unaryNode) eval(ctx *evalContext) (float64, error) {
+	v, err := n.operand.eval(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if n.op == "!" {
+		return boolValue(v == 0), nil
+	}
+	return -v, nil
+}
+
+type binaryNode struct {
+	op          string
+	left, right node
+}
+
+func (n *binaryNode) eval(ctx *evalContext) (float64, error) {
+	l, err := n.left.eval(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// short-circuit the logical operators
+	switch {
+	case n.op == "&&" && l == 0:
+		return 0, nil
+	case n.op == "||" && l != 0:
+		return 1, nil
+	}
+
+	r, err := n.right.eval(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	switch n.op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return l / r, nil
+	case "<":
+		return boolValue(l < r), nil
+	case "<=":
+		return boolValue(l <= r), nil
+	case ">":
+		return boolValue(l > r), nil
+	case ">=":
+		return boolValue(l >= r), nil
+	case "==":
+		return boolValue(l == r), nil
+	case "!=":
+		return boolValue(l != r), nil
+	}
+	// && and || when the left side didn't decide the result
+	return boolValue(r != 0), nil
+}
+
+type callNode struct {
+	name string
+	args []node
+}
+
+// functions are the functions that can be called in an expression,
+// with the minimum and maximum (-1 for any) number of arguments.
+var functions = map[string]struct{ min, max int }{
+	"min": {1, -1},
+	"max": {1, -1},
+	"abs": {1, 1},
+	"if":  {3, 3},
+}
+
+func (n *callNode) eval(ctx *evalContext) (float64, error) {
+	if n.name == "if" {
+		// only evaluate the chosen branch
+		cond, err := n.args[0].eval(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if cond != 0 {
+			return n.args[1].eval(ctx)
+		}
+		return n.args[2].eval(ctx)
+	}
+
+	args := make([]float64, len(n.args))
+	for i, a := range n.args {
+		v, err := a.eval(ctx)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = v
+	}
+
+	switch n.name {
+	case "min":
+		m := args[0]
+		for _, a := range args[1:] {
+			m = math.Min(m, a)
+		}
+		return m, nil
+	case "max":
+		m := args[0]
+		for _, a := range args[1:] {
+			m = math.Max(m, a)
+		}
+		return m, nil
+	}
+	return math.Abs(args[0]), nil
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// walk calls fn for n and all of its descendants.
+func walk(n node, fn func(node)) {
+	fn(n)
+	switch n := n.(type) {
+	case *unaryNode:
+		walk(n.operand, fn)
+	case *binaryNode:
+		walk(n.left, fn)
+		walk(n.right, fn)
+	case *callNode:
+		for _, a := range n.args {
+			walk(a, fn)
+		}
+	}
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenUnit
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+type parser struct {
+	src string
+	pos int
+	tok token
+	err error
+}
+
+// twoCharOperators are the operators made of two characters.
+var twoCharOperators = []string{"<=", ">=", "==", "!=", "&&", "||"}
+
+// next advances to the next token. Lexing errors are recorded in p.err
+// and reported by the parse functions.
+func (p *parser) next() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos >= len(p.src) {
+		p.tok = token{tokenEOF, "", start}
+		return
+	}
+
+	c := p.src[p.pos]
+	switch {
+	case isDigit(c) || c == '.':
+		for p.pos < len(p.src) && (isDigit(p.src[p.pos]) || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		// exponent, e.g. 1e-3
+		if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+			end := p.pos + 1
+			if end < len(p.src) && (p.src[end] == '+' || p.src[end] == '-') {
+				end++
+			}
+			if end < len(p.src) && isDigit(p.src[end]) {
+				for end < len(p.src) && isDigit(p.src[end]) {
+					end++
+				}
+				p.pos = end
+			}
+		}
+		p.tok = token{tokenNumber, p.src[start:p.pos], start}
+	case isIdentStart(c):
+		for p.pos < len(p.src) && (isIdentStart(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok = token{tokenIdent, p.src[start:p.pos], start}
+	case c == '[':
+		end := strings.IndexByte(p.src[start:], ']')
+		if end < 0 {
+			p.fail(fmt.Errorf("unterminated unit at position %d", start+1))
+			return
+		}
+		p.pos = start + end + 1
+		p.tok = token{tokenUnit, strings.TrimSpace(p.src[start+1 : start+end]), start}
+	default:
+		for _, op := range twoCharOperators {
+			if strings.HasPrefix(p.src[start:], op) {
+				p.pos += 2
+				p.tok = token{tokenOperator, op, start}
+				return
+			}
+		}
+		if !strings.ContainsRune("+-*/()<>!,", rune(c)) {
+			p.fail(fmt.Errorf("unexpected character '%c' at position %d", c, start+1))
+			return
+		}
+		p.pos++
+		p.tok = token{tokenOperator, string(c), start}
+	}
+}
+
+func (p *parser) fail(err error) {
+	if p.err == nil {
+		p.err = err
+	}
+	p.pos = len(p.src)
+	p.tok = token{tokenEOF, "", p.pos}
+}
+
+func (p *parser) unexpected() error {
+	if p.err != nil {
+		return p.err
+	}
+	if p.tok.kind == tokenEOF {
+		return fmt.Errorf("unexpected end of expression")
+	}
+	return fmt.Errorf("unexpected '%s' at position %d", p.tok.text, p.tok.pos+1)
+}
+
+// accept advances past the current token and returns true if it's the operator op.
+func (p *parser) accept(op string) bool {
+	if p.tok.kind == tokenOperator && p.tok.text == op {
+		p.next()
+		return true
+	}
+	return false
+}
+
+// parseBinary parses a left-associative chain of the operators, with operands parsed by operand.
+func (p *parser) parseBinary(operand func() (node, error), ops ...string) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		matched := ""
+		for _, op := range ops {
+			if p.tok.kind == tokenOperator && p.tok.text == op {
+				matched = op
+				break
+			}
+		}
+		if matched == "" {
+			return left, nil
+		}
+		p.next()
+
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{matched, left, right}
+	}
+}
+
+func (p *parser) parseOr() (node, error) {
+	return p.parseBinary(p.parseAnd, "||")
+}
+
+func (p *parser) parseAnd() (node, error) {
+	return p.parseBinary(p.parseComparison, "&&")
+}
+
+func (p *parser) parseComparison() (node, error) {
+	return p.parseBinary(p.parseAdditive, "<", "<=", ">", ">=", "==", "!=")
+}
+
+func (p *parser) parseAdditive() (node, error) {
+	return p.parseBinary(p.parseMultiplicative, "+", "-")
+}
+
+func (p *parser) parseMultiplicative() (node, error) {
+	return p.parseBinary(p.parseUnary, "*", "/")
+}
+
+func (p *parser) parseUnary() (node, error) {
+	for _, op := range []string{"-", "!", "+"} {
+		if p.accept(op) {
+			operand, err := p.parseUnary()
+			if err != nil {
+				return nil, err
+			}
+			if op == "+" {
+				return operand, nil
+			}
+			return &unaryNode{op, operand}, nil
+		}
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokenNumber:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s' at position %d", tok.text, tok.pos+1)
+		}
+		p.next()
+		return numberNode(f), nil
+	case tokenIdent:
+		p.next()
+		if p.accept("(") {
+			return p.parseCall(tok)
+		}
+
+		ref := &refNode{Reference{ID: tok.text}}
+		if p.tok.kind == tokenUnit {
+			if p.tok.text == "" {
+				return nil, fmt.Errorf("empty unit at position %d", p.tok.pos+1)
+			}
+			ref.ref.Unit = units.Unit(p.tok.text)
+			p.next()
+		}
+		return ref, nil
+	case tokenOperator:
+		if p.accept("(") {
+			n, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if !p.accept(")") {
+				return nil, p.unexpected()
+			}
+			return n, nil
+		}
+	}
+	return nil, p.unexpected()
+}
+
+// parseCall parses a function call's arguments after the opening parenthesis.
+func (p *parser) parseCall(name token) (node, error) {
+	fn, ok := functions[name.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown function '%s' at position %d", name.text, name.pos+1)
+	}
+
+	call := &callNode{name: name.text}
+	if !p.accept(")") {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+			if p.accept(")") {
+				break
+			}
+			if !p.accept(",") {
+				return nil, p.unexpected()
+			}
+		}
+	}
+
+	if len(call.args) < fn.min || (fn.max >= 0 && len(call.args) > fn.max) {
+		return nil, fmt.Errorf("wrong number of arguments to %s at position %d", name.text, name.pos+1)
+	}
+	return call, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,112 @@
+package formula
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+func TestExpression_Eval(t *testing.T) {
+	values := map[string]ssm2.ParameterValue{
+		"P7":  {Value: 150, Unit: units.KPA},
+		"P8":  {Value: 3000, Unit: units.RPM},
+		"P9":  {Value: 100, Unit: units.KMH},
+		"P25": {Value: 130, Unit: units.KPA},
+	}
+
+	tests := []struct {
+		expr    string
+		want    float64
+		wantErr bool
+	}{
+		{"1 + 2 * 3", 7, false},
+		{"(1 + 2) * 3", 9, false},
+		{"10 - 4 - 3", 3, false},
+		{"8 / 4 / 2", 1, false},
+		{"-P8 + 1e3", -2000, false},
+		{"P7 - P25", 20, false},
+		{"P9[mph]", 62.137, false},
+		{"min(P7, P25, 200)", 130, false},
+		{"max(P7, P25)", 150, false},
+		{"abs(P25 - P7)", 20, false},
+		{"if(P8 > 2500 && P7 >= 150, 1, 2)", 1, false},
+		{"if(P8 < 2500 || !(P7 == 150), 1, 2)", 2, false},
+		{"P8 != 3000", 0, false},
+		{"if(P9 > 0, P8 / P9, 1 / 0)", 30, false},
+		{"P8 / (P7 - 150)", 0, true},
+		{"P12 * 2", 0, true},
+		{"P8[kmh]", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			e, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := e.Eval(values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Eval() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if math.Abs(got-tt.want) > 0.001 {
+				t.Errorf("Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpression_EvalWith(t *testing.T) {
+	e, err := Parse("P58[AFR]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := map[string]ssm2.ParameterValue{"P58": {Value: 1, Unit: units.Lambda}}
+	got, err := e.EvalWith(values, ssm2.Fuel{Type: ssm2.FuelDiesel}.Converter())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(got-float64(ssm2.StoichDiesel)) > 0.001 {
+		t.Errorf("want %v AFR for diesel. got: %v", ssm2.StoichDiesel, got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		expr    string
+		deps    []string
+		wantErr bool
+	}{
+		{"P8 * P21 / 1200", []string{"P8", "P21"}, false},
+		{"P9[mph] / P9[kmh] + P9", []string{"P9"}, false},
+		{"boost_error * 2", []string{"boost_error"}, false},
+		{"", nil, true},
+		{"1 +", nil, true},
+		{"(1 + 2", nil, true},
+		{"1 + 2)", nil, true},
+		{"P8 $ 2", nil, true},
+		{"P8 $", nil, true},
+		{"P9[mph", nil, true},
+		{"P9[]", nil, true},
+		{"sqrt(4)", nil, true},
+		{"abs(1, 2)", nil, true},
+		{"if(1, 2)", nil, true},
+		{"min()", nil, true},
+		{"1 2", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			e, err := Parse(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := e.Dependencies(); !reflect.DeepEqual(got, tt.deps) {
+				t.Errorf("Dependencies() = %v, want %v", got, tt.deps)
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+// Package formula compiles user-defined formulas into derived parameters.
+package formula
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+	"github.com/pkg/errors"
+)
+
+// Formula is a user-defined derived parameter calculated from an expression.
+type Formula struct {
+	// ID identifies the formula's parameter, e.g. in profiles and other expressions.
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	// Expression calculates the value. See Expression for the syntax.
+	Expression string `yaml:"expression"`
+	// Unit is the unit of the calculated value.
+	Unit units.Unit `yaml:"unit,omitempty"`
+}
+
+var idRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Definitions are the user-defined parameters. They're compiled and registered
+// together since they can reference each other.
+type Definitions struct {
+	RAMParameters []RAMParameter
+	Formulas      []Formula
+	Calibrations  []Calibration
+}
+
+// compiled contains the parameters compiled from Definitions.
+type compiled struct {
+	params       []ssm2.Parameter
+	formulas     []ssm2.DerivedParameter
+	calibrations []ssm2.DerivedParameter
+}
+
+var (
+	// registered contains the IDs of the formulas added to ssm2.DerivedParameters.
+	registered = map[string]bool{}
+	// calibrated contains the IDs of the calibrations added to ssm2.DerivedParameters.
+	calibrated = map[string]bool{}
+	// watched contains the IDs of the RAM parameters added to ssm2.Parameters.
+	watched = map[string]bool{}
+	// active are the registered definitions. The others are compiled with
+	// the new definitions when only some of them are replaced.
+	active       Definitions
+	registeredMu sync.Mutex
+)
+
+// IsFormula returns true if the derived parameter with the ID was registered from a formula.
+func IsFormula(id string) bool {
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+	return registered[id]
+}
+
+// Compile validates the formulas and compiles them into DerivedParameters. The formulas
+// can reference the built-in parameters, the registered RAM parameters and calibrations,
+// and each other, but the references can't form a cycle.
+func Compile(formulas []Formula) ([]ssm2.DerivedParameter, error) {
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+
+	c, err := compile(Definitions{RAMParameters: active.RAMParameters, Formulas: formulas, Calibrations: active.Calibrations})
+	if err != nil {
+		return nil, err
+	}
+	return c.formulas, nil
+}
+
+// compile compiles the definitions together. The caller must hold registeredMu.
+func compile(d Definitions) (compiled, error) {
+	var c compiled
+	params := make(map[string]ssm2.Parameter, len(d.RAMParameters))
+	for _, rp := range d.RAMParameters {
+		p, err := rp.compile()
+		if err != nil {
+			return c, err
+		}
+		if _, ok := params[p.Id]; ok {
+			return c, fmt.Errorf("duplicate RAM parameter ID '%s'", p.Id)
+		}
+		if _, ok := builtinUnit(p.Id); ok {
+			return c, fmt.Errorf("RAM parameter ID '%s' is already used by a built-in parameter", p.Id)
+		}
+		params[p.Id] = p
+		c.params = append(c.params, p)
+	}
+
+	byID := make(map[string]Formula, len(d.Formulas))
+	for _, f := range d.Formulas {
+		if !idRegexp.MatchString(f.ID) {
+			return c, fmt.Errorf("invalid formula ID '%s': it must start with a letter and only contain letters, numbers, and underscores", f.ID)
+		}
+		if _, ok := byID[f.ID]; ok {
+			return c, fmt.Errorf("duplicate formula ID '%s'", f.ID)
+		}
+		if _, ok := params[f.ID]; ok {
+			return c, fmt.Errorf("formula ID '%s' is already used by a RAM parameter", f.ID)
+		}
+		if _, ok := builtinUnit(f.ID); ok {
+			return c, fmt.Errorf("formula ID '%s' is already used by a built-in parameter", f.ID)
+		}
+		if strings.TrimSpace(f.Name) == "" {
+			return c, fmt.Errorf("formula %s: the name is required", f.ID)
+		}
+		byID[f.ID] = f
+	}
+	calibrationsByID := make(map[string]Calibration, len(d.Calibrations))
+	for _, cal := range d.Calibrations {
+		if err := cal.Validate(); err != nil {
+			return c, err
+		}
+		if _, ok := params[cal.ID]; ok {
+			return c, fmt.Errorf("calibration ID '%s' is already used by a RAM parameter", cal.ID)
+		}
+		if _, ok := byID[cal.ID]; ok {
+			return c, fmt.Errorf("calibration ID '%s' is already used by a formula", cal.ID)
+		}
+		if _, ok := calibrationsByID[cal.ID]; ok {
+			return c, fmt.Errorf("duplicate calibration ID '%s'", cal.ID)
+		}
+		if _, ok := builtinUnit(cal.ID); ok {
+			return c, fmt.Errorf("calibration ID '%s' is already used by a built-in parameter", cal.ID)
+		}
+		calibrationsByID[cal.ID] = cal
+	}
+
+	unitOf := func(id string) (units.Unit, bool) {
+		if p, ok := params[id]; ok {
+			return p.DefaultUnit, true
+		}
+		if f, ok := byID[id]; ok {
+			return f.Unit, true
+		}
+		if cal, ok := calibrationsByID[id]; ok {
+			return cal.Unit, true
+		}
+		return builtinUnit(id)
+	}
+
+	for _, f := range d.Formulas {
+		expr, err := Parse(f.Expression)
+		if err != nil {
+			return c, errors.Wrapf(err, "formula %s", f.ID)
+		}
+		if err = checkReferences(expr, unitOf); err != nil {
+			return c, errors.Wrapf(err, "formula %s", f.ID)
+		}
+		expr.bind(func(id string) units.Unit {
+			u, _ := unitOf(id)
+			return u
+		})
+		c.formulas = append(c.formulas, derivedParameter(f, expr))
+	}
+	for _, cal := range d.Calibrations {
+		p, err := cal.derivedParameter(unitOf)
+		if err != nil {
+			return c, errors.Wrapf(err, "calibration %s", cal.ID)
+		}
+		c.calibrations = append(c.calibrations, p)
+	}
+
+	// the RAM parameters aren't in ssm2.Parameters yet, so they're resolved as derived
+	// parameters without dependencies while checking the others for cycles
+	derived := append(append([]ssm2.DerivedParameter{}, c.formulas...), c.calibrations...)
+	for _, p := range c.params {
+		derived = append(derived, ssm2.DerivedParameter{Id: p.Id})
+	}
+	if _, _, err := ssm2.ResolveDerivedParameters(derived); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// Register compiles the formulas and adds them to ssm2.DerivedParameters, replacing
+// the previously registered formulas. Nothing is changed when a formula is invalid.
+func Register(formulas []Formula) error {
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+	return register(Definitions{RAMParameters: active.RAMParameters, Formulas: formulas, Calibrations: active.Calibrations})
+}
+
+// RegisterAll compiles the definitions and adds them to ssm2.Parameters and ssm2.DerivedParameters,
+// replacing the previously registered ones. Nothing is changed when one is invalid.
+func RegisterAll(d Definitions) error {
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+	return register(d)
+}
+
+func register(d Definitions) error {
+	c, err := compile(d)
+	if err != nil {
+		return err
+	}
+
+	// the catalog is copied instead of modified since it's read without registeredMu,
+	// e.g. by logging sessions while formulas are saved
+	current, currentDerived := ssm2.Catalog()
+	params := make(map[string]ssm2.Parameter, len(current)+len(c.params))
+	for id, p := range current {
+		if !watched[id] {
+			params[id] = p
+		}
+	}
+	derived := make(map[string]ssm2.DerivedParameter, len(currentDerived)+len(c.formulas)+len(c.calibrations))
+	for id, p := range currentDerived {
+		if !registered[id] && !calibrated[id] {
+			derived[id] = p
+		}
+	}
+
+	watched = make(map[string]bool, len(c.params))
+	registered = make(map[string]bool, len(c.formulas))
+	calibrated = make(map[string]bool, len(c.calibrations))
+	for _, p := range c.params {
+		params[p.Id] = p
+		watched[p.Id] = true
+	}
+	for _, p := range c.formulas {
+		derived[p.Id] = p
+		registered[p.Id] = true
+	}
+	for _, p := range c.calibrations {
+		derived[p.Id] = p
+		calibrated[p.Id] = true
+	}
+	ssm2.SetCatalog(params, derived)
+	active = d
+	return nil
+}
+
+// Preview compiles the formula along with the registered formulas (minus any with
+// the same ID) and evaluates it against the values, e.g. from a logging session, for
+// the vehicle. To preview a formula repeatedly, compile it once with Compile and use Evaluate.
+func Preview(f Formula, registeredFormulas []Formula, values map[string]ssm2.ParameterValue, v ssm2.Vehicle) (*ssm2.ParameterValue, error) {
+	formulas := []Formula{f}
+	for _, rf := range registeredFormulas {
+		if rf.ID != f.ID {
+			formulas = append(formulas, rf)
+		}
+	}
+
+	derived, err := Compile(formulas)
+	if err != nil {
+		return nil, err
+	}
+	return Evaluate(derived[0], values, v)
+}
+
+// Evaluate calculates the compiled formula's or calibration's value for the vehicle
+// when its dependencies are in values.
+func Evaluate(p ssm2.DerivedParameter, values map[string]ssm2.ParameterValue, v ssm2.Vehicle) (*ssm2.ParameterValue, error) {
+	for _, id := range p.DependsOnParameters {
+		if _, ok := values[id]; !ok {
+			return nil, fmt.Errorf("%s isn't being logged", id)
+		}
+	}
+	return p.Value(values, v)
+}
+
+// builtinUnit returns the default unit of the built-in parameter with the ID.
+// The caller must hold registeredMu.
+func builtinUnit(id string) (units.Unit, bool) {
+	params, derived := ssm2.Catalog()
+	if p, ok := params[id]; ok && !watched[id] {
+		return p.DefaultUnit, true
+	}
+	if p, ok := derived[id]; ok && !registered[id] && !calibrated[id] {
+		return p.DefaultUnit, true
+	}
+	return "", false
+}
+
+// builtinCategory returns the category of the built-in parameter with the ID, or
+// CategoryOther if it isn't one. The caller must hold registeredMu.
+func builtinCategory(id string) ssm2.Category {
+	params, derived := ssm2.Catalog()
+	if p, ok := params[id]; ok && !watched[id] {
+		return p.Category
+	}
+	if p, ok := derived[id]; ok && !registered[id] && !calibrated[id] {
+		return p.Category
+	}
+	return ssm2.CategoryOther
+}
+
+// checkReferences returns an error if a referenced parameter doesn't exist
+// or can't be converted to the unit given for the reference.
+func checkReferences(expr *Expression, unitOf func(id string) (units.Unit, bool)) error {
+	for _, r := range expr.References() {
+		u, ok := unitOf(r.ID)
+		if !ok {
+			return fmt.Errorf("unknown parameter '%s'", r.ID)
+		}
+		if r.Unit == "" || r.Unit == u {
+			continue
+		}
+		if !units.CanConvert(u, r.Unit) {
+			return fmt.Errorf("can't convert %s from '%s' to '%s'", r.ID, u, r.Unit)
+		}
+	}
+	return nil
+}
+
+func derivedParameter(f Formula, expr *Expression) ssm2.DerivedParameter {
+	description := f.Description
+	if description == "" {
+		description = fmt.Sprintf("%s-%s", f.ID, expr)
+	}
+
+	return ssm2.DerivedParameter{
+		Id:                  f.ID,
+		Name:                f.Name,
+		Description:         description,
+		DefaultUnit:         f.Unit,
+		Category:            ssm2.CategoryOther,
+		DependsOnParameters: expr.Dependencies(),
+		Value: func(params map[string]ssm2.ParameterValue, vehicle ssm2.Vehicle) (*ssm2.ParameterValue, error) {
+			v, err := expr.EvalWith(params, vehicle.Fuel.Converter())
+			if err != nil {
+				return nil, err
+			}
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return nil, fmt.Errorf("the result isn't a finite number")
+			}
+			return &ssm2.ParameterValue{Value: float32(v), Unit: f.Unit}, nil
+		},
+	}
+}
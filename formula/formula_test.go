@@ -0,0 +1,130 @@
+package formula
+
+import (
+	"testing"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name     string
+		formulas []Formula
+		wantErr  bool
+	}{
+		{"valid", []Formula{
+			{ID: "boost_error", Name: "Boost Error", Expression: "P25[psi] - P7[psi]", Unit: units.PSI},
+			{ID: "load_per_psi", Name: "Load per PSI", Expression: "P200 / max(P7[psi], 1)", Unit: units.GramsPerRev},
+			{ID: "boost_error_kpa", Name: "Boost Error (kPa)", Expression: "boost_error[kPa]", Unit: units.KPA},
+		}, false},
+		{"invalid ID", []Formula{{ID: "boost error", Name: "Boost Error", Expression: "1"}}, true},
+		{"built-in ID", []Formula{{ID: "P8", Name: "Engine Speed", Expression: "1"}}, true},
+		{"duplicate ID", []Formula{
+			{ID: "a", Name: "A", Expression: "1"},
+			{ID: "a", Name: "A", Expression: "2"},
+		}, true},
+		{"missing name", []Formula{{ID: "a", Expression: "1"}}, true},
+		{"syntax error", []Formula{{ID: "a", Name: "A", Expression: "P8 +"}}, true},
+		{"unknown reference", []Formula{{ID: "a", Name: "A", Expression: "P99999"}}, true},
+		{"invalid unit conversion", []Formula{{ID: "a", Name: "A", Expression: "P8[psi]"}}, true},
+		{"cycle", []Formula{
+			{ID: "a", Name: "A", Expression: "b + 1"},
+			{ID: "b", Name: "B", Expression: "a + 1"},
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.formulas)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Compile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	defer Register(nil)
+
+	err := Register([]Formula{
+		{ID: "boost_error", Name: "Boost Error", Expression: "P25 - P7", Unit: units.KPA},
+		{ID: "boost_error_psi", Name: "Boost Error (psi)", Expression: "boost_error[psi]", Unit: units.PSI},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsFormula("boost_error") || IsFormula("P200") {
+		t.Fatal("want only the formulas to be registered as formulas")
+	}
+
+	p, ok := ssm2.DerivedParameters["boost_error_psi"]
+	if !ok {
+		t.Fatal("want the formula added to the derived parameters")
+	}
+	derived, _, err := ssm2.ResolveDerivedParameters([]ssm2.DerivedParameter{p})
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := map[string]ssm2.ParameterValue{
+		"P7":  {Value: 100, Unit: units.KPA},
+		"P25": {Value: 168.9476, Unit: units.KPA},
+	}
+	for _, d := range derived {
+		v, err := d.Value(values, ssm2.DefaultVehicle())
+		if err != nil {
+			t.Fatal(err)
+		}
+		values[d.Id] = *v
+	}
+	if got := values["boost_error_psi"]; got.Unit != units.PSI || got.Value < 9.99 || got.Value > 10.01 {
+		t.Fatalf("want 10 psi. got: %+v", got)
+	}
+
+	// an invalid formula doesn't change the registered formulas
+	if err = Register([]Formula{{ID: "a", Name: "A", Expression: "P8 +"}}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if _, ok := ssm2.DerivedParameters["boost_error"]; !ok {
+		t.Fatal("want the formulas kept after an invalid registration")
+	}
+
+	// registering replaces the previous formulas in a new catalog, leaving the
+	// current one unchanged for anything still reading it
+	_, before := ssm2.Catalog()
+	if err = Register([]Formula{{ID: "a", Name: "A", Expression: "P8 * 2"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ssm2.DerivedParameters["boost_error"]; ok {
+		t.Fatal("want the previous formulas removed")
+	}
+	if _, ok := before["boost_error"]; !ok {
+		t.Fatal("want the previous catalog unchanged")
+	}
+}
+
+func TestPreview(t *testing.T) {
+	values := map[string]ssm2.ParameterValue{"P8": {Value: 3000, Unit: units.RPM}}
+
+	v, err := Preview(Formula{ID: "a", Name: "A", Expression: "P8 / 1000", Unit: units.Unit("krpm")}, nil, values, ssm2.DefaultVehicle())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Value != 3 {
+		t.Fatalf("want 3. got: %v", v.Value)
+	}
+
+	if _, err = Preview(Formula{ID: "a", Name: "A", Expression: "P7 * 2"}, nil, values, ssm2.DefaultVehicle()); err == nil {
+		t.Fatal("expected an error for a parameter that isn't being logged")
+	}
+
+	// references are converted for the vehicle's fuel
+	values["P58"] = ssm2.ParameterValue{Value: 1, Unit: units.Lambda}
+	diesel := ssm2.DefaultVehicle()
+	diesel.Fuel = ssm2.Fuel{Type: ssm2.FuelDiesel}
+	if v, err = Preview(Formula{ID: "a", Name: "A", Expression: "P58[AFR]", Unit: units.AFR}, nil, values, diesel); err != nil {
+		t.Fatal(err)
+	}
+	if v.Value != ssm2.StoichDiesel {
+		t.Fatalf("want %v AFR for diesel. got: %v", ssm2.StoichDiesel, v.Value)
+	}
+}
@@ -0,0 +1,163 @@
+package formula
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strings"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+	"github.com/pkg/errors"
+)
+
+// RawValueID is the ID a RAMParameter's expression uses to reference the raw value.
+const RawValueID = "x"
+
+// maxRAMParameterLength is the most bytes a RAMParameter's value can span.
+const maxRAMParameterLength = 4
+
+// RAMParameter is a user-defined parameter read from an address in the ECU's RAM,
+// e.g. a value found while reverse-engineering a ROM. The bytes are combined into
+// the raw value, masked, and then converted by the expression.
+type RAMParameter struct {
+	// ID identifies the parameter, e.g. in profiles and formulas.
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	// Address is the hex address of the value's first byte, e.g. FF6B1C.
+	Address string `yaml:"address"`
+	// Length is the number of bytes in the value, from 1 to 4.
+	Length int `yaml:"length"`
+	// LittleEndian is set when the value's least significant byte comes first.
+	LittleEndian bool `yaml:"littleEndian,omitempty"`
+	// Signed is set when the value is a two's complement number. The sign bit is
+	// the highest bit of the mask, or of the value when there isn't a mask.
+	Signed bool `yaml:"signed,omitempty"`
+	// Mask selects the value's bits, which are shifted down to the mask's lowest
+	// bit, e.g. 0x10 reads the 5th bit as 0 or 1. Zero reads every bit.
+	Mask uint32 `yaml:"mask,omitempty"`
+	// Expression calculates the value from the raw value, which is referenced as x
+	// (e.g. x * 0.01 - 40). Empty is the raw value.
+	Expression string `yaml:"expression,omitempty"`
+	// Unit is the unit of the calculated value.
+	Unit units.Unit `yaml:"unit,omitempty"`
+}
+
+// Validate returns an error if the parameter is incomplete or its address, length,
+// mask, or expression is invalid.
+func (p RAMParameter) Validate() error {
+	_, err := p.compile()
+	return err
+}
+
+// Raw combines the bytes read from the parameter's address into its raw value.
+// The bytes must be Length long.
+func (p RAMParameter) Raw(b []byte) float64 {
+	var v uint32
+	for i := range b {
+		if p.LittleEndian {
+			v = v<<8 | uint32(b[len(b)-1-i])
+		} else {
+			v = v<<8 | uint32(b[i])
+		}
+	}
+
+	n := 8 * len(b)
+	if p.Mask != 0 {
+		shift := bits.TrailingZeros32(p.Mask)
+		v = (v & p.Mask) >> shift
+		n = bits.Len32(p.Mask >> shift)
+	}
+	if p.Signed && n > 0 && v&(1<<(n-1)) != 0 {
+		return float64(int64(v) - int64(1)<<n)
+	}
+	return float64(v)
+}
+
+// CompileRAMParameters validates the RAM parameters and compiles them into Parameters.
+// The IDs can't be used by the built-in parameters or the registered formulas and calibrations.
+func CompileRAMParameters(params []RAMParameter) ([]ssm2.Parameter, error) {
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+
+	c, err := compile(Definitions{RAMParameters: params, Formulas: active.Formulas, Calibrations: active.Calibrations})
+	if err != nil {
+		return nil, err
+	}
+	return c.params, nil
+}
+
+// IsRAMParameter returns true if the parameter with the ID was registered from a RAMParameter.
+func IsRAMParameter(id string) bool {
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+	return watched[id]
+}
+
+// compile validates the parameter and compiles it into a Parameter.
+func (p RAMParameter) compile() (ssm2.Parameter, error) {
+	if !idRegexp.MatchString(p.ID) {
+		return ssm2.Parameter{}, fmt.Errorf("invalid RAM parameter ID '%s': it must start with a letter and only contain letters, numbers, and underscores", p.ID)
+	}
+	if strings.TrimSpace(p.Name) == "" {
+		return ssm2.Parameter{}, fmt.Errorf("RAM parameter %s: the name is required", p.ID)
+	}
+	addr, err := ssm2.ParseAddress(p.Address)
+	if err != nil {
+		return ssm2.Parameter{}, errors.Wrapf(err, "RAM parameter %s", p.ID)
+	}
+	if p.Length < 1 || p.Length > maxRAMParameterLength {
+		return ssm2.Parameter{}, fmt.Errorf("RAM parameter %s: the length must be from 1 to %d bytes", p.ID, maxRAMParameterLength)
+	}
+	if uint32(addr[0])<<16|uint32(addr[1])<<8|uint32(addr[2])+uint32(p.Length-1) > 0xFFFFFF {
+		return ssm2.Parameter{}, fmt.Errorf("RAM parameter %s: the value goes past the last address", p.ID)
+	}
+	if p.Length < maxRAMParameterLength && p.Mask >= 1<<(8*p.Length) {
+		return ssm2.Parameter{}, fmt.Errorf("RAM parameter %s: the mask 0x%X is longer than %d byte(s)", p.ID, p.Mask, p.Length)
+	}
+
+	var expr *Expression
+	if strings.TrimSpace(p.Expression) != "" {
+		if expr, err = Parse(p.Expression); err != nil {
+			return ssm2.Parameter{}, errors.Wrapf(err, "RAM parameter %s", p.ID)
+		}
+		for _, r := range expr.References() {
+			if r.ID != RawValueID || r.Unit != "" {
+				return ssm2.Parameter{}, fmt.Errorf("RAM parameter %s: the expression can only reference the raw value %s", p.ID, RawValueID)
+			}
+		}
+	}
+
+	description := p.Description
+	if description == "" {
+		description = fmt.Sprintf("%s-Read from 0x%X", p.ID, addr[:])
+	}
+	format := ssm2.Format{}
+	if expr == nil {
+		format.Kind = ssm2.Integer
+	}
+
+	return ssm2.Parameter{
+		Id:          p.ID,
+		Name:        p.Name,
+		Description: description,
+		DefaultUnit: p.Unit,
+		Address:     &ssm2.Address{Address: addr, Length: p.Length},
+		Format:      format,
+		Category:    ssm2.CategoryOther,
+		Custom:      true,
+		Value: func(b []byte) ssm2.ParameterValue {
+			x := p.Raw(b)
+			if expr == nil {
+				return ssm2.ParameterValue{Value: float32(x), Unit: p.Unit}
+			}
+			// the value isn't a number when the expression fails, e.g. dividing by zero
+			v, err := expr.evalRaw(x)
+			if err != nil {
+				v = math.NaN()
+			}
+			return ssm2.ParameterValue{Value: float32(v), Unit: p.Unit}
+		},
+	}, nil
+}
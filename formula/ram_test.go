@@ -0,0 +1,147 @@
+package formula
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+// boostTarget is a 16-bit value in hundredths of a psi.
+var boostTarget = RAMParameter{
+	ID: "boost_target", Name: "Boost Target", Address: "FF6B1C", Length: 2,
+	Expression: "x * 0.01", Unit: units.PSI,
+}
+
+func TestRAMParameter_Raw(t *testing.T) {
+	tests := []struct {
+		name string
+		p    RAMParameter
+		b    []byte
+		want float64
+	}{
+		{"big endian", RAMParameter{}, []byte{0x12, 0x34}, 0x1234},
+		{"little endian", RAMParameter{LittleEndian: true}, []byte{0x12, 0x34}, 0x3412},
+		{"signed", RAMParameter{Signed: true}, []byte{0xFF, 0xFE}, -2},
+		{"signed positive", RAMParameter{Signed: true}, []byte{0x7F, 0xFF}, 0x7FFF},
+		{"32-bit", RAMParameter{}, []byte{0x12, 0x34, 0x56, 0x78}, 0x12345678},
+		{"signed 32-bit", RAMParameter{Signed: true}, []byte{0xFF, 0xFF, 0xFF, 0xFF}, -1},
+		{"bit", RAMParameter{Mask: 0x10}, []byte{0x3C}, 1},
+		{"masked", RAMParameter{Mask: 0x0FF0}, []byte{0xAB, 0xCD}, 0xBC},
+		{"signed mask", RAMParameter{Mask: 0xF0, Signed: true}, []byte{0xE0}, -2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Raw(tt.b); got != tt.want {
+				t.Fatalf("Raw(% X) = %v, want %v", tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileRAMParameters(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  []RAMParameter
+		wantErr bool
+	}{
+		{"valid", []RAMParameter{
+			boostTarget,
+			{ID: "flag", Name: "Flag", Address: "0xff6b1e", Length: 1, Mask: 0x80},
+			{ID: "counter", Name: "Counter", Address: "FFFFFC", Length: 4, LittleEndian: true, Mask: 0xFFFFFFFF},
+		}, false},
+		{"invalid ID", []RAMParameter{{ID: "boost target", Name: "A", Address: "FF6B1C", Length: 1}}, true},
+		{"built-in ID", []RAMParameter{{ID: "P8", Name: "A", Address: "FF6B1C", Length: 1}}, true},
+		{"duplicate ID", []RAMParameter{
+			{ID: "a", Name: "A", Address: "FF6B1C", Length: 1},
+			{ID: "a", Name: "A", Address: "FF6B1D", Length: 1},
+		}, true},
+		{"missing name", []RAMParameter{{ID: "a", Address: "FF6B1C", Length: 1}}, true},
+		{"invalid address", []RAMParameter{{ID: "a", Name: "A", Address: "FF6B1CC", Length: 1}}, true},
+		{"missing length", []RAMParameter{{ID: "a", Name: "A", Address: "FF6B1C"}}, true},
+		{"too long", []RAMParameter{{ID: "a", Name: "A", Address: "FF6B1C", Length: 5}}, true},
+		{"past the last address", []RAMParameter{{ID: "a", Name: "A", Address: "FFFFFF", Length: 2}}, true},
+		{"mask longer than the value", []RAMParameter{{ID: "a", Name: "A", Address: "FF6B1C", Length: 1, Mask: 0x100}}, true},
+		{"syntax error", []RAMParameter{{ID: "a", Name: "A", Address: "FF6B1C", Length: 1, Expression: "x *"}}, true},
+		{"references a parameter", []RAMParameter{{ID: "a", Name: "A", Address: "FF6B1C", Length: 1, Expression: "x * P8"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CompileRAMParameters(tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CompileRAMParameters() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCompileRAMParameters_Precision(t *testing.T) {
+	params, err := CompileRAMParameters([]RAMParameter{
+		{ID: "counter", Name: "Counter", Address: "FFFFFC", Length: 4, Expression: "x - 16777216"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2^24 + 1 can't be held by a float32, so it's only exact when evaluated as a float64
+	if v := params[0].Value([]byte{0x01, 0x00, 0x00, 0x01}); v.Value != 1 {
+		t.Fatalf("want 1. got: %v", v.Value)
+	}
+}
+
+func TestRegisterAll_RAMParameters(t *testing.T) {
+	defer RegisterAll(Definitions{})
+
+	err := RegisterAll(Definitions{
+		RAMParameters: []RAMParameter{boostTarget, {ID: "divided", Name: "Divided", Address: "FF6B1E", Length: 1, Expression: "1 / x"}},
+		Formulas:      []Formula{{ID: "boost_error", Name: "Boost Error", Expression: "boost_target - P7[psi]", Unit: units.PSI}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsRAMParameter("boost_target") || IsRAMParameter("boost_error") {
+		t.Fatal("want only the RAM parameters registered as RAM parameters")
+	}
+	for _, issue := range ssm2.CheckCatalog(ssm2.Parameters, ssm2.DerivedParameters).Errors() {
+		t.Errorf("want the registered parameters to keep the catalog consistent. got: %s", issue)
+	}
+
+	p, ok := ssm2.Parameters["boost_target"]
+	if !ok || !p.Custom || p.Address == nil || p.Address.Address != [3]byte{0xFF, 0x6B, 0x1C} || p.Address.Length != 2 {
+		t.Fatalf("want the RAM parameter added to the parameters. got: %+v", p)
+	}
+	if v := p.Value([]byte{0x03, 0xE8}); math.Abs(float64(v.Value)-10) > 0.001 || v.Unit != units.PSI {
+		t.Fatalf("want 10 psi. got: %+v", v)
+	}
+	if v := ssm2.Parameters["divided"].Value([]byte{0}); !math.IsNaN(float64(v.Value)) {
+		t.Fatalf("want NaN when the expression fails. got: %v", v.Value)
+	}
+
+	// the RAM parameters are logged like the built-in parameters
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results, err := ssm2.LoggingSession(ctx, ssm2.NewFakeConnection(time.Millisecond),
+		[]ssm2.Parameter{p}, []ssm2.DerivedParameter{ssm2.DerivedParameters["boost_error"]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := <-results
+	for _, id := range []string{"boost_target", "P7", "boost_error"} {
+		if _, ok := values[id]; !ok {
+			t.Fatalf("want a value for %s. got: %v", id, values)
+		}
+	}
+
+	// a RAM parameter used by a formula can't be removed
+	if err = RegisterAll(Definitions{Formulas: active.Formulas}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if err = RegisterAll(Definitions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ssm2.Parameters["boost_target"]; ok {
+		t.Fatal("want the RAM parameters removed")
+	}
+}
@@ -0,0 +1,202 @@
+// Package logfile writes logged parameter values to the CSV files created by
+// both ssm2-cli and logger-ui, so their logs can be used interchangeably.
+package logfile
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/gavinwade12/ecLogger/config"
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+	"github.com/pkg/errors"
+)
+
+// TimestampFormat is the format of the timestamp in the first column of each row.
+const TimestampFormat = "2006-01-02 15:04:05.999999999" // yyyy-MM-dd hh:mm:ss
+
+// minFilePrecision is the fewest decimals float values are written with, so log
+// files keep more resolution than what's displayed.
+const minFilePrecision = 4
+
+// Column is a logged parameter in a log file.
+type Column struct {
+	ID     string
+	Name   string
+	Unit   units.Unit
+	Format ssm2.Format
+}
+
+// Columns returns the columns for the parameters (followed by the derived parameters)
+// using the units from logged, or the parameter's default unit when it isn't configured.
+func Columns(params []ssm2.Parameter, derived []ssm2.DerivedParameter, logged []config.Parameter) []Column {
+	configured := make(map[string]units.Unit, len(logged))
+	for _, p := range logged {
+		configured[p.ID] = p.Unit
+	}
+	unit := func(id string, def units.Unit) units.Unit {
+		if u := configured[id]; u != "" {
+			return u
+		}
+		return def
+	}
+
+	columns := make([]Column, 0, len(params)+len(derived))
+	for _, p := range params {
+		columns = append(columns, Column{p.Id, p.Name, unit(p.Id, p.DefaultUnit), p.Format})
+	}
+	for _, p := range derived {
+		columns = append(columns, Column{p.Id, p.Name, unit(p.Id, p.DefaultUnit), p.Format})
+	}
+	return columns
+}
+
+// ConvertUnits converts the values to the units configured in logged using the converter
+// (e.g. for the vehicle's fuel). Values that can't be converted are removed, so they aren't
+// written under a column labeled with another unit, and the failure is logged.
+func ConvertUnits(values map[string]ssm2.ParameterValue, logged []config.Parameter, c units.Converter, l ssm2.Logger) {
+	for _, p := range logged {
+		val, ok := values[p.ID]
+		if !ok || p.Unit == "" || p.Unit == val.Unit {
+			continue
+		}
+
+		converted, err := val.ConvertWith(p.Unit, c)
+		if err != nil {
+			l.Warn("converting parameter value", "id", p.ID, "from", val.Unit, "to", p.Unit, "error", err)
+			delete(values, p.ID)
+			continue
+		}
+		values[p.ID] = *converted
+	}
+}
+
+// Create creates (or truncates) the log file at path, creating its directory if needed.
+func Create(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, errors.Wrap(err, "creating log directory")
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_RDWR, os.ModePerm)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening log file")
+	}
+	return f, nil
+}
+
+// Metadata describes the ECU, vehicle, and profile a log file was recorded with. It's
+// written next to the log file as JSON so the CSV can still be read by other tools.
+type Metadata struct {
+	ROMID string `json:"romId"`
+	SSMID string `json:"ssmId"`
+	// Vehicle labels the vehicle identified by the ROM ID database, e.g. "2006 WRX 5MT, USDM".
+	// It and the identified vehicle's details are empty when the vehicle isn't in the database.
+	Vehicle      string    `json:"vehicle,omitempty"`
+	Market       string    `json:"market,omitempty"`
+	Model        string    `json:"model,omitempty"`
+	Year         int       `json:"year,omitempty"`
+	Engine       string    `json:"engine,omitempty"`
+	Transmission string    `json:"transmission,omitempty"`
+	Nickname     string    `json:"nickname,omitempty"`
+	Profile      string    `json:"profile"`
+	Started      time.Time `json:"started"`
+}
+
+// NewMetadata returns the metadata for a log of the ECU started at t, using the
+// connected vehicle's settings and the ROM ID database from c.
+func NewMetadata(c *config.Config, ecu *ssm2.ECU, t time.Time) Metadata {
+	m := Metadata{
+		ROMID:   hex.EncodeToString(ecu.ROM_ID),
+		SSMID:   hex.EncodeToString(ecu.SSM_ID),
+		Profile: c.Logging.Profile,
+		Started: t,
+	}
+	if v := c.FindVehicle(ecu.ROM_ID); v != nil {
+		m.Nickname = v.Nickname
+	}
+	if e, ok := c.IdentifyVehicle(ecu.ROM_ID, ecu.SSM_ID); ok {
+		m.Vehicle = e.Label()
+		m.Market, m.Model, m.Year, m.Engine, m.Transmission = e.Market, e.Model, e.Year, e.Engine, e.Transmission
+	}
+	return m
+}
+
+// MetadataPath returns the path of the metadata for the log file at path.
+func MetadataPath(path string) string {
+	return path + ".json"
+}
+
+// WriteMetadata writes the metadata for the log file at path.
+func WriteMetadata(path string, m Metadata) error {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encoding log metadata")
+	}
+	if err = os.WriteFile(MetadataPath(path), append(b, '\n'), 0644); err != nil {
+		return errors.Wrap(err, "writing log metadata")
+	}
+	return nil
+}
+
+// Writer writes a header and rows of parameter values as CSV.
+type Writer struct {
+	csv     *csv.Writer
+	columns []Column
+	row     []string
+}
+
+// NewWriter returns a Writer writing the columns to w.
+func NewWriter(w io.Writer, columns []Column) *Writer {
+	return &Writer{
+		csv:     csv.NewWriter(w),
+		columns: columns,
+		row:     make([]string, len(columns)+1),
+	}
+}
+
+// WriteHeader writes the header row containing each column's name and unit.
+func (w *Writer) WriteHeader() error {
+	w.row[0] = "Timestamp"
+	for i, c := range w.columns {
+		w.row[i+1] = fmt.Sprintf("%s (%s)", c.Name, c.Unit)
+	}
+	return w.write()
+}
+
+// WriteRow writes a row containing the timestamp and the value of each column.
+// Enum values are written as their label and integer values as whole numbers.
+// Missing values are written as empty cells so they can't be mistaken for readings.
+func (w *Writer) WriteRow(t time.Time, values map[string]ssm2.ParameterValue) error {
+	w.row[0] = t.Format(TimestampFormat)
+	for i, c := range w.columns {
+		v, ok := values[c.ID]
+		if !ok {
+			w.row[i+1] = ""
+			continue
+		}
+		w.row[i+1] = formatValue(c.Format, v.Value)
+	}
+	return w.write()
+}
+
+// formatValue formats the value for a log file.
+func formatValue(f ssm2.Format, v float32) string {
+	if f.Kind != ssm2.Float {
+		return f.String(v)
+	}
+	return strconv.FormatFloat(float64(v), 'f', max(f.Decimals(), minFilePrecision), 32)
+}
+
+func (w *Writer) write() error {
+	if err := w.csv.Write(w.row); err != nil {
+		return err
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
@@ -0,0 +1,107 @@
+package logfile
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gavinwade12/ecLogger/config"
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/romdb"
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+func TestWriter(t *testing.T) {
+	logged := []config.Parameter{
+		{ID: "P2", Unit: units.F},
+		{ID: "P8", Unit: units.RPM},
+	}
+	columns := Columns(
+		[]ssm2.Parameter{ssm2.Parameters["P2"], ssm2.Parameters["P8"], ssm2.Parameters["P60"]},
+		[]ssm2.DerivedParameter{ssm2.DerivedParameters["P200"]},
+		logged,
+	)
+
+	var b bytes.Buffer
+	w := NewWriter(&b, columns)
+	if err := w.WriteHeader(); err != nil {
+		t.Fatal(err)
+	}
+
+	values := map[string]ssm2.ParameterValue{
+		"P2":   {Value: 100, Unit: units.C},
+		"P8":   {Value: 2500.25, Unit: units.RPM},
+		"P60":  {Value: 3, Unit: units.Gear},
+		"P200": {Value: 0.5, Unit: units.GramsPerRev},
+	}
+	ConvertUnits(values, logged, units.DefaultConverter, ssm2.NopLogger)
+	ts := time.Date(2024, 3, 9, 14, 5, 6, 500000000, time.UTC)
+	if err := w.WriteRow(ts, values); err != nil {
+		t.Fatal(err)
+	}
+
+	delete(values, "P8")
+	if err := w.WriteRow(ts.Add(time.Second), values); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "Timestamp,Coolant Temperature (F),Engine Speed (rpm),Gear Position (gear),Engine Load (Calculated) (g/rev)\n" +
+		"2024-03-09 14:05:06.5,212.0000,2500,3rd,0.5000\n" +
+		"2024-03-09 14:05:07.5,212.0000,,3rd,0.5000\n"
+	if b.String() != want {
+		t.Fatalf("want:\n%s\ngot:\n%s", want, b.String())
+	}
+}
+
+func TestConvertUnits(t *testing.T) {
+	values := map[string]ssm2.ParameterValue{
+		"P2": {Value: 100, Unit: units.C},
+		"P8": {Value: 2500, Unit: units.RPM},
+	}
+	ConvertUnits(values, []config.Parameter{{ID: "P2", Unit: units.F}, {ID: "P8", Unit: units.PSI}},
+		units.DefaultConverter, ssm2.NopLogger)
+
+	if v := values["P2"]; v.Value != 212 || v.Unit != units.F {
+		t.Fatalf("want 212 F. got: %v %s", v.Value, v.Unit)
+	}
+	if v, ok := values["P8"]; ok {
+		t.Fatalf("want the value that couldn't be converted removed. got: %v %s", v.Value, v.Unit)
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	c := config.Default()
+	c.ROMIDs = []romdb.Entry{{ROMID: "2f12785606", Market: "USDM", Model: "WRX", Year: 2006, Engine: "EJ255", Transmission: "5MT"}}
+	c.Vehicle([]byte{0x2F, 0x12, 0x78, 0x56, 0x06}).Nickname = "wrx"
+	ecu := &ssm2.ECU{ROM_ID: []byte{0x2F, 0x12, 0x78, 0x56, 0x06}, SSM_ID: []byte{0xA4, 0x10, 0x0C}}
+	started := time.Date(2024, 3, 9, 14, 5, 6, 0, time.UTC)
+
+	path := filepath.Join(t.TempDir(), "log.csv")
+	if err := WriteMetadata(path, NewMetadata(c, ecu, started)); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(MetadataPath(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{
+  "romId": "2f12785606",
+  "ssmId": "a4100c",
+  "vehicle": "2006 WRX 5MT, USDM",
+  "market": "USDM",
+  "model": "WRX",
+  "year": 2006,
+  "engine": "EJ255",
+  "transmission": "5MT",
+  "nickname": "wrx",
+  "profile": "default",
+  "started": "2024-03-09T14:05:06Z"
+}
+`
+	if string(b) != want {
+		t.Fatalf("want:\n%s\ngot:\n%s", want, b)
+	}
+}
@@ -0,0 +1,331 @@
+package ssm2
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+// Severity is how serious a CatalogIssue is.
+type Severity string
+
+const (
+	// SeverityError is a mistake that breaks a parameter, e.g. a Value that panics.
+	SeverityError Severity = "error"
+	// SeverityWarning is a likely mistake or something that makes the catalog harder to use.
+	SeverityWarning Severity = "warning"
+)
+
+// The checks run by CheckCatalog, in the order their issues are reported.
+const (
+	CheckIDs            = "ids"
+	CheckCapabilityBits = "capability bits"
+	CheckAddresses      = "addresses"
+	CheckValues         = "values"
+	CheckDependencies   = "dependencies"
+	CheckUnits          = "units"
+	CheckNames          = "names"
+	CheckDescriptions   = "descriptions"
+)
+
+var checkOrder = []string{
+	CheckIDs, CheckCapabilityBits, CheckAddresses, CheckValues, CheckDependencies,
+	CheckUnits, CheckNames, CheckDescriptions,
+}
+
+// CatalogIssue is a problem with one or more parameters in the catalog.
+type CatalogIssue struct {
+	Severity Severity
+	Check    string
+	// IDs are the parameters with the problem, sorted.
+	IDs     []string
+	Message string
+}
+
+func (i CatalogIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.Severity, strings.Join(i.IDs, ", "), i.Message)
+}
+
+// CatalogIssues are the issues found by CheckCatalog.
+type CatalogIssues []CatalogIssue
+
+// Errors returns the issues with SeverityError.
+func (issues CatalogIssues) Errors() CatalogIssues {
+	var errs CatalogIssues
+	for _, i := range issues {
+		if i.Severity == SeverityError {
+			errs = append(errs, i)
+		}
+	}
+	return errs
+}
+
+// Report writes the issues grouped by check, followed by a count of the errors and warnings.
+func (issues CatalogIssues) Report(w io.Writer) error {
+	var check string
+	for _, i := range issues {
+		if i.Check != check {
+			check = i.Check
+			if _, err := fmt.Fprintf(w, "\n%s:\n", check); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "  %s\n", i); err != nil {
+			return err
+		}
+	}
+	errs := len(issues.Errors())
+	_, err := fmt.Fprintf(w, "\n%d error(s), %d warning(s)\n", errs, len(issues)-errs)
+	return err
+}
+
+// valueProbes are the bytes each parameter's Value is called with by CheckCatalog,
+// in addition to every value of a single byte.
+var valueProbes = []byte{0x00, 0x01, 0x7F, 0x80, 0xFE, 0xFF}
+
+// CheckCatalog checks the parameters and derived parameters for mistakes:
+//   - IDs used by both a parameter and a derived parameter, whose values would be mixed up
+//   - parameters flagged by the same capability bit
+//   - parameters with overlapping addresses
+//   - Value functions that panic, return a unit other than the default unit, or return
+//     non-finite values for any bytes
+//   - derived parameters that depend on missing parameters, and stateful derived
+//     parameters without a calculator
+//   - units that aren't defined or can't be converted to any other unit of their dimension
+//   - duplicate names
+//   - descriptions that are just the ID
+//
+// The issues are sorted by check and ID.
+func CheckCatalog(params map[string]Parameter, derived map[string]DerivedParameter) CatalogIssues {
+	var issues CatalogIssues
+	add := func(severity Severity, check, message string, ids ...string) {
+		SortIDs(ids)
+		issues = append(issues, CatalogIssue{Severity: severity, Check: check, IDs: ids, Message: message})
+	}
+
+	for id := range derived {
+		if _, ok := params[id]; ok {
+			add(SeverityError, CheckIDs, "is the ID of both a parameter and a derived parameter", id)
+		}
+	}
+	checkCapabilityBits(params, add)
+	checkAddresses(params, add)
+	for _, p := range params {
+		checkValue(p, add)
+	}
+	for _, p := range derived {
+		for _, id := range p.DependsOnParameters {
+			_, isParam := params[id]
+			_, isDerived := derived[id]
+			if !isParam && !isDerived {
+				add(SeverityError, CheckDependencies, fmt.Sprintf("depends on the missing parameter %s", id), p.Id)
+			}
+		}
+		if p.Value == nil && p.NewCalculator == nil {
+			add(SeverityError, CheckDependencies, "has neither a Value nor a calculator", p.Id)
+		}
+	}
+	checkUnits(params, derived, add)
+	checkNamesAndDescriptions(params, derived, add)
+
+	order := make(map[string]int, len(checkOrder))
+	for i, c := range checkOrder {
+		order[c] = i
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.Check != b.Check {
+			return order[a.Check] < order[b.Check]
+		}
+		if a.IDs[0] != b.IDs[0] {
+			return LessID(a.IDs[0], b.IDs[0])
+		}
+		return a.Message < b.Message
+	})
+	return issues
+}
+
+type addIssue func(severity Severity, check, message string, ids ...string)
+
+func checkCapabilityBits(params map[string]Parameter, add addIssue) {
+	type bit struct {
+		byteIndex uint
+		bitIndex  uint8
+	}
+	flagged := make(map[bit][]string)
+	for _, p := range params {
+		if p.Custom {
+			continue
+		}
+		if p.CapabilityBitIndex > 7 {
+			add(SeverityError, CheckCapabilityBits, fmt.Sprintf("has the invalid capability bit %d", p.CapabilityBitIndex), p.Id)
+			continue
+		}
+		if p.CapabilityByteIndex < CapabilityByteOffset {
+			add(SeverityError, CheckCapabilityBits, fmt.Sprintf("has capability byte %d, which is part of the ECU's IDs", p.CapabilityByteIndex), p.Id)
+			continue
+		}
+		b := bit{p.CapabilityByteIndex, p.CapabilityBitIndex}
+		flagged[b] = append(flagged[b], p.Id)
+	}
+	for b, ids := range flagged {
+		if len(ids) > 1 {
+			add(SeverityWarning, CheckCapabilityBits, fmt.Sprintf("share capability byte %d, bit %d", b.byteIndex, b.bitIndex), ids...)
+		}
+	}
+}
+
+func checkAddresses(params map[string]Parameter, add addIssue) {
+	type span struct {
+		id         string
+		start, end uint32 // end is exclusive
+	}
+	var spans []span
+	for _, p := range params {
+		if p.Address == nil {
+			continue
+		}
+		if p.Address.Length < 1 {
+			add(SeverityError, CheckAddresses, fmt.Sprintf("has the invalid address length %d", p.Address.Length), p.Id)
+			continue
+		}
+		start := addressValue(p.Address.Address)
+		spans = append(spans, span{p.Id, start, start + uint32(p.Address.Length)})
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		if spans[i].start != spans[j].start {
+			return spans[i].start < spans[j].start
+		}
+		return LessID(spans[i].id, spans[j].id)
+	})
+
+	for i, a := range spans {
+		for _, b := range spans[i+1:] {
+			if b.start >= a.end {
+				break
+			}
+			message := fmt.Sprintf("overlap at 0x%06X", b.start)
+			if a.start == b.start && a.end == b.end {
+				message = fmt.Sprintf("read the same %d byte(s) at 0x%06X", a.end-a.start, a.start)
+			}
+			add(SeverityWarning, CheckAddresses, message, a.id, b.id)
+		}
+	}
+}
+
+// checkValue calls the parameter's Value with bytes from valueProbes and every value
+// of a single byte, and reports the first problem.
+func checkValue(p Parameter, add addIssue) {
+	if p.Value == nil {
+		add(SeverityError, CheckValues, "doesn't have a Value", p.Id)
+		return
+	}
+	if p.Address == nil || p.Address.Length < 1 {
+		return // reported by checkAddresses, or there aren't any bytes to read
+	}
+
+	var inputs [][]byte
+	for _, b := range valueProbes {
+		inputs = append(inputs, bytesOf(b, p.Address.Length))
+	}
+	for i := 0; i < 256; i++ {
+		in := make([]byte, p.Address.Length)
+		in[len(in)-1] = byte(i)
+		inputs = append(inputs, in)
+	}
+
+	for _, in := range inputs {
+		v, panicked := callValue(p, in)
+		switch {
+		case panicked != nil:
+			add(SeverityError, CheckValues, fmt.Sprintf("panics for % X: %v", in, panicked), p.Id)
+		case v.Unit != p.DefaultUnit:
+			add(SeverityError, CheckValues, fmt.Sprintf("returns the unit %q instead of its default unit %q", v.Unit, p.DefaultUnit), p.Id)
+		case !p.Custom && (math.IsNaN(float64(v.Value)) || math.IsInf(float64(v.Value), 0)):
+			add(SeverityWarning, CheckValues, fmt.Sprintf("returns %v for % X", v.Value, in), p.Id)
+		default:
+			continue
+		}
+		return
+	}
+}
+
+func bytesOf(b byte, n int) []byte {
+	in := make([]byte, n)
+	for i := range in {
+		in[i] = b
+	}
+	return in
+}
+
+func callValue(p Parameter, in []byte) (v ParameterValue, panicked interface{}) {
+	defer func() { panicked = recover() }()
+	return p.Value(in), nil
+}
+
+func checkUnits(params map[string]Parameter, derived map[string]DerivedParameter, add addIssue) {
+	used := make(map[units.Unit][]string)
+	for _, p := range params {
+		used[p.DefaultUnit] = append(used[p.DefaultUnit], p.Id)
+	}
+	for _, p := range derived {
+		used[p.DefaultUnit] = append(used[p.DefaultUnit], p.Id)
+	}
+
+	for u, ids := range used {
+		if u == "" {
+			add(SeverityWarning, CheckUnits, "don't have a default unit", ids...)
+			continue
+		}
+		d, ok := units.Dimensions[u]
+		if !ok {
+			add(SeverityError, CheckUnits, fmt.Sprintf("use the unit %q, which isn't defined and can't be converted", u), ids...)
+			continue
+		}
+		if d == units.Dimensionless || len(units.UnitsOf(d)) < 2 {
+			continue
+		}
+		if len(units.ConvertibleUnits(u)) == 0 {
+			add(SeverityWarning, CheckUnits, fmt.Sprintf("use the unit %q, which can't be converted to the other %s units", u, d), ids...)
+		}
+	}
+}
+
+func checkNamesAndDescriptions(params map[string]Parameter, derived map[string]DerivedParameter, add addIssue) {
+	names := make(map[string][]string)
+	// spelling is the name as spelled by the lowest ID sharing it, so the reports don't
+	// depend on the maps' iteration order
+	spelling := make(map[string]string)
+	spellingID := make(map[string]string)
+	check := func(id, name, description string) {
+		key := strings.ToLower(strings.TrimSpace(name))
+		names[key] = append(names[key], id)
+		if first, ok := spellingID[key]; !ok || LessID(id, first) {
+			spelling[key], spellingID[key] = name, id
+		}
+
+		description = strings.TrimSpace(description)
+		if description == "" || description == id || strings.TrimPrefix(description, id+"-") == "" {
+			add(SeverityWarning, CheckDescriptions, "doesn't have a description besides its ID", id)
+		}
+	}
+	for _, p := range params {
+		check(p.Id, p.Name, p.Description)
+	}
+	for _, p := range derived {
+		check(p.Id, p.Name, p.Description)
+	}
+
+	for name, ids := range names {
+		switch {
+		case name == "":
+			add(SeverityError, CheckNames, "don't have a name", ids...)
+		case len(ids) > 1:
+			add(SeverityWarning, CheckNames, fmt.Sprintf("share the name %q", spelling[name]), ids...)
+		}
+	}
+}
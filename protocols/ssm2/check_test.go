@@ -0,0 +1,131 @@
+package ssm2_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+func TestCatalog(t *testing.T) {
+	issues := ssm2.CheckCatalog(ssm2.Parameters, ssm2.DerivedParameters)
+	for _, i := range issues.Errors() {
+		t.Error(i)
+	}
+	t.Logf("%d warning(s)", len(issues)-len(issues.Errors()))
+}
+
+func TestCheckCatalog(t *testing.T) {
+	byte1 := func(v []byte) ssm2.ParameterValue { return ssm2.ParameterValue{float32(v[0]), units.Percent} }
+	params := map[string]ssm2.Parameter{
+		"P1": {
+			Id: "P1", Name: "One", Description: "P1-The first.", DefaultUnit: units.Percent,
+			CapabilityByteIndex: 8, CapabilityBitIndex: 7,
+			Address: &ssm2.Address{Address: [3]byte{0, 0, 1}, Length: 2},
+			Value:   byte1,
+		},
+		"P2": {
+			Id: "P2", Name: "one", Description: "P2", DefaultUnit: units.Percent,
+			CapabilityByteIndex: 8, CapabilityBitIndex: 7,
+			Address: &ssm2.Address{Address: [3]byte{0, 0, 2}, Length: 1},
+			Value: func(v []byte) ssm2.ParameterValue {
+				return ssm2.ParameterValue{float32(v[0]) / float32(v[0]-0x80), units.Percent}
+			},
+		},
+		"P3": {
+			Id: "P3", Name: "Three", Description: "P3-The third.", DefaultUnit: units.Percent,
+			CapabilityByteIndex: 8, CapabilityBitIndex: 5,
+			Address: &ssm2.Address{Address: [3]byte{0, 0, 3}, Length: 1},
+			Value:   func(v []byte) ssm2.ParameterValue { return byte1(v[1:]) },
+		},
+		"P4": {
+			Id: "P4", Name: "Four", Description: "P4-The fourth.", DefaultUnit: "furlongs",
+			CapabilityByteIndex: 8, CapabilityBitIndex: 4,
+			Address: &ssm2.Address{Address: [3]byte{0, 0, 4}, Length: 1},
+			Value:   func(v []byte) ssm2.ParameterValue { return ssm2.ParameterValue{float32(v[0]), "furlongs"} },
+		},
+		"P5": {
+			Id: "P5", Name: "Five", Description: "P5-The fifth.", DefaultUnit: units.PSI,
+			CapabilityByteIndex: 8, CapabilityBitIndex: 3,
+			Address: &ssm2.Address{Address: [3]byte{0, 0, 5}, Length: 1},
+			Value:   byte1,
+		},
+	}
+	derived := map[string]ssm2.DerivedParameter{
+		"P200": {
+			Id: "P200", Name: "Derived", Description: "P200-Derived from P1 and P9.", DefaultUnit: units.Percent,
+			DependsOnParameters: []string{"P1", "P9"},
+		},
+	}
+
+	issues := ssm2.CheckCatalog(params, derived)
+	want := []struct {
+		severity ssm2.Severity
+		check    string
+		ids      string
+		message  string
+	}{
+		{ssm2.SeverityWarning, ssm2.CheckCapabilityBits, "P1, P2", "share capability byte 8, bit 7"},
+		{ssm2.SeverityWarning, ssm2.CheckAddresses, "P1, P2", "overlap at 0x000002"},
+		{ssm2.SeverityWarning, ssm2.CheckValues, "P2", "returns +Inf for 80"},
+		{ssm2.SeverityError, ssm2.CheckValues, "P3", "panics for 00"},
+		{ssm2.SeverityError, ssm2.CheckValues, "P5", `returns the unit "%" instead of its default unit "psi"`},
+		{ssm2.SeverityError, ssm2.CheckDependencies, "P200", "depends on the missing parameter P9"},
+		{ssm2.SeverityError, ssm2.CheckDependencies, "P200", "has neither a Value nor a calculator"},
+		{ssm2.SeverityError, ssm2.CheckUnits, "P4", `use the unit "furlongs", which isn't defined`},
+		{ssm2.SeverityWarning, ssm2.CheckNames, "P1, P2", `share the name "One"`},
+		{ssm2.SeverityWarning, ssm2.CheckDescriptions, "P2", "doesn't have a description besides its ID"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("want %d issues. got: %v", len(want), issues)
+	}
+	for i, w := range want {
+		got := issues[i]
+		if got.Severity != w.severity || got.Check != w.check || strings.Join(got.IDs, ", ") != w.ids ||
+			!strings.HasPrefix(got.Message, w.message) {
+			t.Errorf("issue %d: want %s %s: %s: %s. got: %s %s: %v", i, w.severity, w.check, w.ids, w.message, got.Severity, got.Check, got)
+		}
+	}
+
+	var report strings.Builder
+	if err := issues.Report(&report); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(report.String(), "\ndependencies:\n  error: P200: depends on the missing parameter P9\n") ||
+		!strings.HasSuffix(report.String(), "\n5 error(s), 5 warning(s)\n") {
+		t.Fatalf("unexpected report:\n%s", report.String())
+	}
+}
+
+func TestCheckCatalog_DuplicateIDs(t *testing.T) {
+	params := map[string]ssm2.Parameter{
+		"P1": {
+			Id: "P1", Name: "One", Description: "P1-The first.", DefaultUnit: units.Percent,
+			CapabilityByteIndex: 8, CapabilityBitIndex: 7,
+			Address: &ssm2.Address{Address: [3]byte{0, 0, 1}, Length: 1},
+			Value:   func(v []byte) ssm2.ParameterValue { return ssm2.ParameterValue{float32(v[0]), units.Percent} },
+		},
+	}
+	derived := map[string]ssm2.DerivedParameter{
+		"P1": {
+			Id: "P1", Name: "One (Calculated)", Description: "P1-Calculated.", DefaultUnit: units.Percent,
+			Value: func(map[string]ssm2.ParameterValue, ssm2.Vehicle) (*ssm2.ParameterValue, error) {
+				return &ssm2.ParameterValue{Value: 1, Unit: units.Percent}, nil
+			},
+		},
+	}
+
+	errs := ssm2.CheckCatalog(params, derived).Errors()
+	if len(errs) != 1 || errs[0].Check != ssm2.CheckIDs || strings.Join(errs[0].IDs, ", ") != "P1" {
+		t.Fatalf("want the duplicate ID P1 reported. got: %v", errs)
+	}
+}
+
+func TestLessID(t *testing.T) {
+	ids := []string{"P10", "boost", "P2", "D1", "P1", "P100", "Pa"}
+	ssm2.SortIDs(ids)
+	if got := strings.Join(ids, " "); got != "D1 P1 P2 P10 P100 Pa boost" {
+		t.Fatalf("unexpected order: %s", got)
+	}
+}
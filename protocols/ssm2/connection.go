@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"log"
 	"math"
 	"time"
 
@@ -16,41 +15,149 @@ import (
 type Connection interface {
 	InitECU(ctx context.Context) (*ECU, error)
 	SendReadAddressesRequest(ctx context.Context, addresses [][3]byte, continous bool) (Packet, error)
+	ReadBlock(ctx context.Context, address [3]byte, length int) ([]byte, error)
 	NextPacket(ctx context.Context) (Packet, error)
 	Close() error
 
 	logger() Logger
+	options() ConnectionOptions
 }
 
 type connection struct {
 	serialPort io.ReadWriteCloser
 	log        Logger
+	opts       ConnectionOptions
 }
 
+// ConnectionDataBits is the data bit setting (bits/word) used for the serial connection.
+const ConnectionDataBits int = 8
+
+// EchoHandling describes how a Connection deals with the request bytes
+// that some interfaces echo back on the K-line.
+type EchoHandling string
+
 const (
-	// ConnectionBaudRate is the baud rate (bits/s) used for the serial connection.
-	ConnectionBaudRate int = 4800
-	// ConnectionDataBits is the data bit setting (bits/word) used for the serial connection.
-	ConnectionDataBits int = 8
-	// ConnectionReadTimeout is the amount of time per read spent before a timeout occurs.
+	// EchoHandlingMatchCommand skips any packets read back with the same
+	// command as the request that was just sent.
+	EchoHandlingMatchCommand EchoHandling = "command"
+	// EchoHandlingDiscard reads and discards exactly as many bytes as were
+	// sent before reading the response. Use this for interfaces that always echo.
+	EchoHandlingDiscard EchoHandling = "discard"
+	// EchoHandlingNone expects the response to be the first packet read.
+	EchoHandlingNone EchoHandling = "none"
+)
+
+// EchoHandlings lists the valid EchoHandling values.
+var EchoHandlings = []EchoHandling{EchoHandlingMatchCommand, EchoHandlingDiscard, EchoHandlingNone}
+
+// WireTiming describes how a Connection waits for bytes to arrive before reading.
+type WireTiming string
+
+const (
+	// WireTimingBaud waits for the time it takes the expected bytes to
+	// transfer at the configured baud rate before each read.
+	WireTimingBaud WireTiming = "baud"
+	// WireTimingNone reads immediately and relies on the serial port's read timeout.
+	WireTimingNone WireTiming = "none"
+)
+
+// WireTimings lists the valid WireTiming values.
+var WireTimings = []WireTiming{WireTimingBaud, WireTimingNone}
+
+// ConnectionOptions configures the serial settings and read behavior of a Connection.
+type ConnectionOptions struct {
+	// BaudRate is the baud rate (bits/s) used for the serial connection.
+	BaudRate int `yaml:"baudRate"`
+	// ReadTimeout is the amount of time per read spent before a timeout occurs.
 	// The timeout is per read, but it may take several reads to consume an entire packet.
-	ConnectionReadTimeout time.Duration = time.Millisecond * 1500
-	// ConnectionTotalReadTimeout is the amount of time spent to read an entire buffer before
+	ReadTimeout time.Duration `yaml:"readTimeout"`
+	// TotalReadTimeout is the amount of time spent to read an entire buffer before
 	// a timeout occurs. This applies to the full-length read and not individual reads.
-	ConnectionTotalReadTimeout time.Duration = time.Millisecond * 5000
-)
+	TotalReadTimeout time.Duration `yaml:"totalReadTimeout"`
+	// MaxReadAttempts is the number of times reading a packet is attempted while
+	// searching for the start of a packet.
+	MaxReadAttempts int `yaml:"maxReadAttempts"`
+	// MaxConsecutiveErrors is the number of consecutive read errors a LoggingSession
+	// tolerates before it's closed.
+	MaxConsecutiveErrors int `yaml:"maxConsecutiveErrors"`
+	// EchoHandling determines how echoed request bytes are handled.
+	EchoHandling EchoHandling `yaml:"echoHandling"`
+	// WireTiming determines how long to wait for bytes to transfer before reading.
+	WireTiming WireTiming `yaml:"wireTiming"`
+}
+
+// DefaultConnectionOptions returns the options used by NewConnection.
+func DefaultConnectionOptions() ConnectionOptions {
+	return ConnectionOptions{
+		BaudRate:             4800,
+		ReadTimeout:          time.Millisecond * 1500,
+		TotalReadTimeout:     time.Millisecond * 5000,
+		MaxReadAttempts:      5,
+		MaxConsecutiveErrors: 3,
+		EchoHandling:         EchoHandlingMatchCommand,
+		WireTiming:           WireTimingBaud,
+	}
+}
+
+// WithDefaults returns a copy of the options with any unset
+// or invalid values replaced by their defaults.
+func (o ConnectionOptions) WithDefaults() ConnectionOptions {
+	d := DefaultConnectionOptions()
+	if o.BaudRate <= 0 {
+		o.BaudRate = d.BaudRate
+	}
+	if o.ReadTimeout <= 0 {
+		o.ReadTimeout = d.ReadTimeout
+	}
+	if o.TotalReadTimeout <= 0 {
+		o.TotalReadTimeout = d.TotalReadTimeout
+	}
+	if o.MaxReadAttempts <= 0 {
+		o.MaxReadAttempts = d.MaxReadAttempts
+	}
+	if o.MaxConsecutiveErrors <= 0 {
+		o.MaxConsecutiveErrors = d.MaxConsecutiveErrors
+	}
+	switch o.EchoHandling {
+	case EchoHandlingMatchCommand, EchoHandlingDiscard, EchoHandlingNone:
+	default:
+		o.EchoHandling = d.EchoHandling
+	}
+	switch o.WireTiming {
+	case WireTimingBaud, WireTimingNone:
+	default:
+		o.WireTiming = d.WireTiming
+	}
+	return o
+}
 
 // ErrReadTimeout is returned when reading a packet times out.
 var ErrReadTimeout = errors.New("the read operation timed out")
 
-// NewConnection returns a new Connection.
+// MaxReadAddresses is the most addresses that fit in a single read addresses
+// request, since the payload size (the data, including the continuous flag,
+// and the command byte) must fit in a byte.
+const MaxReadAddresses = (0xFF - 2) / 3
+
+// ErrTooManyAddresses is returned when a read addresses request would
+// contain more than MaxReadAddresses addresses.
+var ErrTooManyAddresses = fmt.Errorf("a read addresses request can't contain more than %d addresses", MaxReadAddresses)
+
+// NewConnection returns a new Connection using the DefaultConnectionOptions.
 func NewConnection(serialPort io.ReadWriteCloser, l Logger) Connection {
+	return NewConnectionWithOptions(serialPort, l, DefaultConnectionOptions())
+}
+
+// NewConnectionWithOptions returns a new Connection using the given options.
+// Unset options are replaced by their defaults.
+func NewConnectionWithOptions(serialPort io.ReadWriteCloser, l Logger, opts ConnectionOptions) Connection {
 	if l == nil {
 		l = NopLogger
 	}
 	return &connection{
 		serialPort: serialPort,
 		log:        l,
+		opts:       opts.WithDefaults(),
 	}
 }
 
@@ -73,6 +180,10 @@ func (c *connection) InitECU(ctx context.Context) (*ECU, error) {
 // When continous is true, NextPacket() will continue to return results for the given addresses until the ECU
 // is interrupted.
 func (c *connection) SendReadAddressesRequest(ctx context.Context, addresses [][3]byte, continous bool) (Packet, error) {
+	if len(addresses) > MaxReadAddresses {
+		return nil, ErrTooManyAddresses
+	}
+
 	data := make([]byte, 1+len(addresses)*3)
 	if continous {
 		data[0] = 0x01
@@ -99,6 +210,33 @@ func (c *connection) SendReadAddressesRequest(ctx context.Context, addresses [][
 	return rp, nil
 }
 
+// MaxReadBlockLength is the most bytes read by a single read block request. The
+// protocol allows longer blocks, but ECUs commonly reject them.
+const MaxReadBlockLength = 0x80
+
+// ReadBlock sends a read block request to the ECU and returns the length bytes starting at the address.
+// It can't be used while the ECU is continuously responding to a read addresses request.
+func (c *connection) ReadBlock(ctx context.Context, address [3]byte, length int) ([]byte, error) {
+	if length < 1 || length > MaxReadBlockLength {
+		return nil, fmt.Errorf("invalid block length %d: it must be from 1 to %d bytes", length, MaxReadBlockLength)
+	}
+
+	data := []byte{0x00, address[0], address[1], address[2], byte(length - 1)}
+	p := newPacket(DeviceDiagnosticTool, DeviceEngine, CommandReadBlockRequest, data)
+	rp, err := c.sendPacket(ctx, p)
+	if err != nil {
+		return nil, errors.Wrap(err, "sending packet")
+	}
+
+	if rp[PacketIndexCommand] != byte(CommandReadBlockResponse) {
+		return nil, ErrInvalidResponseCommand
+	}
+	if len(rp.Data()) != length {
+		return nil, fmt.Errorf("read %d bytes instead of the requested %d", len(rp.Data()), length)
+	}
+	return append([]byte{}, rp.Data()...), nil
+}
+
 func (c *connection) sendPacket(ctx context.Context, p Packet) (Packet, error) {
 	logBytes(c.log, p, "sending packet: ")
 
@@ -110,6 +248,17 @@ func (c *connection) sendPacket(ctx context.Context, p Packet) (Packet, error) {
 		return nil, errors.Wrapf(err, "only wrote %d bytes (packet had %d bytes)", wb, len(p))
 	}
 
+	switch c.opts.EchoHandling {
+	case EchoHandlingNone:
+		return c.NextPacket(ctx)
+	case EchoHandlingDiscard:
+		echo := make([]byte, len(p))
+		if err = c.readInFull(ctx, echo); err != nil {
+			return nil, errors.Wrap(err, "reading echoed packet")
+		}
+		return c.NextPacket(ctx)
+	}
+
 	sentCommand := p[PacketIndexCommand]
 	currentCommand := sentCommand
 	for currentCommand == sentCommand { // make sure we aren't reading back the packet we just sent
@@ -132,7 +281,8 @@ func (c *connection) NextPacket(ctx context.Context) (Packet, error) {
 }
 
 func (c *connection) nextPacket(ctx context.Context, attempt int) (Packet, error) {
-	if attempt > 5 {
+	if attempt > c.opts.MaxReadAttempts {
+		c.log.Warn("no valid packet could be read", "attempts", attempt-1)
 		return nil, fmt.Errorf("no valid packet could be read")
 	}
 
@@ -185,7 +335,8 @@ func (c *connection) nextPacket(ctx context.Context, attempt int) (Packet, error
 	checksum := packet[len(packet)-1]
 	calculatedChecksum := CalculateChecksum(packet)
 	if checksum != calculatedChecksum {
-		c.log.Debugf("invalid checksum. want: %x. got: %x.\n", calculatedChecksum, checksum)
+		c.log.Warn("invalid checksum",
+			"want", fmt.Sprintf("0x%x", calculatedChecksum), "got", fmt.Sprintf("0x%x", checksum))
 		return nil, ErrInvalidChecksumByte
 	}
 	return packet, nil
@@ -204,6 +355,7 @@ func (c *connection) readInFull(ctx context.Context, b []byte) error {
 		for readCount < len(b) {
 			if err := c.waitForNBytesToTransfer(ctx, len(b)-readCount); err != nil {
 				result <- readResult{readCount, err}
+				return
 			}
 
 			c.log.Debug("starting read")
@@ -225,7 +377,8 @@ func (c *connection) readInFull(ctx context.Context, b []byte) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
-	case <-time.NewTimer(ConnectionTotalReadTimeout).C:
+	case <-time.NewTimer(c.opts.TotalReadTimeout).C:
+		c.log.Warn("read timed out", "bytes", len(b), "timeout", c.opts.TotalReadTimeout)
 		return ErrReadTimeout
 	case r := <-result:
 		return r.err
@@ -236,8 +389,12 @@ func (c *connection) logger() Logger {
 	return c.log
 }
 
+func (c *connection) options() ConnectionOptions {
+	return c.opts
+}
+
 func (c *connection) Close() error {
-	c.log.Debug("closing connection")
+	c.log.Info("closing connection")
 
 	if c.serialPort != nil {
 		return c.serialPort.Close()
@@ -247,7 +404,11 @@ func (c *connection) Close() error {
 }
 
 func (c *connection) waitForNBytesToTransfer(ctx context.Context, n int) error {
-	ms := microsecondsOnTheWire(n)
+	if c.opts.WireTiming == WireTimingNone {
+		return nil
+	}
+
+	ms := microsecondsOnTheWire(n, c.opts.BaudRate)
 	c.log.Debugf("waiting %s for %d bytes\n", ms, n)
 	select {
 	case <-time.NewTimer(ms).C:
@@ -264,45 +425,8 @@ func (c *connection) waitForNBytesToTransfer(ctx context.Context, n int) error {
 // so, creating an equation...
 // baud rate in µs = 4800bits/1,000,000µs = 10bits*byteCount/xµs = wordSize*wordCount/x
 // x = (10*byteCount*1,000,000)/4800 µs = (byteCount*10,000,000)/4800 µs
-func microsecondsOnTheWire(byteCount int) time.Duration {
+func microsecondsOnTheWire(byteCount, baudRate int) time.Duration {
 	return time.Duration(int(math.Round(
-		float64(byteCount*10000000)/float64(ConnectionBaudRate),
+		float64(byteCount*10000000)/float64(baudRate),
 	))) * time.Microsecond
 }
-
-type Logger interface {
-	Debug(message string)
-	Debugf(message string, args ...interface{})
-}
-
-type nopLogger struct{}
-
-func (l nopLogger) Debug(message string) {}
-
-func (l nopLogger) Debugf(message string, args ...interface{}) {}
-
-var NopLogger Logger = nopLogger{}
-
-type defaultLogger struct {
-	l *log.Logger
-}
-
-func (l *defaultLogger) Debug(message string) {
-	l.l.Println(message)
-}
-
-func (l *defaultLogger) Debugf(message string, args ...interface{}) {
-	l.l.Printf(message, args...)
-}
-
-var DefaultLogger = func(out io.Writer) Logger {
-	return &defaultLogger{log.New(out, "SSM2 ", log.LstdFlags)}
-}
-
-func logBytes(l Logger, b []byte, prefix string) {
-	s := prefix
-	for _, bb := range b {
-		s += fmt.Sprintf("0x%x ", bb)
-	}
-	l.Debug(s)
-}
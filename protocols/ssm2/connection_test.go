@@ -166,6 +166,57 @@ func TestSendingPacket(t *testing.T) {
 	})
 }
 
+func TestEchoHandling(t *testing.T) {
+	initResponse := func() []byte {
+		resp := []byte{
+			ssm2.PacketMagicByte, ssm2.DeviceDiagnosticTool, ssm2.DeviceEngine,
+			0x01, ssm2.CommandInitResponse,
+		}
+		return append(resp, calculateChecksum(resp))
+	}
+
+	t.Run("DiscardReadsTheEcho", func(t *testing.T) {
+		port := newTestSerialPort()
+		port.out = bytes.NewBuffer(append(append([]byte{}, initRequestPacket...), initResponse()...))
+
+		opts := ssm2.DefaultConnectionOptions()
+		opts.EchoHandling = ssm2.EchoHandlingDiscard
+		opts.WireTiming = ssm2.WireTimingNone
+		conn := ssm2.NewConnectionWithOptions(port, nil, opts)
+
+		if _, err := conn.InitECU(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if port.out.Len() != 0 {
+			t.Fatalf("expected buffer to have been read in full. %d bytes remain.", port.out.Len())
+		}
+	})
+
+	t.Run("NoneExpectsTheResponseFirst", func(t *testing.T) {
+		port := newTestSerialPort()
+		port.out = bytes.NewBuffer(append(append([]byte{}, initRequestPacket...), initResponse()...))
+
+		opts := ssm2.DefaultConnectionOptions()
+		opts.EchoHandling = ssm2.EchoHandlingNone
+		conn := ssm2.NewConnectionWithOptions(port, nil, opts)
+
+		_, err := conn.InitECU(context.Background())
+		if !errors.Is(err, ssm2.ErrInvalidResponseCommand) {
+			t.Fatalf("want ErrInvalidResponseCommand (%v). got: %v.", ssm2.ErrInvalidResponseCommand, err)
+		}
+	})
+}
+
+func TestConnectionOptions_WithDefaults(t *testing.T) {
+	got := ssm2.ConnectionOptions{BaudRate: 9600, EchoHandling: "bogus"}.WithDefaults()
+
+	want := ssm2.DefaultConnectionOptions()
+	want.BaudRate = 9600
+	if got != want {
+		t.Fatalf("ConnectionOptions.WithDefaults() = %+v, want %+v", got, want)
+	}
+}
+
 func TestInitECU(t *testing.T) {
 	t.Run("ValidRequest", func(t *testing.T) {
 		port := newTestSerialPort()
@@ -241,6 +292,9 @@ func TestInitECU(t *testing.T) {
 		if !bytes.Equal(ecu.ROM_ID, romID) {
 			t.Fatalf("invalid ROM_ID. want: %x. got: %x.", romID, ecu.ROM_ID)
 		}
+		if want := []byte{0b00000001, 0b00010000}; !bytes.Equal(ecu.Capabilities, want) {
+			t.Fatalf("invalid Capabilities. want: %08b. got: %08b.", want, ecu.Capabilities)
+		}
 
 		if len(ecu.SupportedParameters) != 5 {
 			t.Fatalf("expected 5 supported params (P8, P12, P239, P240, P241). got: %d (%v).", len(ecu.SupportedParameters), ecu.SupportedParameters)
@@ -260,11 +314,20 @@ func TestInitECU(t *testing.T) {
 			}
 		}
 
-		if len(ecu.SupportedDerivedParameters) != 1 {
-			t.Fatalf("expected 1 supported derived param. got: %d.", len(ecu.SupportedDerivedParameters))
+		if len(ecu.SupportedDerivedParameters) != 2 {
+			t.Fatalf("expected 2 supported derived params (P200, P246). got: %d.", len(ecu.SupportedDerivedParameters))
 		}
-		if ecu.SupportedDerivedParameters[0].Id != "P200" {
-			t.Fatal("expected P200 derived param to be supported")
+		for _, want := range []string{"P200", "P246"} {
+			supported := false
+			for _, p := range ecu.SupportedDerivedParameters {
+				if p.Id == want {
+					supported = true
+					break
+				}
+			}
+			if !supported {
+				t.Fatalf("expected %s derived param to be supported", want)
+			}
 		}
 	})
 }
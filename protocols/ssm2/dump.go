@@ -0,0 +1,80 @@
+package ssm2
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// DumpOptions describes the range of memory read by Dump.
+type DumpOptions struct {
+	// Start is the first address of the range.
+	Start [3]byte
+	// Length is the number of bytes in the range.
+	Length int
+	// Offset is the number of bytes at the start of the range that were already
+	// dumped. Dumping resumes at the byte after them.
+	Offset int
+	// ChunkSize is the number of bytes read by each request. The default is MaxReadBlockLength.
+	ChunkSize int
+	// Retries is the number of times a chunk is read again after failing.
+	Retries int
+	// Progress is optionally called with the number of bytes dumped after each chunk,
+	// including the bytes before the offset.
+	Progress func(dumped int)
+}
+
+// Dump reads the range of memory in chunks and writes each chunk to w as it's read,
+// so a dump that's interrupted can be resumed from its offset. It returns the number
+// of bytes dumped, including the bytes before the offset.
+func Dump(ctx context.Context, conn Connection, w io.Writer, opts DumpOptions) (int, error) {
+	if opts.ChunkSize == 0 {
+		opts.ChunkSize = MaxReadBlockLength
+	}
+	switch {
+	case opts.Length < 1:
+		return opts.Offset, errors.New("the range must be at least 1 byte")
+	case addressValue(opts.Start)+uint32(opts.Length) > 0x1000000:
+		return opts.Offset, errors.New("the range goes past the last address")
+	case opts.Offset < 0 || opts.Offset > opts.Length:
+		return opts.Offset, fmt.Errorf("invalid offset %d: it must be from 0 to the length", opts.Offset)
+	case opts.ChunkSize < 1 || opts.ChunkSize > MaxReadBlockLength:
+		return opts.Offset, fmt.Errorf("invalid chunk size %d: it must be from 1 to %d bytes", opts.ChunkSize, MaxReadBlockLength)
+	case opts.Retries < 0:
+		return opts.Offset, errors.New("the number of retries can't be negative")
+	}
+
+	start := Address{Address: opts.Start}
+	dumped := opts.Offset
+	for dumped < opts.Length {
+		address := start.Add(uint32(dumped))
+		length := opts.Length - dumped
+		if length > opts.ChunkSize {
+			length = opts.ChunkSize
+		}
+
+		var chunk []byte
+		var err error
+		for attempt := 0; attempt <= opts.Retries; attempt++ {
+			if chunk, err = conn.ReadBlock(ctx, address, length); err == nil || ctx.Err() != nil {
+				break
+			}
+			conn.logger().Warn("reading chunk failed", "address", fmt.Sprintf("%X", address[:]),
+				"attempt", attempt+1, "error", err)
+		}
+		if err != nil {
+			return dumped, errors.Wrapf(err, "reading %d bytes at 0x%X", length, address[:])
+		}
+
+		if _, err = w.Write(chunk); err != nil {
+			return dumped, errors.Wrapf(err, "writing chunk at 0x%X", address[:])
+		}
+		dumped += length
+		if opts.Progress != nil {
+			opts.Progress(dumped)
+		}
+	}
+	return dumped, nil
+}
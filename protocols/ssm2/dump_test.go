@@ -0,0 +1,90 @@
+package ssm2_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+)
+
+func TestDump(t *testing.T) {
+	image := make([]byte, 0x90)
+	for i := range image {
+		image[i] = byte(i)
+	}
+	failed := responsePacket(ssm2.CommandReadAddressesResponse, nil)
+
+	t.Run("RetriesChunks", func(t *testing.T) {
+		port := newTestSerialPort()
+		port.out = bytes.NewBuffer(bytes.Join([][]byte{
+			responsePacket(ssm2.CommandReadBlockResponse, image[:0x80]),
+			failed,
+			responsePacket(ssm2.CommandReadBlockResponse, image[0x80:]),
+		}, nil))
+
+		var out bytes.Buffer
+		var progress []int
+		n, err := ssm2.Dump(context.Background(), ssm2.NewConnection(port, nil), &out, ssm2.DumpOptions{
+			Start: [3]byte{0xFF, 0x00, 0x00}, Length: len(image), Retries: 1,
+			Progress: func(dumped int) { progress = append(progress, dumped) },
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != len(image) || !bytes.Equal(out.Bytes(), image) {
+			t.Fatalf("want the image dumped. got %d bytes: % X", n, out.Bytes())
+		}
+		if len(progress) != 2 || progress[0] != 0x80 || progress[1] != 0x90 {
+			t.Fatalf("want progress after each chunk. got: %v", progress)
+		}
+	})
+
+	t.Run("ResumesFromOffset", func(t *testing.T) {
+		port := newTestSerialPort()
+		port.out = bytes.NewBuffer(responsePacket(ssm2.CommandReadBlockResponse, image[0x80:]))
+
+		var out bytes.Buffer
+		n, err := ssm2.Dump(context.Background(), ssm2.NewConnection(port, nil), &out, ssm2.DumpOptions{
+			Start: [3]byte{0xFF, 0x00, 0x00}, Length: len(image), Offset: 0x80,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != len(image) || !bytes.Equal(out.Bytes(), image[0x80:]) {
+			t.Fatalf("want the rest of the image dumped. got %d bytes: % X", n, out.Bytes())
+		}
+		if want := []byte{ssm2.CommandReadBlockRequest, 0x00, 0xFF, 0x00, 0x80, 0x0F}; !bytes.Contains(port.in.Bytes(), want) {
+			t.Fatalf("want a request for the rest of the image. got: % X", port.in.Bytes())
+		}
+	})
+
+	t.Run("ReturnsDumpedBytesOnFailure", func(t *testing.T) {
+		port := newTestSerialPort()
+		port.out = bytes.NewBuffer(append(responsePacket(ssm2.CommandReadBlockResponse, image[:0x80]), failed...))
+
+		var out bytes.Buffer
+		n, err := ssm2.Dump(context.Background(), ssm2.NewConnection(port, nil), &out, ssm2.DumpOptions{
+			Start: [3]byte{0xFF, 0x00, 0x00}, Length: len(image),
+		})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if n != 0x80 || out.Len() != 0x80 {
+			t.Fatalf("want the first chunk dumped. got %d bytes", n)
+		}
+	})
+
+	t.Run("ValidatesOptions", func(t *testing.T) {
+		for _, opts := range []ssm2.DumpOptions{
+			{Start: [3]byte{0xFF, 0x00, 0x00}},
+			{Start: [3]byte{0xFF, 0xFF, 0xFF}, Length: 2},
+			{Start: [3]byte{0xFF, 0x00, 0x00}, Length: 1, Offset: 2},
+			{Start: [3]byte{0xFF, 0x00, 0x00}, Length: 1, ChunkSize: ssm2.MaxReadBlockLength + 1},
+		} {
+			if _, err := ssm2.Dump(context.Background(), ssm2.NewFakeConnection(0), &bytes.Buffer{}, opts); err == nil {
+				t.Fatalf("expected an error for %+v", opts)
+			}
+		}
+	})
+}
@@ -2,6 +2,7 @@ package ssm2
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"time"
 )
@@ -23,15 +24,24 @@ func NewFakeConnection(latency time.Duration) Connection {
 
 // InitECU returns fake ECU data with all supported parameters.
 func (c *fakeConnection) InitECU(ctx context.Context) (*ECU, error) {
-	params := make([]Parameter, len(Parameters))
+	catalog, derivedCatalog := Catalog()
+	params := make([]Parameter, len(catalog))
+	var capabilities []byte
 	i := 0
-	for _, p := range Parameters {
+	for _, p := range catalog {
 		params[i] = p
 		i++
+		if p.Custom {
+			continue
+		}
+		for uint(len(capabilities)) <= p.CapabilityByteIndex-CapabilityByteOffset {
+			capabilities = append(capabilities, 0)
+		}
+		capabilities[p.CapabilityByteIndex-CapabilityByteOffset] |= 1 << p.CapabilityBitIndex
 	}
 	i = 0
-	derivedParams := make([]DerivedParameter, len(DerivedParameters))
-	for _, p := range DerivedParameters {
+	derivedParams := make([]DerivedParameter, len(derivedCatalog))
+	for _, p := range derivedCatalog {
 		derivedParams[i] = p
 		i++
 	}
@@ -39,6 +49,7 @@ func (c *fakeConnection) InitECU(ctx context.Context) (*ECU, error) {
 	return &ECU{
 		SSM_ID:                     []byte{0x00, 0x00, 0x01},
 		ROM_ID:                     []byte{0x00, 0x00, 0x00, 0x00, 0x01},
+		Capabilities:               capabilities,
 		SupportedParameters:        params,
 		SupportedDerivedParameters: derivedParams,
 	}, nil
@@ -55,6 +66,31 @@ func (c *fakeConnection) SendReadAddressesRequest(ctx context.Context, addresses
 	return c.addressResponsePacket(), nil
 }
 
+// ReadBlock waits for the connection's latency and then returns fake data. Each
+// address has a base value, and every 16th address changes randomly.
+func (c *fakeConnection) ReadBlock(ctx context.Context, address [3]byte, length int) ([]byte, error) {
+	if length < 1 || length > MaxReadBlockLength {
+		return nil, fmt.Errorf("invalid block length %d: it must be from 1 to %d bytes", length, MaxReadBlockLength)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(c.latency):
+	}
+
+	start := uint32(address[0])<<16 | uint32(address[1])<<8 | uint32(address[2])
+	data := make([]byte, length)
+	for i := range data {
+		a := start + uint32(i)
+		data[i] = byte(a * 7)
+		if a%16 == 0 {
+			data[i] = byte(rand.Intn(256))
+		}
+	}
+	return data, nil
+}
+
 // NextPacket waits for the connection's latency and then returns
 // a packet.
 func (c *fakeConnection) NextPacket(ctx context.Context) (Packet, error) {
@@ -74,6 +110,10 @@ func (c *fakeConnection) logger() Logger {
 	return NopLogger
 }
 
+func (c *fakeConnection) options() ConnectionOptions {
+	return DefaultConnectionOptions()
+}
+
 func (c *fakeConnection) addressResponsePacket() Packet {
 	resp := make(Packet, PacketHeaderSize+c.addresses+1)
 	resp[0] = PacketMagicByte
@@ -0,0 +1,85 @@
+package ssm2
+
+import (
+	"math"
+	"strconv"
+)
+
+// ValueKind is the kind of a parameter's values, which determines how they're displayed.
+type ValueKind int
+
+const (
+	// Float values are displayed with the parameter's precision.
+	Float ValueKind = iota
+	// Integer values are displayed rounded to a whole number.
+	Integer
+	// Enum values are displayed as their label, e.g. "3rd" for a gear.
+	Enum
+)
+
+// DefaultPrecision is the number of decimals float values are displayed with
+// when their parameter doesn't set a precision.
+const DefaultPrecision = 2
+
+// Format describes how a parameter's values are displayed. The zero value
+// displays floats with DefaultPrecision.
+type Format struct {
+	Kind ValueKind
+
+	// Precision is the number of decimals float values are displayed with.
+	// DefaultPrecision is used when it's 0; use Integer for whole numbers.
+	Precision int
+
+	// Labels maps enum values to their labels. Values without a label are displayed as integers.
+	Labels map[int]string
+}
+
+// Decimals returns the number of decimals the format displays.
+func (f Format) Decimals() int {
+	switch {
+	case f.Kind != Float:
+		return 0
+	case f.Precision > 0:
+		return f.Precision
+	default:
+		return DefaultPrecision
+	}
+}
+
+// Label returns the label for the value if the format is an enum with a label for it.
+func (f Format) Label(v float32) (string, bool) {
+	if f.Kind != Enum {
+		return "", false
+	}
+	l, ok := f.Labels[int(math.Round(float64(v)))]
+	return l, ok
+}
+
+// String returns the value formatted for display.
+func (f Format) String(v float32) string {
+	if l, ok := f.Label(v); ok {
+		return l
+	}
+	return strconv.FormatFloat(float64(v), 'f', f.Decimals(), 32)
+}
+
+// gearLabels are the labels for gear positions.
+var gearLabels = map[int]string{
+	1: "1st",
+	2: "2nd",
+	3: "3rd",
+	4: "4th",
+	5: "5th",
+	6: "6th",
+}
+
+// calculatedGearLabels are the labels for calculated gear positions, which are 0 when stopped.
+var calculatedGearLabels = map[int]string{
+	0: "Stopped",
+	1: "1st",
+	2: "2nd",
+	3: "3rd",
+	4: "4th",
+	5: "5th",
+	6: "6th",
+}
@@ -0,0 +1,40 @@
+package ssm2_test
+
+import (
+	"testing"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+)
+
+func TestFormat_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		format ssm2.Format
+		value  float32
+		want   string
+	}{
+		{"default precision", ssm2.Format{}, 14.7345, "14.73"},
+		{"precision", ssm2.Format{Precision: 3}, 0.51234, "0.512"},
+		{"integer", ssm2.Format{Kind: ssm2.Integer}, 2999.75, "3000"},
+		{"enum label", ssm2.Parameters["P60"].Format, 3, "3rd"},
+		{"enum without label", ssm2.Parameters["P60"].Format, 9, "9"},
+		{"calculated gear stopped", ssm2.DerivedParameters["P254"].Format, 0, "Stopped"},
+		{"lift mode", ssm2.Parameters["P127"].Format, 1, "High lift"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.format.String(tt.value); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat_Label(t *testing.T) {
+	if _, ok := (ssm2.Format{Kind: ssm2.Integer, Labels: map[int]string{1: "On"}}).Label(1); ok {
+		t.Fatal("want labels only used by enums")
+	}
+	if l, ok := ssm2.Parameters["P185"].Format.Label(2); !ok || l != "Low" {
+		t.Fatalf("want the Low label. got: %q, %v", l, ok)
+	}
+}
@@ -0,0 +1,189 @@
+package ssm2
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Level is the severity of a logged message.
+type Level int
+
+const (
+	// LevelDebug is used for protocol detail like the bytes read and written.
+	LevelDebug Level = iota
+	// LevelInfo is used for notable events like a connection being opened.
+	LevelInfo
+	// LevelWarn is used for problems that were recovered from, like an invalid packet.
+	LevelWarn
+	// LevelError is used for problems that stop an operation, like a lost port.
+	LevelError
+)
+
+// Levels lists the valid Level values from least to most severe.
+var Levels = []Level{LevelDebug, LevelInfo, LevelWarn, LevelError}
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
+
+// SlogLevel returns the equivalent slog.Level.
+func (l Level) SlogLevel() slog.Level {
+	switch l {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelInfo:
+		return slog.LevelInfo
+	case LevelWarn:
+		return slog.LevelWarn
+	}
+	return slog.LevelError
+}
+
+// ParseLevel parses a Level from its name, ignoring case.
+func ParseLevel(s string) (Level, error) {
+	for _, l := range Levels {
+		if strings.EqualFold(s, l.String()) {
+			return l, nil
+		}
+	}
+	return LevelDebug, errors.Errorf("invalid log level '%s'", s)
+}
+
+// Logger is used to log messages at different levels. Messages may be
+// followed by alternating keys and values that add context to the message,
+// in the same style as log/slog.
+type Logger interface {
+	Debug(message string, keyvals ...interface{})
+	Debugf(message string, args ...interface{})
+	Info(message string, keyvals ...interface{})
+	Warn(message string, keyvals ...interface{})
+	Error(message string, keyvals ...interface{})
+}
+
+type nopLogger struct{}
+
+func (l nopLogger) Debug(message string, keyvals ...interface{}) {}
+
+func (l nopLogger) Debugf(message string, args ...interface{}) {}
+
+func (l nopLogger) Info(message string, keyvals ...interface{}) {}
+
+func (l nopLogger) Warn(message string, keyvals ...interface{}) {}
+
+func (l nopLogger) Error(message string, keyvals ...interface{}) {}
+
+var NopLogger Logger = nopLogger{}
+
+type defaultLogger struct {
+	l     *log.Logger
+	level Level
+}
+
+func (l *defaultLogger) Debug(message string, keyvals ...interface{}) {
+	l.log(LevelDebug, message, keyvals)
+}
+
+func (l *defaultLogger) Debugf(message string, args ...interface{}) {
+	l.log(LevelDebug, fmt.Sprintf(message, args...), nil)
+}
+
+func (l *defaultLogger) Info(message string, keyvals ...interface{}) {
+	l.log(LevelInfo, message, keyvals)
+}
+
+func (l *defaultLogger) Warn(message string, keyvals ...interface{}) {
+	l.log(LevelWarn, message, keyvals)
+}
+
+func (l *defaultLogger) Error(message string, keyvals ...interface{}) {
+	l.log(LevelError, message, keyvals)
+}
+
+func (l *defaultLogger) log(level Level, message string, keyvals []interface{}) {
+	if level < l.level {
+		return
+	}
+	l.l.Println(level.String() + " " + strings.TrimSuffix(message, "\n") + formatKeyvals(keyvals))
+}
+
+// formatKeyvals formats the key/value pairs as ' key=value key2=value2'.
+// A trailing key without a value is logged with a missing value.
+func formatKeyvals(keyvals []interface{}) string {
+	var sb strings.Builder
+	for i := 0; i < len(keyvals); i += 2 {
+		var val interface{} = "<missing>"
+		if i+1 < len(keyvals) {
+			val = keyvals[i+1]
+		}
+
+		v := fmt.Sprint(val)
+		if strings.ContainsAny(v, " \t\"=") {
+			v = fmt.Sprintf("%q", v)
+		}
+		fmt.Fprintf(&sb, " %v=%s", keyvals[i], v)
+	}
+	return sb.String()
+}
+
+// DefaultLogger returns a Logger that writes all levels to out.
+var DefaultLogger = func(out io.Writer) Logger {
+	return NewLeveledLogger(out, LevelDebug)
+}
+
+// NewLeveledLogger returns a Logger that writes messages at or above
+// the given level to out.
+func NewLeveledLogger(out io.Writer, level Level) Logger {
+	return &defaultLogger{log.New(out, "SSM2 ", log.LstdFlags), level}
+}
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// NewSlogLogger returns a Logger that writes to the given *slog.Logger.
+func NewSlogLogger(l *slog.Logger) Logger {
+	return slogLogger{l}
+}
+
+func (l slogLogger) Debug(message string, keyvals ...interface{}) {
+	l.l.Debug(message, keyvals...)
+}
+
+func (l slogLogger) Debugf(message string, args ...interface{}) {
+	l.l.Debug(strings.TrimSuffix(fmt.Sprintf(message, args...), "\n"))
+}
+
+func (l slogLogger) Info(message string, keyvals ...interface{}) {
+	l.l.Info(message, keyvals...)
+}
+
+func (l slogLogger) Warn(message string, keyvals ...interface{}) {
+	l.l.Warn(message, keyvals...)
+}
+
+func (l slogLogger) Error(message string, keyvals ...interface{}) {
+	l.l.Error(message, keyvals...)
+}
+
+func logBytes(l Logger, b []byte, prefix string) {
+	s := prefix
+	for _, bb := range b {
+		s += fmt.Sprintf("0x%x ", bb)
+	}
+	l.Debug(s)
+}
@@ -0,0 +1,60 @@
+package ssm2_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+)
+
+func TestNewLeveledLogger(t *testing.T) {
+	out := &bytes.Buffer{}
+	l := ssm2.NewLeveledLogger(out, ssm2.LevelWarn)
+
+	l.Debug("read: 0x80")
+	l.Info("opening serial port", "port", "/dev/ttyUSB0")
+	l.Warn("invalid checksum", "want", "0x2f", "got", "0x30")
+	l.Error("port lost", "error", "device not configured")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("want 2 lines logged at or above WARN. got: %q", lines)
+	}
+	if !strings.HasSuffix(lines[0], "WARN invalid checksum want=0x2f got=0x30") {
+		t.Fatalf("unexpected warn line: %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], `ERROR port lost error="device not configured"`) {
+		t.Fatalf("unexpected error line: %q", lines[1])
+	}
+}
+
+func TestNewSlogLogger(t *testing.T) {
+	out := &bytes.Buffer{}
+	l := ssm2.NewSlogLogger(slog.New(slog.NewTextHandler(out,
+		&slog.HandlerOptions{Level: ssm2.LevelInfo.SlogLevel()})))
+
+	l.Debugf("reading %d header bytes\n", 5)
+	l.Info("initialized", "romID", "1040a132b1")
+
+	got := out.String()
+	if strings.Contains(got, "header bytes") {
+		t.Fatalf("debug message should have been filtered: %q", got)
+	}
+	if !strings.Contains(got, "level=INFO msg=initialized romID=1040a132b1") {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, l := range ssm2.Levels {
+		got, err := ssm2.ParseLevel(strings.ToLower(l.String()))
+		if err != nil || got != l {
+			t.Fatalf("ParseLevel(%q) = %v, %v. want %v.", strings.ToLower(l.String()), got, err, l)
+		}
+	}
+	if _, err := ssm2.ParseLevel("verbose"); err == nil {
+		t.Fatal("expected an error for an invalid level")
+	}
+}
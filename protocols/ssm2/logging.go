@@ -2,6 +2,7 @@ package ssm2
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -9,28 +10,73 @@ import (
 // LoggingSession sends a continuous ReadAddressesRequest for the given parameters
 // and then reads the response packets until the context is canceled. The results
 // are sent on the returned channel, and the channel is closed when the context
-// is canceled or too many consecutive errors are encountered during processing.
+// is canceled or the connection's MaxConsecutiveErrors is reached during processing.
+// Derived parameters are calculated using the DefaultVehicle.
 func LoggingSession(ctx context.Context, conn Connection, params []Parameter,
 	derived []DerivedParameter) (<-chan map[string]ParameterValue, error) {
+	return LoggingSessionForVehicle(ctx, conn, params, derived, DefaultVehicle())
+}
+
+// LoggingSessionForVehicle is like LoggingSession, but derived parameters are
+// calculated using the constants from the given vehicle.
+//
+// The parameters the derived parameters depend on are read even when they aren't
+// in params, and the derived parameters are calculated after their dependencies.
+func LoggingSessionForVehicle(ctx context.Context, conn Connection, params []Parameter,
+	derived []DerivedParameter, v Vehicle) (<-chan map[string]ParameterValue, error) {
+	derived, dependencies, err := ResolveDerivedParameters(derived)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving derived parameters")
+	}
+	params = appendMissingParameters(params, dependencies)
+
 	addressesToRead := [][3]byte{}
 	for _, param := range params {
 		for i := 0; i < param.Address.Length; i++ {
 			addressesToRead = append(addressesToRead, param.Address.Add(uint32(i)))
 		}
 	}
+	if len(addressesToRead) > MaxReadAddresses {
+		return nil, ErrTooManyAddresses
+	}
 
-	_, err := conn.SendReadAddressesRequest(ctx, addressesToRead, true)
+	_, err = conn.SendReadAddressesRequest(ctx, addressesToRead, true)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending read addresses request")
 	}
 
 	results := make(chan map[string]ParameterValue, 10)
-	go processPackets(ctx, results, conn, params, derived)
+	go processPackets(ctx, results, conn, params, derived, v.WithDefaults())
 	return results, nil
 }
 
+// appendMissingParameters appends the parameters in add that aren't already in params.
+func appendMissingParameters(params, add []Parameter) []Parameter {
+	ids := make(map[string]bool, len(params))
+	for _, p := range params {
+		ids[p.Id] = true
+	}
+
+	out := append([]Parameter{}, params...)
+	for _, p := range add {
+		if !ids[p.Id] {
+			ids[p.Id] = true
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
 func processPackets(ctx context.Context, results chan<- map[string]ParameterValue,
-	conn Connection, params []Parameter, derived []DerivedParameter) {
+	conn Connection, params []Parameter, derived []DerivedParameter, v Vehicle) {
+	// stateful parameters keep their state for the whole session
+	calculators := make(map[string]Calculator)
+	for _, p := range derived {
+		if p.Stateful() {
+			calculators[p.Id] = p.NewCalculator()
+		}
+	}
+
 	errCount := 0
 	for {
 		select {
@@ -40,15 +86,17 @@ func processPackets(ctx context.Context, results chan<- map[string]ParameterValu
 		default:
 			packet, err := conn.NextPacket(ctx)
 			if err != nil {
-				conn.logger().Debug(err.Error())
 				errCount++
-				if errCount == 3 {
+				conn.logger().Warn("reading logging packet", "error", err, "consecutiveErrors", errCount)
+				if errCount >= conn.options().MaxConsecutiveErrors {
+					conn.logger().Error("closing logging session after too many consecutive errors", "errors", errCount)
 					close(results)
 					return
 				}
 				continue
 			}
 			errCount = 0
+			t := time.Now()
 
 			data := packet.Data()
 			addrIndex := 0
@@ -58,9 +106,20 @@ func processPackets(ctx context.Context, results chan<- map[string]ParameterValu
 				addrIndex += param.Address.Length
 			}
 			for _, param := range derived {
-				val, err := param.Value(values)
+				// a dependency is missing when its own calculation failed
+				if id, ok := missingDependency(param, values); ok {
+					conn.logger().Warn("skipping derived parameter with a missing dependency", "id", param.Id, "dependency", id)
+					continue
+				}
+
+				var val *ParameterValue
+				if c, ok := calculators[param.Id]; ok {
+					val, err = c.Next(t, values, v)
+				} else {
+					val, err = param.Value(values, v)
+				}
 				if err != nil {
-					conn.logger().Debugf("getting value from %s: %v\n", param.Id, err)
+					conn.logger().Warn("calculating derived parameter", "id", param.Id, "error", err)
 					continue
 				}
 
@@ -71,3 +130,13 @@ func processPackets(ctx context.Context, results chan<- map[string]ParameterValu
 		}
 	}
 }
+
+// missingDependency returns the ID of the first dependency of p that doesn't have a value.
+func missingDependency(p DerivedParameter, values map[string]ParameterValue) (string, bool) {
+	for _, id := range p.DependsOnParameters {
+		if _, ok := values[id]; !ok {
+			return id, true
+		}
+	}
+	return "", false
+}
@@ -36,3 +36,34 @@ func TestLoggingSession(t *testing.T) {
 		}
 	}
 }
+
+func TestLoggingSession_DerivedDependencies(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	session, err := ssm2.LoggingSession(ctx, ssm2.NewFakeConnection(time.Millisecond),
+		nil, []ssm2.DerivedParameter{ssm2.DerivedParameters["P242"]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := <-session
+	for _, id := range []string{"P7", "P8", "P11", "P12", "P200", "P242"} {
+		if _, ok := values[id]; !ok {
+			t.Fatalf("want a value for %s. got: %v", id, values)
+		}
+	}
+}
+
+func TestLoggingSession_TooManyAddresses(t *testing.T) {
+	params := []ssm2.Parameter{}
+	for _, p := range ssm2.Parameters {
+		if p.Address != nil {
+			params = append(params, p)
+		}
+	}
+
+	_, err := ssm2.LoggingSession(context.Background(), ssm2.NewFakeConnection(time.Millisecond), params, nil)
+	if err != ssm2.ErrTooManyAddresses {
+		t.Fatalf("want ErrTooManyAddresses. got: %v", err)
+	}
+}
@@ -0,0 +1,267 @@
+package ssm2
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// ScanFilter narrows down a Scanner's candidates by their values.
+type ScanFilter string
+
+const (
+	// ScanChanged keeps the candidates whose value changed since the previous snapshot.
+	ScanChanged ScanFilter = "changed"
+	// ScanUnchanged keeps the candidates whose value didn't change since the previous snapshot.
+	ScanUnchanged ScanFilter = "unchanged"
+	// ScanIncreased keeps the candidates whose value increased since the previous snapshot.
+	ScanIncreased ScanFilter = "increased"
+	// ScanDecreased keeps the candidates whose value decreased since the previous snapshot.
+	ScanDecreased ScanFilter = "decreased"
+	// ScanEqual keeps the candidates whose value in the latest snapshot equals the filter's value.
+	ScanEqual ScanFilter = "equal"
+	// ScanCorrelated keeps the candidates whose values across the snapshots are correlated
+	// with the reference parameter's values. The filter's value is the minimum absolute
+	// correlation coefficient, from 0 to 1.
+	ScanCorrelated ScanFilter = "correlated"
+)
+
+// ScanFilters lists the valid ScanFilter values.
+var ScanFilters = []ScanFilter{ScanChanged, ScanUnchanged, ScanIncreased, ScanDecreased, ScanEqual, ScanCorrelated}
+
+// ScanOptions describes the range of RAM a Scanner snapshots and how its values are read.
+type ScanOptions struct {
+	// Start is the first address of the range.
+	Start [3]byte
+	// Length is the number of bytes in the range.
+	Length int
+	// Width is the number of bytes in each value: 1, 2, or 4. The values are aligned to the width from Start.
+	Width int
+	// LittleEndian is set when each value's least significant byte comes first.
+	LittleEndian bool
+	// Signed is set when the values are two's complement numbers.
+	Signed bool
+	// Reference is read with each snapshot to correlate the candidates' values with,
+	// e.g. engine speed. It's optional unless the ScanCorrelated filter is used.
+	Reference *Parameter
+}
+
+// ScanCandidate is an address whose values have matched every filter.
+type ScanCandidate struct {
+	Address [3]byte
+	// Values are the candidate's value in each snapshot.
+	Values []float64
+}
+
+// Value returns the candidate's value in the latest snapshot.
+func (c ScanCandidate) Value() float64 {
+	if len(c.Values) == 0 {
+		return 0
+	}
+	return c.Values[len(c.Values)-1]
+}
+
+// Scanner finds the RAM addresses of unknown values, e.g. a flag that flips with the A/C.
+// It snapshots the values in a range of RAM and narrows down the candidate addresses
+// with filters comparing their values.
+type Scanner struct {
+	conn       Connection
+	opts       ScanOptions
+	candidates []ScanCandidate
+	// references are the reference parameter's value in each snapshot.
+	references []float64
+	snapshots  int
+}
+
+// NewScanner returns a Scanner with every value in the range as a candidate.
+func NewScanner(conn Connection, opts ScanOptions) (*Scanner, error) {
+	switch opts.Width {
+	case 1, 2, 4:
+	default:
+		return nil, fmt.Errorf("invalid width %d: it must be 1, 2, or 4 bytes", opts.Width)
+	}
+	if opts.Length < opts.Width {
+		return nil, fmt.Errorf("the range must be at least %d byte(s)", opts.Width)
+	}
+	start := Address{Address: opts.Start}
+	if addressValue(opts.Start)+uint32(opts.Length) > 0x1000000 {
+		return nil, errors.New("the range goes past the last address")
+	}
+	if opts.Reference != nil && opts.Reference.Address == nil {
+		return nil, fmt.Errorf("the reference parameter %s isn't read from an address", opts.Reference.Id)
+	}
+
+	s := &Scanner{conn: conn, opts: opts}
+	for i := 0; i+opts.Width <= opts.Length; i += opts.Width {
+		s.candidates = append(s.candidates, ScanCandidate{Address: start.Add(uint32(i))})
+	}
+	return s, nil
+}
+
+// Candidates returns the candidates that have matched every filter, ordered by address.
+func (s *Scanner) Candidates() []ScanCandidate {
+	return s.candidates
+}
+
+// Snapshots returns the number of snapshots taken.
+func (s *Scanner) Snapshots() int {
+	return s.snapshots
+}
+
+// References returns the reference parameter's value in each snapshot.
+func (s *Scanner) References() []float64 {
+	return s.references
+}
+
+// Snapshot reads the candidates' values and the reference parameter's value. Only
+// the blocks of RAM containing the candidates are read, so snapshots get faster
+// as the candidates are narrowed down.
+func (s *Scanner) Snapshot(ctx context.Context) error {
+	values := make([]float64, 0, len(s.candidates))
+	for i := 0; i < len(s.candidates); {
+		// read the following candidates that fit in a block along with this one
+		first := addressValue(s.candidates[i].Address)
+		j := i + 1
+		for j < len(s.candidates) && addressValue(s.candidates[j].Address)+uint32(s.opts.Width)-first <= MaxReadBlockLength {
+			j++
+		}
+		length := int(addressValue(s.candidates[j-1].Address) + uint32(s.opts.Width) - first)
+
+		block, err := s.conn.ReadBlock(ctx, s.candidates[i].Address, length)
+		if err != nil {
+			return errors.Wrapf(err, "reading block at 0x%X", s.candidates[i].Address[:])
+		}
+		for ; i < j; i++ {
+			offset := int(addressValue(s.candidates[i].Address) - first)
+			values = append(values, s.decode(block[offset:offset+s.opts.Width]))
+		}
+	}
+
+	var ref float64
+	if p := s.opts.Reference; p != nil {
+		addresses := make([][3]byte, p.Address.Length)
+		for i := range addresses {
+			addresses[i] = p.Address.Add(uint32(i))
+		}
+		packet, err := s.conn.SendReadAddressesRequest(ctx, addresses, false)
+		if err != nil {
+			return errors.Wrapf(err, "reading the reference parameter %s", p.Id)
+		}
+		if len(packet.Data()) < p.Address.Length {
+			return fmt.Errorf("read %d bytes of the reference parameter %s instead of %d", len(packet.Data()), p.Id, p.Address.Length)
+		}
+		ref = float64(p.Value(packet.Data()[:p.Address.Length]).Value)
+	}
+
+	for i, v := range values {
+		s.candidates[i].Values = append(s.candidates[i].Values, v)
+	}
+	if s.opts.Reference != nil {
+		s.references = append(s.references, ref)
+	}
+	s.snapshots++
+	return nil
+}
+
+// Filter removes the candidates that don't match the filter. The value is used
+// by the ScanEqual and ScanCorrelated filters.
+func (s *Scanner) Filter(f ScanFilter, value float64) error {
+	var keep func(c ScanCandidate) bool
+	switch f {
+	case ScanChanged, ScanUnchanged, ScanIncreased, ScanDecreased:
+		if s.snapshots < 2 {
+			return fmt.Errorf("the %s filter needs at least 2 snapshots", f)
+		}
+		keep = func(c ScanCandidate) bool {
+			prev, cur := c.Values[len(c.Values)-2], c.Values[len(c.Values)-1]
+			switch f {
+			case ScanChanged:
+				return cur != prev
+			case ScanUnchanged:
+				return cur == prev
+			case ScanIncreased:
+				return cur > prev
+			}
+			return cur < prev
+		}
+	case ScanEqual:
+		if s.snapshots < 1 {
+			return fmt.Errorf("the %s filter needs a snapshot", f)
+		}
+		keep = func(c ScanCandidate) bool { return c.Value() == value }
+	case ScanCorrelated:
+		if s.opts.Reference == nil {
+			return fmt.Errorf("the %s filter needs a reference parameter", f)
+		}
+		if s.snapshots < 3 {
+			return fmt.Errorf("the %s filter needs at least 3 snapshots", f)
+		}
+		keep = func(c ScanCandidate) bool { return math.Abs(s.Correlation(c)) >= value }
+	default:
+		return fmt.Errorf("unknown scan filter '%s'", f)
+	}
+
+	kept := s.candidates[:0]
+	for _, c := range s.candidates {
+		if keep(c) {
+			kept = append(kept, c)
+		}
+	}
+	s.candidates = kept
+	return nil
+}
+
+// Correlation returns the Pearson correlation coefficient of the candidate's values
+// and the reference parameter's values, from -1 to 1. It's 0 when there isn't a
+// reference parameter or either's values are constant.
+func (s *Scanner) Correlation(c ScanCandidate) float64 {
+	n := len(s.references)
+	if n < 2 || len(c.Values) != n {
+		return 0
+	}
+
+	var meanX, meanY float64
+	for i := 0; i < n; i++ {
+		meanX += c.Values[i]
+		meanY += s.references[i]
+	}
+	meanX, meanY = meanX/float64(n), meanY/float64(n)
+
+	var cov, varX, varY float64
+	for i := 0; i < n; i++ {
+		dx, dy := c.Values[i]-meanX, s.references[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varX*varY)
+}
+
+// decode combines a value's bytes.
+func (s *Scanner) decode(b []byte) float64 {
+	var v uint32
+	for i := range b {
+		if s.opts.LittleEndian {
+			v = v<<8 | uint32(b[len(b)-1-i])
+		} else {
+			v = v<<8 | uint32(b[i])
+		}
+	}
+	if s.opts.Signed {
+		n := 8 * len(b)
+		if v&(1<<(n-1)) != 0 {
+			return float64(int64(v) - int64(1)<<n)
+		}
+	}
+	return float64(v)
+}
+
+// addressValue returns the address as a number.
+func addressValue(a [3]byte) uint32 {
+	return uint32(a[0])<<16 | uint32(a[1])<<8 | uint32(a[2])
+}
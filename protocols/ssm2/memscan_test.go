@@ -0,0 +1,163 @@
+package ssm2_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+)
+
+func responsePacket(cmd byte, data []byte) []byte {
+	p := []byte{
+		ssm2.PacketMagicByte, ssm2.DeviceDiagnosticTool, ssm2.DeviceEngine,
+		byte(len(data) + 1), cmd,
+	}
+	p = append(p, data...)
+	return append(p, calculateChecksum(p))
+}
+
+func TestNewScanner(t *testing.T) {
+	rpm := ssm2.Parameters["P8"]
+	tests := []struct {
+		name    string
+		opts    ssm2.ScanOptions
+		wantErr bool
+	}{
+		{"valid", ssm2.ScanOptions{Start: [3]byte{0xFF, 0x60, 0x00}, Length: 0x100, Width: 2, Reference: &rpm}, false},
+		{"invalid width", ssm2.ScanOptions{Start: [3]byte{0xFF, 0x60, 0x00}, Length: 0x100, Width: 3}, true},
+		{"range shorter than the width", ssm2.ScanOptions{Start: [3]byte{0xFF, 0x60, 0x00}, Length: 1, Width: 2}, true},
+		{"past the last address", ssm2.ScanOptions{Start: [3]byte{0xFF, 0xFF, 0xFF}, Length: 2, Width: 1}, true},
+		{"derived reference", ssm2.ScanOptions{Start: [3]byte{0xFF, 0x60, 0x00}, Length: 1, Width: 1, Reference: &ssm2.Parameter{Id: "D1"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ssm2.NewScanner(ssm2.NewFakeConnection(0), tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewScanner() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScanner(t *testing.T) {
+	t.Run("Filters", func(t *testing.T) {
+		port := newTestSerialPort()
+		rpm := ssm2.Parameters["P8"]
+		s, err := ssm2.NewScanner(ssm2.NewConnection(port, nil), ssm2.ScanOptions{
+			Start: [3]byte{0xFF, 0x60, 0x00}, Length: 8, Width: 2, Reference: &rpm,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(s.Candidates()) != 4 {
+			t.Fatalf("want 4 candidates. got: %d", len(s.Candidates()))
+		}
+		if err = s.Filter(ssm2.ScanChanged, 0); err == nil {
+			t.Fatal("expected an error filtering before the snapshots")
+		}
+
+		snapshot := func(values [4]uint16, engineSpeed float64) {
+			block := make([]byte, 8)
+			for i, v := range values {
+				binary.BigEndian.PutUint16(block[i*2:], v)
+			}
+			ref := binary.BigEndian.AppendUint16(nil, uint16(engineSpeed*4))
+			port.out = bytes.NewBuffer(append(
+				responsePacket(ssm2.CommandReadBlockResponse, block),
+				responsePacket(ssm2.CommandReadAddressesResponse, ref)...))
+			if err := s.Snapshot(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		wantCandidates := func(addresses ...byte) {
+			t.Helper()
+			candidates := s.Candidates()
+			if len(candidates) != len(addresses) {
+				t.Fatalf("want %d candidates. got: %+v", len(addresses), candidates)
+			}
+			for i, c := range candidates {
+				if c.Address != [3]byte{0xFF, 0x60, addresses[i]} {
+					t.Fatalf("want candidate %d at 0xFF60%02X. got: %X", i, addresses[i], c.Address)
+				}
+			}
+		}
+
+		snapshot([4]uint16{0x100, 5, 0, 16}, 1000)
+		snapshot([4]uint16{0x200, 5, 1, 8}, 2000)
+		if err = s.Filter(ssm2.ScanChanged, 0); err != nil {
+			t.Fatal(err)
+		}
+		wantCandidates(0x00, 0x04, 0x06)
+
+		if err = s.Filter(ssm2.ScanCorrelated, 0.9); err == nil {
+			t.Fatal("expected an error correlating fewer than 3 snapshots")
+		}
+		snapshot([4]uint16{0x300, 5, 0, 4}, 3000)
+		if s.Snapshots() != 3 {
+			t.Fatalf("want 3 snapshots. got: %d", s.Snapshots())
+		}
+		if r := s.Correlation(s.Candidates()[0]); math.Abs(r-1) > 1e-9 {
+			t.Fatalf("want a correlation of 1. got: %v", r)
+		}
+		if err = s.Filter(ssm2.ScanCorrelated, 0.9); err != nil {
+			t.Fatal(err)
+		}
+		wantCandidates(0x00, 0x06)
+
+		if err = s.Filter(ssm2.ScanDecreased, 0); err != nil {
+			t.Fatal(err)
+		}
+		wantCandidates(0x06)
+		if err = s.Filter(ssm2.ScanEqual, 4); err != nil {
+			t.Fatal(err)
+		}
+		wantCandidates(0x06)
+		if err = s.Filter(ssm2.ScanEqual, 5); err != nil {
+			t.Fatal(err)
+		}
+		wantCandidates()
+
+		if err = s.Filter("bigger", 0); err == nil {
+			t.Fatal("expected an error for an unknown filter")
+		}
+	})
+
+	t.Run("ReadsCandidatesInBlocks", func(t *testing.T) {
+		port := newTestSerialPort()
+		s, err := ssm2.NewScanner(ssm2.NewConnection(port, nil), ssm2.ScanOptions{
+			Start: [3]byte{0xFF, 0x60, 0x00}, Length: 0x100, Width: 1, Signed: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		first, second := make([]byte, ssm2.MaxReadBlockLength), make([]byte, ssm2.MaxReadBlockLength)
+		second[0x10] = 0xFE
+		port.out = bytes.NewBuffer(append(
+			responsePacket(ssm2.CommandReadBlockResponse, first),
+			responsePacket(ssm2.CommandReadBlockResponse, second)...))
+		if err = s.Snapshot(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		// the second request starts at the first address after the first block
+		requests := port.in.Bytes()
+		if n := bytes.Count(requests, []byte{ssm2.CommandReadBlockRequest, 0x00, 0xFF, 0x60}); n != 2 {
+			t.Fatalf("want 2 read block requests. got: % X", requests)
+		}
+		if !bytes.Contains(requests, []byte{ssm2.CommandReadBlockRequest, 0x00, 0xFF, 0x60, 0x80, 0x7F}) {
+			t.Fatalf("want a request for the second block. got: % X", requests)
+		}
+
+		if err = s.Filter(ssm2.ScanEqual, -2); err != nil {
+			t.Fatal(err)
+		}
+		candidates := s.Candidates()
+		if len(candidates) != 1 || candidates[0].Address != [3]byte{0xFF, 0x60, 0x90} || candidates[0].Value() != -2 {
+			t.Fatalf("want the signed value at 0xFF6090. got: %+v", candidates)
+		}
+	})
+}
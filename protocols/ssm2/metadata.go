@@ -0,0 +1,90 @@
+package ssm2
+
+import "github.com/gavinwade12/ecLogger/units"
+
+// Category is a group of related parameters.
+type Category string
+
+const (
+	CategoryEngine       Category = "Engine"
+	CategoryFueling      Category = "Fueling"
+	CategoryIgnition     Category = "Ignition"
+	CategoryTransmission Category = "Transmission"
+	CategoryChassis      Category = "ABS/VDC"
+	CategoryDiesel       Category = "Diesel/DPF"
+	CategoryOther        Category = "Other"
+)
+
+// Categories are the parameter categories in the order they're displayed.
+var Categories = []Category{
+	CategoryEngine,
+	CategoryFueling,
+	CategoryIgnition,
+	CategoryTransmission,
+	CategoryChassis,
+	CategoryDiesel,
+	CategoryOther,
+}
+
+// Range is a range of parameter values. A nil bound means the range is unbounded on that side.
+type Range struct {
+	Min *float32
+	Max *float32
+}
+
+// bound returns a pointer to v for the bounds of the ranges in the parameter catalog.
+func bound(v float32) *float32 {
+	return &v
+}
+
+// Bounded returns true if the range has both a minimum and a maximum.
+func (r Range) Bounded() bool {
+	return r.Min != nil && r.Max != nil
+}
+
+// Contains returns true if v is within the range's bounds.
+func (r Range) Contains(v float32) bool {
+	return (r.Min == nil || v >= *r.Min) && (r.Max == nil || v <= *r.Max)
+}
+
+// ConvertTo converts the range's bounds from one unit to another. The bounds are swapped
+// for conversions that decrease as the value increases (e.g. mpg to L/100km), and bounds
+// that can't be converted are dropped.
+func (r Range) ConvertTo(from, to units.Unit) Range {
+	return r.ConvertWith(from, to, units.DefaultConverter)
+}
+
+// ConvertWith converts the range's bounds like ConvertTo using the converter, e.g. to
+// convert between AFR and Lambda for the vehicle's fuel.
+func (r Range) ConvertWith(from, to units.Unit, c units.Converter) Range {
+	if from == to {
+		return r
+	}
+
+	convert := func(b *float32) *float32 {
+		if b == nil {
+			return nil
+		}
+		v, err := ParameterValue{*b, from}.ConvertWith(to, c)
+		if err != nil {
+			return nil
+		}
+		return &v.Value
+	}
+	converted := Range{convert(r.Min), convert(r.Max)}
+
+	var decreasing bool
+	if converted.Bounded() {
+		decreasing = *converted.Min > *converted.Max
+	} else if b := r.Min; b != nil || r.Max != nil {
+		if b == nil {
+			b = r.Max
+		}
+		lower, higher := convert(b), convert(bound(*b+1))
+		decreasing = lower != nil && higher != nil && *higher < *lower
+	}
+	if decreasing {
+		converted.Min, converted.Max = converted.Max, converted.Min
+	}
+	return converted
+}
@@ -0,0 +1,80 @@
+package ssm2_test
+
+import (
+	"testing"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+func float32Ptr(v float32) *float32 {
+	return &v
+}
+
+func TestRange_Contains(t *testing.T) {
+	r := ssm2.Range{Min: float32Ptr(-40), Max: float32Ptr(130)}
+	for v, want := range map[float32]bool{-41: false, -40: true, 90: true, 130: true, 131: false} {
+		if got := r.Contains(v); got != want {
+			t.Errorf("Contains(%v) = %v, want %v", v, got, want)
+		}
+	}
+	if !(ssm2.Range{Max: float32Ptr(110)}).Contains(-1000) {
+		t.Error("want an unbounded minimum to contain any lower value")
+	}
+}
+
+func TestRange_ConvertTo(t *testing.T) {
+	r := ssm2.Range{Min: float32Ptr(-40), Max: float32Ptr(100)}.ConvertTo(units.C, units.F)
+	if !r.Bounded() || !approxEqual(*r.Min, -40, 0.01) || !approxEqual(*r.Max, 212, 0.01) {
+		t.Fatalf("want -40 to 212 F. got: %v to %v", r.Min, r.Max)
+	}
+
+	// a range that can't be converted is unbounded
+	if r = (ssm2.Range{Min: float32Ptr(0), Max: float32Ptr(100)}).ConvertTo(units.C, units.PSI); r.Min != nil || r.Max != nil {
+		t.Fatalf("want an unbounded range. got: %v to %v", r.Min, r.Max)
+	}
+}
+
+func TestRange_ConvertWith(t *testing.T) {
+	diesel := ssm2.Fuel{Type: ssm2.FuelDiesel}.Converter()
+	r := ssm2.Range{Min: float32Ptr(1), Max: float32Ptr(2)}.ConvertWith(units.Lambda, units.AFR, diesel)
+	if !r.Bounded() || !approxEqual(*r.Min, ssm2.StoichDiesel, 0.01) || !approxEqual(*r.Max, 2*ssm2.StoichDiesel, 0.01) {
+		t.Fatalf("want %v to %v AFR. got: %v to %v", ssm2.StoichDiesel, 2*ssm2.StoichDiesel, *r.Min, *r.Max)
+	}
+}
+
+func TestParameters_Metadata(t *testing.T) {
+	categories := map[ssm2.Category]bool{}
+	for _, c := range ssm2.Categories {
+		categories[c] = true
+	}
+	check := func(id string, c ssm2.Category, unit units.Unit, rng, warning ssm2.Range) {
+		if _, ok := units.Dimensions[unit]; !ok {
+			t.Errorf("%s has a unit without a dimension %q", id, unit)
+		}
+		if !categories[c] {
+			t.Errorf("%s has an unknown category %q", id, c)
+		}
+		for _, r := range []ssm2.Range{rng, warning} {
+			if r.Bounded() && *r.Min >= *r.Max {
+				t.Errorf("%s has a range from %v to %v", id, *r.Min, *r.Max)
+			}
+		}
+		if warning.Min != nil && !rng.Contains(*warning.Min) || warning.Max != nil && !rng.Contains(*warning.Max) {
+			t.Errorf("%s has warning thresholds outside of its range", id)
+		}
+	}
+	for id, p := range ssm2.Parameters {
+		check(id, p.Category, p.DefaultUnit, p.Range, p.Warning)
+	}
+	for id, p := range ssm2.DerivedParameters {
+		check(id, p.Category, p.DefaultUnit, p.Range, p.Warning)
+	}
+
+	if c := ssm2.Parameters["P9"].Category; c != ssm2.CategoryTransmission {
+		t.Errorf("want P9 in %s. got: %s", ssm2.CategoryTransmission, c)
+	}
+	if c := ssm2.DerivedParameters["P248"].Category; c != ssm2.CategoryTransmission {
+		t.Errorf("want P248 in %s. got: %s", ssm2.CategoryTransmission, c)
+	}
+}
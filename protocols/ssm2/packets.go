@@ -128,15 +128,41 @@ func validateHeader(b []byte) error {
 	return nil
 }
 
+// CapabilityByteOffset is the index of the first capability byte in the init response
+// data. Parameter.CapabilityByteIndex counts from the start of the data, before the IDs.
+const CapabilityByteOffset = 8
+
 // ECU describes an ECU and the different parameters it supports.
 type ECU struct {
 	SSM_ID []byte
 	ROM_ID []byte
+	// Capabilities are the raw capability bytes from the init response. Each bit flags
+	// support for a parameter, and some bits aren't mapped to a known parameter.
+	Capabilities []byte
 
 	SupportedParameters        []Parameter
 	SupportedDerivedParameters []DerivedParameter
 }
 
+// UpdateCustomParameters replaces the ECU's custom parameters with the ones
+// currently in Parameters and updates the supported derived parameters.
+func (e *ECU) UpdateCustomParameters() {
+	supported := make([]Parameter, 0, len(e.SupportedParameters))
+	for _, p := range e.SupportedParameters {
+		if !p.Custom {
+			supported = append(supported, p)
+		}
+	}
+	params, _ := Catalog()
+	for _, p := range params {
+		if p.Custom {
+			supported = append(supported, p)
+		}
+	}
+	e.SupportedParameters = supported
+	e.SupportedDerivedParameters = AvailableDerivedParameters(supported)
+}
+
 func parseECUFromInitResponse(p Packet) *ECU {
 	data := p.Data()
 	dLen := uint(len(data))
@@ -147,8 +173,16 @@ func parseECUFromInitResponse(p Packet) *ECU {
 		SupportedParameters:        make([]Parameter, 0),
 		SupportedDerivedParameters: make([]DerivedParameter, 0),
 	}
+	if dLen > CapabilityByteOffset {
+		ecu.Capabilities = data[CapabilityByteOffset:]
+	}
 
-	for _, p := range Parameters {
+	params, _ := Catalog()
+	for _, p := range params {
+		if p.Custom {
+			ecu.SupportedParameters = append(ecu.SupportedParameters, p)
+			continue
+		}
 		if p.CapabilityByteIndex >= dLen {
 			continue // capability byte isn't in the data
 		}
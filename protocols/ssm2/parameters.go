@@ -2,6 +2,12 @@ package ssm2
 
 import (
 	"encoding/binary"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gavinwade12/ecLogger/units"
 )
@@ -22,6 +28,22 @@ type Parameter struct {
 	Address *Address
 
 	Value func(v []byte) ParameterValue
+
+	// Format is how the parameter's values are displayed.
+	Format Format
+
+	// Category groups related parameters.
+	Category Category
+	// Range is the plausible range of the parameter's values in its default unit.
+	// Values outside of it are likely a bad reading.
+	Range Range
+	// Warning is the range of values in the default unit that are normal for a healthy
+	// engine. Values outside of it are worth a warning, e.g. a high coolant temperature.
+	Warning Range
+
+	// Custom is set for user-defined parameters, which are read from their address
+	// regardless of the ECU's capabilities.
+	Custom bool
 }
 
 // DerivedParameter is a parameter derived from other calculated parameters instead of from ECU values.
@@ -33,7 +55,29 @@ type DerivedParameter struct {
 
 	DependsOnParameters []string
 
-	Value func(parameters map[string]ParameterValue) (*ParameterValue, error)
+	// Format is how the parameter's values are displayed.
+	Format Format
+
+	// Category groups related parameters.
+	Category Category
+	// Range is the plausible range of the parameter's values in its default unit.
+	// Values outside of it are likely a bad reading.
+	Range Range
+	// Warning is the range of values in the default unit that are normal for a healthy
+	// engine. Values outside of it are worth a warning, e.g. a high coolant temperature.
+	Warning Range
+
+	// Value calculates the parameter's value using the constants (e.g. displacement) from the vehicle.
+	Value func(parameters map[string]ParameterValue, v Vehicle) (*ParameterValue, error)
+
+	// NewCalculator is set for stateful parameters, which are calculated from every
+	// sample in a logging session by their own Calculator instead of by Value.
+	NewCalculator func() Calculator
+}
+
+// Stateful returns true if the parameter is calculated by a Calculator instead of by Value.
+func (p DerivedParameter) Stateful() bool {
+	return p.NewCalculator != nil
 }
 
 // Address describes the address(es) containing the value for the parameter
@@ -44,6 +88,19 @@ type Address struct {
 	Bit     uint8 // the bit location within the byte returned from reading the address
 }
 
+// ParseAddress parses a 3-byte address from hex, e.g. FF6B1C or 0xFF6B1C.
+func ParseAddress(s string) ([3]byte, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(s), "0x"), "0X")
+	if s == "" || len(s) > 6 {
+		return [3]byte{}, fmt.Errorf("invalid address '%s': want up to 6 hex digits", s)
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return [3]byte{}, fmt.Errorf("invalid address '%s': want up to 6 hex digits", s)
+	}
+	return [3]byte{byte(v >> 16), byte(v >> 8), byte(v)}, nil
+}
+
 // Add adds i to the Address.
 func (a Address) Add(i uint32) [3]byte {
 	if i == 0 {
@@ -55,6 +112,38 @@ func (a Address) Add(i uint32) [3]byte {
 	return [3]byte{addr[1], addr[2], addr[3]}
 }
 
+// SortIDs sorts parameter IDs with LessID.
+func SortIDs(ids []string) {
+	sort.Slice(ids, func(i, j int) bool { return LessID(ids[i], ids[j]) })
+}
+
+// LessID orders parameter IDs naturally, so P2 comes before P10.
+func LessID(a, b string) bool {
+	aPrefix, aNum := splitID(a)
+	bPrefix, bNum := splitID(b)
+	if aPrefix != bPrefix || aNum < 0 || bNum < 0 {
+		return a < b
+	}
+	return aNum < bNum
+}
+
+// splitID splits an ID into its letters and trailing number, or returns -1 as the
+// number when the ID doesn't end in one.
+func splitID(id string) (string, int) {
+	i := len(id)
+	for i > 0 && id[i-1] >= '0' && id[i-1] <= '9' {
+		i--
+	}
+	if i == len(id) || len(id)-i > 9 {
+		return id, -1
+	}
+	n := 0
+	for _, c := range id[i:] {
+		n = n*10 + int(c-'0')
+	}
+	return id[:i], n
+}
+
 // Parameter value stores a parameter's value with its current unit.
 type ParameterValue struct {
 	Value float32
@@ -63,16 +152,22 @@ type ParameterValue struct {
 
 // ConvertTo converts a ParameterValue from its current unit to the given unit.
 func (v ParameterValue) ConvertTo(u units.Unit) (*ParameterValue, error) {
+	return v.ConvertWith(u, units.DefaultConverter)
+}
+
+// ConvertWith converts a ParameterValue from its current unit to the given unit using
+// the converter, e.g. to convert between AFR and Lambda for the vehicle's fuel.
+func (v ParameterValue) ConvertWith(u units.Unit, c units.Converter) (*ParameterValue, error) {
 	if u == v.Unit {
 		return &ParameterValue{v.Value, v.Unit}, nil
 	}
 
-	val, err := units.Convert(v.Value, v.Unit, u)
+	val, err := c.Convert(float64(v.Value), v.Unit, u)
 	if err != nil {
 		return nil, err
 	}
 
-	return &ParameterValue{Value: val, Unit: u}, nil
+	return &ParameterValue{Value: float32(val), Unit: u}, nil
 }
 
 // SafeConvertTo returns the ParameterValue converted to the
@@ -87,37 +182,160 @@ func (v ParameterValue) SafeConvertTo(u units.Unit) ParameterValue {
 	return ParameterValue{Unit: u}
 }
 
+// wideOpenThrottle is the throttle opening (%) considered wide open.
+const wideOpenThrottle = 80
+
+// revolutionsPerCycle is the number of crankshaft revolutions in a four-stroke engine cycle.
+const revolutionsPerCycle = 2
+
+// mpgUSPerKMPerL converts km/L to mpg (US).
+const mpgUSPerKMPerL = 2.352145
+
+// injectorDutyCycle returns the percentage of each engine cycle the injectors are open.
+func injectorDutyCycle(rpm, pulseWidth ParameterValue) float32 {
+	if rpm.Value <= 0 {
+		return 0
+	}
+	cycleTime := 60 * 1000 * 1000 * revolutionsPerCycle / rpm.Value // µs
+	return pulseWidth.SafeConvertTo(units.US).Value / cycleTime * 100
+}
+
 // AvailableDerivedParameters returns the DerivedParameters
-// that are available based on the provided Parameters.
+// that are available based on the provided Parameters, including
+// those that depend on other available DerivedParameters.
 func AvailableDerivedParameters(params []Parameter) []DerivedParameter {
-	lookup := make(map[string]Parameter)
+	available := make(map[string]bool)
 	for _, p := range params {
-		lookup[p.Id] = p
+		available[p.Id] = true
 	}
 
+	_, catalog := Catalog()
 	derived := make([]DerivedParameter, 0)
-	for _, p := range DerivedParameters {
-		available := true
-		for _, d := range p.DependsOnParameters {
-			if _, ok := lookup[d]; !ok {
-				available = false
-				break
+	// keep adding the derived parameters whose dependencies are all available
+	// until no more are found, since each one can make others available
+	for added := true; added; {
+		added = false
+		for _, p := range catalog {
+			if available[p.Id] {
+				continue
+			}
+
+			ok := true
+			for _, d := range p.DependsOnParameters {
+				if !available[d] {
+					ok = false
+					break
+				}
+			}
+			if ok {
+				available[p.Id] = true
+				derived = append(derived, p)
+				added = true
 			}
-		}
-		if available {
-			derived = append(derived, p)
 		}
 	}
 
 	return derived
 }
 
-// Parameters defines all the parameters supported by the SSM2 protocol.
+// ResolveDerivedParameters returns the derived parameters along with the derived
+// parameters they depend on, ordered so each one comes after its dependencies,
+// and the Parameters that need to be read to calculate them. An error is returned
+// when a dependency doesn't exist or the dependencies form a cycle.
+func ResolveDerivedParameters(derived []DerivedParameter) ([]DerivedParameter, []Parameter, error) {
+	params, catalog := Catalog()
+	lookup := make(map[string]DerivedParameter, len(derived))
+	for _, p := range derived {
+		lookup[p.Id] = p
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	var (
+		state    = make(map[string]int)
+		ordered  = make([]DerivedParameter, 0, len(derived))
+		reads    = make([]Parameter, 0)
+		readIDs  = make(map[string]bool)
+		path     []string
+		visit    func(p DerivedParameter) error
+		findNode = func(id string) (DerivedParameter, bool) {
+			if p, ok := lookup[id]; ok {
+				return p, true
+			}
+			p, ok := catalog[id]
+			return p, ok
+		}
+	)
+	visit = func(p DerivedParameter) error {
+		switch state[p.Id] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("derived parameter dependency cycle: %s -> %s", strings.Join(path, " -> "), p.Id)
+		}
+
+		state[p.Id] = visiting
+		path = append(path, p.Id)
+		for _, id := range p.DependsOnParameters {
+			if param, ok := params[id]; ok {
+				if !readIDs[id] {
+					readIDs[id] = true
+					reads = append(reads, param)
+				}
+				continue
+			}
+
+			dep, ok := findNode(id)
+			if !ok {
+				return fmt.Errorf("derived parameter %s depends on unknown parameter '%s'", p.Id, id)
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[p.Id] = visited
+		ordered = append(ordered, p)
+		return nil
+	}
+
+	for _, p := range derived {
+		if err := visit(p); err != nil {
+			return nil, nil, err
+		}
+	}
+	return ordered, reads, nil
+}
+
+// catalogMu guards replacing Parameters and DerivedParameters. See Catalog.
+var catalogMu sync.RWMutex
+
+// Catalog returns Parameters and DerivedParameters, including the registered user-defined
+// parameters. The maps must not be modified. SetCatalog replaces them instead of changing
+// them, so the returned maps can be read while parameters are registered.
+func Catalog() (map[string]Parameter, map[string]DerivedParameter) {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	return Parameters, DerivedParameters
+}
+
+// SetCatalog replaces Parameters and DerivedParameters, e.g. with copies containing the
+// registered user-defined parameters. The maps must not be modified afterwards.
+func SetCatalog(params map[string]Parameter, derived map[string]DerivedParameter) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	Parameters, DerivedParameters = params, derived
+}
+
+// Parameters defines all the parameters supported by the SSM2 protocol. Use Catalog
+// to read it while user-defined parameters may be registered.
 var Parameters = map[string]Parameter{
 	"P1": {
 		Id:                  "P1",
 		Name:                "Engine Load (Relative)",
-		Description:         "P1",
+		Description:         "P1-Engine load relative to the maximum load, as calculated by the ECU",
 		CapabilityByteIndex: 8,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -128,11 +346,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 100 / 255, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P2": {
 		Id:                  "P2",
 		Name:                "Coolant Temperature",
-		Description:         "P2",
+		Description:         "P2-Engine coolant temperature",
 		CapabilityByteIndex: 8,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -143,11 +363,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) - 40, units.C}
 		},
 		DefaultUnit: units.C,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(-40), Max: bound(130)},
+		Warning:     Range{Max: bound(110)},
 	},
 	"P3": {
 		Id:                  "P3",
 		Name:                "A/F Correction #1",
-		Description:         "P3",
+		Description:         "P3-Short-term fuel trim for bank 1 from the front A/F sensor's closed loop feedback",
 		CapabilityByteIndex: 8,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -158,11 +381,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 100 / 128, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(-50), Max: bound(50)},
 	},
 	"P4": {
 		Id:                  "P4",
 		Name:                "A/F Learning #1",
-		Description:         "P4",
+		Description:         "P4-Long-term fuel trim for bank 1, learned from the A/F correction",
 		CapabilityByteIndex: 8,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -173,11 +398,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 100 / 128, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(-50), Max: bound(50)},
+		Warning:     Range{Min: bound(-10), Max: bound(10)},
 	},
 	"P5": {
 		Id:                  "P5",
 		Name:                "A/F Correction #2",
-		Description:         "P5",
+		Description:         "P5-Short-term fuel trim for bank 2 from the front A/F sensor's closed loop feedback",
 		CapabilityByteIndex: 8,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -188,11 +416,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 100 / 128, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(-50), Max: bound(50)},
 	},
 	"P6": {
 		Id:                  "P6",
 		Name:                "A/F Learning #2",
-		Description:         "P6",
+		Description:         "P6-Long-term fuel trim for bank 2, learned from the A/F correction",
 		CapabilityByteIndex: 8,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -203,6 +433,9 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 100 / 128, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(-50), Max: bound(50)},
+		Warning:     Range{Min: bound(-10), Max: bound(10)},
 	},
 	"P7": {
 		Id:                  "P7",
@@ -218,11 +451,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.KPA}
 		},
 		DefaultUnit: units.KPA,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(300)},
 	},
 	"P8": {
 		Id:                  "P8",
 		Name:                "Engine Speed",
-		Description:         "P8",
+		Description:         "P8-Engine speed from the crankshaft position sensor",
 		CapabilityByteIndex: 8,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -233,11 +468,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)) / 4, units.RPM}
 		},
 		DefaultUnit: units.RPM,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(9000)},
 	},
 	"P9": {
 		Id:                  "P9",
 		Name:                "Vehicle Speed",
-		Description:         "P9",
+		Description:         "P9-Vehicle speed from the vehicle speed sensor",
 		CapabilityByteIndex: 9,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -248,11 +486,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.KMH}
 		},
 		DefaultUnit: units.KMH,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(300)},
 	},
 	"P10": {
 		Id:                  "P10",
 		Name:                "Ignition Total Timing",
-		Description:         "P10",
+		Description:         "P10-Total ignition timing advance, including the knock corrections",
 		CapabilityByteIndex: 9,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -263,11 +504,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) / 2, units.Degress}
 		},
 		DefaultUnit: units.Degress,
+		Category:    CategoryIgnition,
+		Range:       Range{Min: bound(-20), Max: bound(60)},
 	},
 	"P11": {
 		Id:                  "P11",
 		Name:                "Intake Air Temperature",
-		Description:         "P11",
+		Description:         "P11-Temperature of the air entering the intake",
 		CapabilityByteIndex: 9,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -278,11 +521,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) - 40, units.C}
 		},
 		DefaultUnit: units.C,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(-40), Max: bound(80)},
+		Warning:     Range{Max: bound(60)},
 	},
 	"P12": {
 		Id:                  "P12",
 		Name:                "Mass Airflow",
-		Description:         "P12",
+		Description:         "P12-Mass of the air entering the intake, measured by the MAF sensor",
 		CapabilityByteIndex: 9,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -293,6 +539,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)) / 100, units.GS}
 		},
 		DefaultUnit: units.GS,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(400)},
 	},
 	"P13": {
 		Id:                  "P13",
@@ -308,11 +556,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 100 / 255, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P14": {
 		Id:                  "P14",
 		Name:                "Front O2 Sensor #1",
-		Description:         "P14",
+		Description:         "P14-Output voltage of the front O2 sensor for bank 1",
 		CapabilityByteIndex: 9,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -323,11 +573,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)) / 200, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Format:      Format{Precision: 3},
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(1.5)},
 	},
 	"P15": {
 		Id:                  "P15",
 		Name:                "Rear O2 Sensor",
-		Description:         "P15",
+		Description:         "P15-Output voltage of the rear (post-catalyst) O2 sensor",
 		CapabilityByteIndex: 9,
 		CapabilityBitIndex:  1,
 		Address: &Address{
@@ -338,11 +591,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)) / 200, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Format:      Format{Precision: 3},
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(1.5)},
 	},
 	"P16": {
 		Id:                  "P16",
 		Name:                "Front O2 Sensor #2",
-		Description:         "P16",
+		Description:         "P16-Output voltage of the front O2 sensor for bank 2",
 		CapabilityByteIndex: 9,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -353,11 +609,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)) / 200, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Format:      Format{Precision: 3},
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(1.5)},
 	},
 	"P17": {
 		Id:                  "P17",
 		Name:                "Battery Voltage",
-		Description:         "P17",
+		Description:         "P17-Battery voltage supplied to the ECU",
 		CapabilityByteIndex: 10,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -368,11 +627,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 8 / 100, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(8), Max: bound(16)},
+		Warning:     Range{Min: bound(11.5), Max: bound(15)},
 	},
 	"P18": {
 		Id:                  "P18",
 		Name:                "Mass Airflow Sensor Voltage",
-		Description:         "P18",
+		Description:         "P18-Output voltage of the MAF sensor",
 		CapabilityByteIndex: 10,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -383,11 +645,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 50, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(5.1)},
 	},
 	"P19": {
 		Id:                  "P19",
 		Name:                "Throttle Sensor Voltage",
-		Description:         "P19",
+		Description:         "P19-Output voltage of the throttle position sensor",
 		CapabilityByteIndex: 10,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -398,11 +662,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 50, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(5.1)},
 	},
 	"P20": {
 		Id:                  "P20",
 		Name:                "Differential Pressure Sensor Voltage",
-		Description:         "P20",
+		Description:         "P20-Output voltage of the differential pressure sensor",
 		CapabilityByteIndex: 10,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -413,6 +679,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 50, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(5.1)},
 	},
 	"P21": {
 		Id:                  "P21",
@@ -428,6 +696,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 256, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(30000)},
 	},
 	"P22": {
 		Id:                  "P22",
@@ -443,6 +713,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 256, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(30000)},
 	},
 	"P23": {
 		Id:                  "P23",
@@ -458,11 +730,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) / 2, units.Degress}
 		},
 		DefaultUnit: units.Degress,
+		Category:    CategoryIgnition,
+		Range:       Range{Min: bound(-20), Max: bound(20)},
+		Warning:     Range{Min: bound(-2)},
 	},
 	"P24": {
 		Id:                  "P24",
 		Name:                "Atmospheric Pressure",
-		Description:         "P24",
+		Description:         "P24-Barometric pressure measured by the atmospheric pressure sensor",
 		CapabilityByteIndex: 10,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -473,6 +748,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.KPA}
 		},
 		DefaultUnit: units.KPA,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(50), Max: bound(110)},
 	},
 	"P25": {
 		Id:                  "P25",
@@ -488,11 +765,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) - 128, units.KPA}
 		},
 		DefaultUnit: units.KPA,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(-101.3), Max: bound(200)},
 	},
 	"P26": {
 		Id:                  "P26",
 		Name:                "Pressure Differential Sensor",
-		Description:         "P26",
+		Description:         "P26-Pressure measured by the differential pressure sensor",
 		CapabilityByteIndex: 11,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -503,11 +782,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) - 128, units.KPA}
 		},
 		DefaultUnit: units.KPA,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(-128), Max: bound(128)},
 	},
 	"P27": {
 		Id:                  "P27",
 		Name:                "Fuel Tank Pressure",
-		Description:         "P27",
+		Description:         "P27-Pressure in the fuel tank relative to atmospheric pressure, used to check the evaporative emissions system",
 		CapabilityByteIndex: 11,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -518,11 +799,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) / 4, units.HPA}
 		},
 		DefaultUnit: units.HPA,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(-32), Max: bound(32)},
 	},
 	"P28": {
 		Id:                  "P28",
 		Name:                "CO Adjustment",
-		Description:         "P28",
+		Description:         "P28-Voltage of the CO adjustment potentiometer on models without a front O2 sensor",
 		CapabilityByteIndex: 11,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -533,6 +816,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 50, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(5.1)},
 	},
 	"P29": {
 		Id:                  "P29",
@@ -548,6 +833,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) / 2, units.Degress}
 		},
 		DefaultUnit: units.Degress,
+		Category:    CategoryIgnition,
+		Range:       Range{Min: bound(-20), Max: bound(20)},
 	},
 	"P30": {
 		Id:                  "P30",
@@ -563,11 +850,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 100 / 255, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P31": {
 		Id:                  "P31",
 		Name:                "Fuel Temperature",
-		Description:         "P31",
+		Description:         "P31-Temperature of the fuel",
 		CapabilityByteIndex: 11,
 		CapabilityBitIndex:  1,
 		Address: &Address{
@@ -578,11 +867,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) - 40, units.C}
 		},
 		DefaultUnit: units.C,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(-40), Max: bound(80)},
 	},
 	"P32": {
 		Id:                  "P32",
 		Name:                "Front O2 Heater Current #1",
-		Description:         "P32",
+		Description:         "P32-Current through the heater of the front O2 sensor for bank 1",
 		CapabilityByteIndex: 11,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -593,11 +884,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 1004 / 25600, units.Amps}
 		},
 		DefaultUnit: units.Amps,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(10)},
 	},
 	"P33": {
 		Id:                  "P33",
 		Name:                "Rear O2 Heater Current",
-		Description:         "P33",
+		Description:         "P33-Current through the heater of the rear O2 sensor",
 		CapabilityByteIndex: 12,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -608,11 +901,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 1004 / 25600, units.Amps}
 		},
 		DefaultUnit: units.Amps,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(10)},
 	},
 	"P34": {
 		Id:                  "P34",
 		Name:                "Front O2 Heater Current #2",
-		Description:         "P34",
+		Description:         "P34-Current through the heater of the front O2 sensor for bank 2",
 		CapabilityByteIndex: 12,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -623,11 +918,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 1004 / 25600, units.Amps}
 		},
 		DefaultUnit: units.Amps,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(10)},
 	},
 	"P35": {
 		Id:                  "P35",
 		Name:                "Fuel Level",
-		Description:         "P35",
+		Description:         "P35-Output voltage of the fuel level sensor",
 		CapabilityByteIndex: 12,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -638,11 +935,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 50, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(5.1)},
 	},
 	"P36": {
 		Id:                  "P36",
 		Name:                "Primary Wastegate Duty Cycle",
-		Description:         "P36-Trubo Control Valve Duty Cycle",
+		Description:         "P36-Turbo control valve (wastegate solenoid) duty cycle",
 		CapabilityByteIndex: 12,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -653,11 +952,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 100 / 255, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P37": {
 		Id:                  "P37",
 		Name:                "Secondary Wastegate Duty Cycle",
-		Description:         "P37",
+		Description:         "P37-Duty cycle of the secondary turbocharger's wastegate solenoid on sequential twin turbo models",
 		CapabilityByteIndex: 12,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -668,11 +969,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 100 / 255, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P38": {
 		Id:                  "P38",
 		Name:                "CPC Valve Duty Ratio",
-		Description:         "P38",
+		Description:         "P38-Duty ratio of the canister purge control valve for the evaporative emissions system",
 		CapabilityByteIndex: 12,
 		CapabilityBitIndex:  1,
 		Address: &Address{
@@ -683,11 +986,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 100 / 255, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P39": {
 		Id:                  "P39",
 		Name:                "Tumble Valve Position Sensor Right",
-		Description:         "P39",
+		Description:         "P39-Output voltage of the right bank's tumble generator valve position sensor",
 		CapabilityByteIndex: 12,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -698,11 +1003,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 50, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(5.1)},
 	},
 	"P40": {
 		Id:                  "P40",
 		Name:                "Tumble Valve Position Sensor Left",
-		Description:         "P40",
+		Description:         "P40-Output voltage of the left bank's tumble generator valve position sensor",
 		CapabilityByteIndex: 13,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -713,11 +1020,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 50, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(5.1)},
 	},
 	"P41": {
 		Id:                  "P41",
 		Name:                "Idle Speed Control Valve Duty Ratio",
-		Description:         "P41",
+		Description:         "P41-Duty ratio of the idle speed control valve",
 		CapabilityByteIndex: 13,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -728,11 +1037,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 2, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P42": {
 		Id:                  "P42",
 		Name:                "A/F Lean Correction",
-		Description:         "P42",
+		Description:         "P42-Fuel correction applied by the ECU while running lean",
 		CapabilityByteIndex: 13,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -743,11 +1054,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 100 / 255, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P43": {
 		Id:                  "P43",
 		Name:                "A/F Heater Duty",
-		Description:         "P43",
+		Description:         "P43-Duty cycle of the front A/F sensor's heater",
 		CapabilityByteIndex: 13,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -758,11 +1071,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 100 / 255, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P44": {
 		Id:                  "P44",
 		Name:                "Idle Speed Control Valve Step",
-		Description:         "P44",
+		Description:         "P44-Position of the stepper motor idle speed control valve",
 		CapabilityByteIndex: 13,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -773,11 +1088,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Steps}
 		},
 		DefaultUnit: units.Steps,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P45": {
 		Id:                  "P45",
 		Name:                "Number of Exh. Gas Recirc. Steps",
-		Description:         "P45",
+		Description:         "P45-Position of the stepper motor exhaust gas recirculation valve",
 		CapabilityByteIndex: 13,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -788,11 +1106,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Steps}
 		},
 		DefaultUnit: units.Steps,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P46": {
 		Id:                  "P46",
 		Name:                "Alternator Duty",
-		Description:         "P46",
+		Description:         "P46-Duty cycle of the alternator's field control",
 		CapabilityByteIndex: 13,
 		CapabilityBitIndex:  1,
 		Address: &Address{
@@ -803,11 +1124,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P47": {
 		Id:                  "P47",
 		Name:                "Fuel Pump Duty",
-		Description:         "P47",
+		Description:         "P47-Duty cycle of the fuel pump controller",
 		CapabilityByteIndex: 13,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -818,11 +1141,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 100 / 255, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P48": {
 		Id:                  "P48",
 		Name:                "Intake VVT Advance Angle Right",
-		Description:         "P48",
+		Description:         "P48-Intake camshaft advance of the right bank from the variable valve timing",
 		CapabilityByteIndex: 14,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -833,11 +1158,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) - 50, units.Degress}
 		},
 		DefaultUnit: units.Degress,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(-10), Max: bound(60)},
 	},
 	"P49": {
 		Id:                  "P49",
 		Name:                "Intake VVT Advance Angle Left",
-		Description:         "P49",
+		Description:         "P49-Intake camshaft advance of the left bank from the variable valve timing",
 		CapabilityByteIndex: 14,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -848,11 +1175,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) - 50, units.Degress}
 		},
 		DefaultUnit: units.Degress,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(-10), Max: bound(60)},
 	},
 	"P50": {
 		Id:                  "P50",
 		Name:                "Intake OCV Duty Right",
-		Description:         "P50",
+		Description:         "P50-Duty cycle of the right bank's intake oil control valve, which adjusts the intake cam timing",
 		CapabilityByteIndex: 14,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -863,11 +1192,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 100 / 255, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P51": {
 		Id:                  "P51",
 		Name:                "Intake OCV Duty Left",
-		Description:         "P51",
+		Description:         "P51-Duty cycle of the left bank's intake oil control valve, which adjusts the intake cam timing",
 		CapabilityByteIndex: 14,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -878,11 +1209,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 100 / 255, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P52": {
 		Id:                  "P52",
 		Name:                "Intake OCV Current Right",
-		Description:         "P52",
+		Description:         "P52-Current through the right bank's intake oil control valve",
 		CapabilityByteIndex: 14,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -893,11 +1226,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 32, units.Milliamps}
 		},
 		DefaultUnit: units.Milliamps,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(2000)},
 	},
 	"P53": {
 		Id:                  "P53",
 		Name:                "Intake OCV Current Left",
-		Description:         "P53",
+		Description:         "P53-Current through the left bank's intake oil control valve",
 		CapabilityByteIndex: 14,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -908,11 +1243,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 32, units.Milliamps}
 		},
 		DefaultUnit: units.Milliamps,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(2000)},
 	},
 	"P54": {
 		Id:                  "P54",
 		Name:                "A/F Sensor #1 Current",
-		Description:         "P54",
+		Description:         "P54-Pumping current of the front A/F sensor for bank 1",
 		CapabilityByteIndex: 14,
 		CapabilityBitIndex:  1,
 		Address: &Address{
@@ -923,11 +1260,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) / 8, units.Milliamps}
 		},
 		DefaultUnit: units.Milliamps,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(-16), Max: bound(16)},
 	},
 	"P55": {
 		Id:                  "P55",
 		Name:                "A/F Sensor #2 Current",
-		Description:         "P55",
+		Description:         "P55-Pumping current of the front A/F sensor for bank 2",
 		CapabilityByteIndex: 14,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -938,11 +1277,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) / 8, units.Milliamps}
 		},
 		DefaultUnit: units.Milliamps,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(-16), Max: bound(16)},
 	},
 	"P56": {
 		Id:                  "P56",
 		Name:                "A/F Sensor #1 Resistance",
-		Description:         "P56",
+		Description:         "P56-Element resistance of the front A/F sensor for bank 1, which falls as the sensor warms up",
 		CapabilityByteIndex: 15,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -953,11 +1294,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Ohms}
 		},
 		DefaultUnit: units.Ohms,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P57": {
 		Id:                  "P57",
 		Name:                "A/F Sensor #2 Resistance",
-		Description:         "P57",
+		Description:         "P57-Element resistance of the front A/F sensor for bank 2, which falls as the sensor warms up",
 		CapabilityByteIndex: 15,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -968,11 +1311,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Ohms}
 		},
 		DefaultUnit: units.Ohms,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P58": {
 		Id:                  "P58",
 		Name:                "A/F Sensor #1",
-		Description:         "P58",
+		Description:         "P58-Air/fuel ratio measured by the front wideband A/F sensor for bank 1",
 		CapabilityByteIndex: 15,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -983,11 +1328,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 128, units.Lambda}
 		},
 		DefaultUnit: units.Lambda,
+		Format:      Format{Precision: 3},
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0.5), Max: bound(1.5)},
 	},
 	"P59": {
 		Id:                  "P59",
 		Name:                "A/F Sensor #2",
-		Description:         "P59",
+		Description:         "P59-Air/fuel ratio measured by the front wideband A/F sensor for bank 2",
 		CapabilityByteIndex: 15,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -998,11 +1346,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 128, units.Lambda}
 		},
 		DefaultUnit: units.Lambda,
+		Format:      Format{Precision: 3},
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0.5), Max: bound(1.5)},
 	},
 	"P60": {
 		Id:                  "P60",
 		Name:                "Gear Position",
-		Description:         "P60",
+		Description:         "P60-Gear engaged, as reported by the ECU",
 		CapabilityByteIndex: 16,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -1013,11 +1364,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) + 1, units.Gear}
 		},
 		DefaultUnit: units.Gear,
+		Format:      Format{Kind: Enum, Labels: gearLabels},
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(1), Max: bound(6)},
 	},
 	"P61": {
 		Id:                  "P61",
 		Name:                "A/F Sensor #1 Heater Current",
-		Description:         "P61",
+		Description:         "P61-Current through the heater of the front A/F sensor for bank 1",
 		CapabilityByteIndex: 17,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -1028,11 +1382,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 10, units.Amps}
 		},
 		DefaultUnit: units.Amps,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(10)},
 	},
 	"P62": {
 		Id:                  "P62",
 		Name:                "A/F Sensor #2 Heater Current",
-		Description:         "P62",
+		Description:         "P62-Current through the heater of the front A/F sensor for bank 2",
 		CapabilityByteIndex: 17,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -1043,11 +1399,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 10, units.Amps}
 		},
 		DefaultUnit: units.Amps,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(10)},
 	},
 	"P63": {
 		Id:                  "P63",
 		Name:                "Roughness Monitor Cylinder #1",
-		Description:         "P63",
+		Description:         "P63-Roughness (misfire) count of cylinder #1 monitored by the ECU",
 		CapabilityByteIndex: 55,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -1058,11 +1416,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.MisfireCount}
 		},
 		DefaultUnit: units.MisfireCount,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryIgnition,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P64": {
 		Id:                  "P64",
 		Name:                "Roughness Monitor Cylinder #2",
-		Description:         "P64",
+		Description:         "P64-Roughness (misfire) count of cylinder #2 monitored by the ECU",
 		CapabilityByteIndex: 55,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -1073,11 +1434,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.MisfireCount}
 		},
 		DefaultUnit: units.MisfireCount,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryIgnition,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P65": {
 		Id:                  "P65",
 		Name:                "A/F Correction #3 (16-bit ECU)",
-		Description:         "P65",
+		Description:         "P65-Fuel trim from the rear O2 sensor's feedback on 16-bit ECUs",
 		CapabilityByteIndex: 15,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -1088,11 +1452,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 100 / 128, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(-50), Max: bound(50)},
 	},
 	"P66": {
 		Id:                  "P66",
 		Name:                "A/F Learning #3",
-		Description:         "P66",
+		Description:         "P66-Fuel trim learned from the rear O2 sensor's feedback",
 		CapabilityByteIndex: 15,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -1103,11 +1469,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 100 / 128, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(-50), Max: bound(50)},
 	},
 	"P67": {
 		Id:                  "P67",
 		Name:                "Rear O2 Heater Voltage",
-		Description:         "P67",
+		Description:         "P67-Voltage of the rear O2 sensor's heater",
 		CapabilityByteIndex: 15,
 		CapabilityBitIndex:  1,
 		Address: &Address{
@@ -1118,11 +1486,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 50, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(5.1)},
 	},
 	"P68": {
 		Id:                  "P68",
 		Name:                "A/F Adjustment Voltage",
-		Description:         "P68",
+		Description:         "P68-Voltage of the A/F adjustment potentiometer",
 		CapabilityByteIndex: 15,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -1133,11 +1503,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 50, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(5.1)},
 	},
 	"P69": {
 		Id:                  "P69",
 		Name:                "Roughness Monitor Cylinder #3",
-		Description:         "P69",
+		Description:         "P69-Roughness (misfire) count of cylinder #3 monitored by the ECU",
 		CapabilityByteIndex: 55,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -1148,11 +1520,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.MisfireCount}
 		},
 		DefaultUnit: units.MisfireCount,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryIgnition,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P70": {
 		Id:                  "P70",
 		Name:                "Roughness Monitor Cylinder #4",
-		Description:         "P70",
+		Description:         "P70-Roughness (misfire) count of cylinder #4 monitored by the ECU",
 		CapabilityByteIndex: 55,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -1163,11 +1538,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.MisfireCount}
 		},
 		DefaultUnit: units.MisfireCount,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryIgnition,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P71": {
 		Id:                  "P71",
 		Name:                "Throttle Motor Duty",
-		Description:         "P71",
+		Description:         "P71-Duty cycle of the electronic throttle's motor. Negative values drive the throttle closed.",
 		CapabilityByteIndex: 38,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -1178,11 +1556,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 100 / 128, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(-100), Max: bound(100)},
 	},
 	"P72": {
 		Id:                  "P72",
 		Name:                "Throttle Motor Voltage",
-		Description:         "P72",
+		Description:         "P72-Supply voltage of the electronic throttle's motor",
 		CapabilityByteIndex: 38,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -1193,11 +1573,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 8 / 100, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(20.4)},
 	},
 	"P73": {
 		Id:                  "P73",
 		Name:                "Sub Throttle Sensor",
-		Description:         "P73",
+		Description:         "P73-Output voltage of the electronic throttle's sub position sensor",
 		CapabilityByteIndex: 40,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -1208,11 +1590,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 50, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(5.1)},
 	},
 	"P74": {
 		Id:                  "P74",
 		Name:                "Main Throttle Sensor",
-		Description:         "P74",
+		Description:         "P74-Output voltage of the electronic throttle's main position sensor",
 		CapabilityByteIndex: 40,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -1223,11 +1607,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 50, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(5.1)},
 	},
 	"P75": {
 		Id:                  "P75",
 		Name:                "Sub Accelerator Sensor",
-		Description:         "P75",
+		Description:         "P75-Output voltage of the accelerator pedal's sub position sensor",
 		CapabilityByteIndex: 40,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -1238,11 +1624,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 50, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(5.1)},
 	},
 	"P76": {
 		Id:                  "P76",
 		Name:                "Main Accelerator Sensor",
-		Description:         "P76",
+		Description:         "P76-Output voltage of the accelerator pedal's main position sensor",
 		CapabilityByteIndex: 40,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -1253,11 +1641,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 50, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(5.1)},
 	},
 	"P77": {
 		Id:                  "P77",
 		Name:                "Brake Booster Pressure",
-		Description:         "P77",
+		Description:         "P77-Pressure in the brake booster, measured by its pressure sensor",
 		CapabilityByteIndex: 40,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -1268,11 +1658,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.KPA}
 		},
 		DefaultUnit: units.KPA,
+		Category:    CategoryChassis,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P78": {
 		Id:                  "P78",
 		Name:                "Fuel Pressure (High)",
-		Description:         "P78",
+		Description:         "P78-Fuel pressure in the direct injection high pressure fuel system",
 		CapabilityByteIndex: 40,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -1283,6 +1675,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 25, units.MPA}
 		},
 		DefaultUnit: units.MPA,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(10.2)},
 	},
 	"P79": {
 		Id:                  "P79",
@@ -1298,11 +1692,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) + 40) * 5, units.C}
 		},
 		DefaultUnit: units.C,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(1000)},
+		Warning:     Range{Max: bound(900)},
 	},
 	"P80": {
 		Id:                  "P80",
 		Name:                "Cold Start Injector (Air Pump)",
-		Description:         "P80",
+		Description:         "P80-Pulse width of the cold start injector, or the secondary air pump on models with one",
 		CapabilityByteIndex: 41,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -1313,11 +1710,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 256, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(30000)},
 	},
 	"P81": {
 		Id:                  "P81",
 		Name:                "SCV Step",
-		Description:         "P81",
+		Description:         "P81-Position of the swirl control valve's stepper motor",
 		CapabilityByteIndex: 41,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -1328,11 +1727,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Steps}
 		},
 		DefaultUnit: units.Steps,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P82": {
 		Id:                  "P82",
 		Name:                "Memorised Cruise Speed",
-		Description:         "P82",
+		Description:         "P82-Speed set for the cruise control",
 		CapabilityByteIndex: 41,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -1343,11 +1745,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.KMH}
 		},
 		DefaultUnit: units.KMH,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P83": {
 		Id:                  "P83",
 		Name:                "Exhaust VVT Advance Angle Right",
-		Description:         "P83",
+		Description:         "P83-Exhaust camshaft advance of the right bank from the variable valve timing",
 		CapabilityByteIndex: 43,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -1358,11 +1762,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) - 50, units.Degress}
 		},
 		DefaultUnit: units.Degress,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(-50), Max: bound(50)},
 	},
 	"P84": {
 		Id:                  "P84",
 		Name:                "Exhaust VVT Advance Angle Left",
-		Description:         "P84",
+		Description:         "P84-Exhaust camshaft advance of the left bank from the variable valve timing",
 		CapabilityByteIndex: 43,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -1373,11 +1779,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) - 50, units.Degress}
 		},
 		DefaultUnit: units.Degress,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(-50), Max: bound(50)},
 	},
 	"P85": {
 		Id:                  "P85",
 		Name:                "Exhaust OCV Duty Right",
-		Description:         "P85",
+		Description:         "P85-Duty cycle of the right bank's exhaust oil control valve, which adjusts the exhaust cam timing",
 		CapabilityByteIndex: 43,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -1388,11 +1796,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 100 / 255, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P86": {
 		Id:                  "P86",
 		Name:                "Exhaust OCV Duty Left",
-		Description:         "P86",
+		Description:         "P86-Duty cycle of the left bank's exhaust oil control valve, which adjusts the exhaust cam timing",
 		CapabilityByteIndex: 43,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -1403,11 +1813,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 100 / 255, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P87": {
 		Id:                  "P87",
 		Name:                "Exhaust OCV Current Right",
-		Description:         "P87",
+		Description:         "P87-Current through the right bank's exhaust oil control valve",
 		CapabilityByteIndex: 43,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -1418,11 +1830,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 32, units.Milliamps}
 		},
 		DefaultUnit: units.Milliamps,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(2000)},
 	},
 	"P88": {
 		Id:                  "P88",
 		Name:                "Exhaust OCV Current Left",
-		Description:         "P88",
+		Description:         "P88-Current through the left bank's exhaust oil control valve",
 		CapabilityByteIndex: 43,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -1433,11 +1847,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 32, units.Milliamps}
 		},
 		DefaultUnit: units.Milliamps,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(2000)},
 	},
 	"P89": {
 		Id:                  "P89",
 		Name:                "A/F Correction #3 (32-bit ECU)",
-		Description:         "P89",
+		Description:         "P89-Fuel trim from the rear O2 sensor's feedback on 32-bit ECUs",
 		CapabilityByteIndex: 15,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -1448,11 +1864,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) * .078125) - 5, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(-5), Max: bound(15)},
 	},
 	"P90": {
 		Id:                  "P90",
 		Name:                "IAM",
-		Description:         "P90",
+		Description:         "P90-Ignition advance multiplier: the fraction of the ignition advance map the ECU applies. It's lowered after knock.",
 		CapabilityByteIndex: 55,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -1463,11 +1881,15 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 16, units.Multiplier}
 		},
 		DefaultUnit: units.Multiplier,
+		Format:      Format{Precision: 3},
+		Category:    CategoryIgnition,
+		Range:       Range{Min: bound(0), Max: bound(1)},
+		Warning:     Range{Min: bound(0.9)},
 	},
 	"P91": {
 		Id:                  "P91",
 		Name:                "Fine Learning Knock Correction",
-		Description:         "P91",
+		Description:         "P91-Knock correction learned for the current RPM and load cell of the fine learning table",
 		CapabilityByteIndex: 55,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -1478,11 +1900,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) * 0.25) - 32, units.Degress}
 		},
 		DefaultUnit: units.Degress,
+		Category:    CategoryIgnition,
+		Range:       Range{Min: bound(-20), Max: bound(20)},
 	},
 	"P92": {
 		Id:                  "P92",
 		Name:                "Radiator Fan Control",
-		Description:         "P92",
+		Description:         "P92-Duty cycle of the radiator fan",
 		CapabilityByteIndex: 12,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -1493,11 +1917,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P93": {
 		Id:                  "P93",
 		Name:                "Front Wheel Speed",
-		Description:         "P93",
+		Description:         "P93-Average speed of the front wheels",
 		CapabilityByteIndex: 16,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -1508,6 +1934,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.KMH}
 		},
 		DefaultUnit: units.KMH,
+		Category:    CategoryChassis,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P94": {
 		Id:                  "P94",
@@ -1523,11 +1951,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Index}
 		},
 		DefaultUnit: units.Index,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P95": {
 		Id:                  "P95",
 		Name:                "Line Pressure Duty Ratio",
-		Description:         "P95",
+		Description:         "P95-Duty ratio of the automatic transmission's line pressure solenoid",
 		CapabilityByteIndex: 16,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -1538,11 +1969,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 2, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P96": {
 		Id:                  "P96",
 		Name:                "Lock Up Duty Ratio",
-		Description:         "P96",
+		Description:         "P96-Duty ratio of the torque converter's lock up solenoid",
 		CapabilityByteIndex: 16,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -1553,11 +1986,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 2, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P97": {
 		Id:                  "P97",
 		Name:                "Transfer Duty Ratio",
-		Description:         "P97",
+		Description:         "P97-Duty ratio of the AWD transfer clutch solenoid",
 		CapabilityByteIndex: 16,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -1568,11 +2003,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 2, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P98": {
 		Id:                  "P98",
 		Name:                "Throttle Sensor Voltage",
-		Description:         "P98",
+		Description:         "P98-Throttle position sensor voltage read by the transmission control unit",
 		CapabilityByteIndex: 16,
 		CapabilityBitIndex:  1,
 		Address: &Address{
@@ -1583,11 +2020,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 45, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(5.7)},
 	},
 	"P99": {
 		Id:                  "P99",
 		Name:                "Turbine Revolution Speed",
-		Description:         "P99",
+		Description:         "P99-Speed of the torque converter's turbine",
 		CapabilityByteIndex: 16,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -1598,11 +2037,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 32, units.RPM}
 		},
 		DefaultUnit: units.RPM,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(8160)},
 	},
 	"P100": {
 		Id:                  "P100",
 		Name:                "Brake Clutch Duty Ratio",
-		Description:         "P100",
+		Description:         "P100-Duty ratio of the automatic transmission's brake clutch solenoid",
 		CapabilityByteIndex: 17,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -1613,11 +2055,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 2, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P101": {
 		Id:                  "P101",
 		Name:                "Rear Wheel Speed",
-		Description:         "P101",
+		Description:         "P101-Average speed of the rear wheels",
 		CapabilityByteIndex: 17,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -1628,11 +2072,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.KMH}
 		},
 		DefaultUnit: units.KMH,
+		Category:    CategoryChassis,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P102": {
 		Id:                  "P102",
 		Name:                "Manifold Pressure Sensor Voltage",
-		Description:         "P102",
+		Description:         "P102-Output voltage of the manifold pressure sensor",
 		CapabilityByteIndex: 17,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -1643,11 +2089,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 50, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(5.1)},
 	},
 	"P103": {
 		Id:                  "P103",
 		Name:                "Lateral G Sensor Voltage",
-		Description:         "P103",
+		Description:         "P103-Output voltage of the lateral G sensor",
 		CapabilityByteIndex: 17,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -1658,11 +2106,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 50, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryChassis,
+		Range:       Range{Min: bound(0), Max: bound(5.1)},
 	},
 	"P104": {
 		Id:                  "P104",
 		Name:                "ATF Temperature",
-		Description:         "P104",
+		Description:         "P104-Temperature of the automatic transmission fluid",
 		CapabilityByteIndex: 17,
 		CapabilityBitIndex:  1,
 		Address: &Address{
@@ -1673,11 +2123,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) - 50, units.C}
 		},
 		DefaultUnit: units.C,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(-40), Max: bound(150)},
+		Warning:     Range{Max: bound(110)},
 	},
 	"P105": {
 		Id:                  "P105",
 		Name:                "Low Clutch Duty",
-		Description:         "P105",
+		Description:         "P105-Duty ratio of the automatic transmission's low clutch solenoid",
 		CapabilityByteIndex: 17,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -1688,11 +2141,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 2, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P106": {
 		Id:                  "P106",
 		Name:                "High Clutch Duty",
-		Description:         "P106",
+		Description:         "P106-Duty ratio of the automatic transmission's high clutch solenoid",
 		CapabilityByteIndex: 18,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -1703,11 +2158,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 2, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P107": {
 		Id:                  "P107",
 		Name:                "Load and Reverse Brake (L and RB) Duty",
-		Description:         "P107",
+		Description:         "P107-Duty ratio of the automatic transmission's low and reverse brake solenoid",
 		CapabilityByteIndex: 18,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -1718,11 +2175,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 2, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P108": {
 		Id:                  "P108",
 		Name:                "ATF Temperature 2",
-		Description:         "P108",
+		Description:         "P108-Temperature of the automatic transmission fluid from the second sensor",
 		CapabilityByteIndex: 18,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -1733,11 +2192,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) - 50, units.C}
 		},
 		DefaultUnit: units.C,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(-40), Max: bound(150)},
 	},
 	"P109": {
 		Id:                  "P109",
 		Name:                "Voltage Center Differential Switch",
-		Description:         "P109",
+		Description:         "P109-Voltage of the center differential's mode switch",
 		CapabilityByteIndex: 18,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -1748,11 +2209,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 51, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(5)},
 	},
 	"P110": {
 		Id:                  "P110",
 		Name:                "AT Turbine Speed 1",
-		Description:         "P110",
+		Description:         "P110-Speed of the automatic transmission's turbine from the first sensor",
 		CapabilityByteIndex: 18,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -1763,11 +2226,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 32, units.RPM}
 		},
 		DefaultUnit: units.RPM,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(8160)},
 	},
 	"P111": {
 		Id:                  "P111",
 		Name:                "AT Turbine Speed 2",
-		Description:         "P111",
+		Description:         "P111-Speed of the automatic transmission's turbine from the second sensor",
 		CapabilityByteIndex: 18,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -1778,11 +2244,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 32, units.RPM}
 		},
 		DefaultUnit: units.RPM,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(8160)},
 	},
 	"P112": {
 		Id:                  "P112",
 		Name:                "Center Differential Real Current",
-		Description:         "P112",
+		Description:         "P112-Current through the center differential's clutch solenoid",
 		CapabilityByteIndex: 18,
 		CapabilityBitIndex:  1,
 		Address: &Address{
@@ -1793,11 +2262,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 32, units.Amps}
 		},
 		DefaultUnit: units.Amps,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(8)},
 	},
 	"P113": {
 		Id:                  "P113",
 		Name:                "Center Differential Indicate Current",
-		Description:         "P113",
+		Description:         "P113-Current commanded for the center differential's clutch solenoid",
 		CapabilityByteIndex: 18,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -1808,6 +2279,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 32, units.Amps}
 		},
 		DefaultUnit: units.Amps,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(8)},
 	},
 	"P114": {
 		Id:                  "P114",
@@ -1823,11 +2296,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Index}
 		},
 		DefaultUnit: units.Index,
+		Format:      Format{Kind: Enum, Labels: map[int]string{0: "---", 1: "S", 2: "S#", 3: "I", 8: "S#", 16: "I"}},
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(16)},
 	},
 	"P115": {
 		Id:                  "P115",
 		Name:                "Throttle Sensor Closed Voltage",
-		Description:         "P115",
+		Description:         "P115-Throttle position sensor voltage learned with the throttle closed",
 		CapabilityByteIndex: 38,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -1838,11 +2314,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 50, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(5.1)},
 	},
 	"P116": {
 		Id:                  "P116",
 		Name:                "Exhaust Gas Temperature 2",
-		Description:         "P116",
+		Description:         "P116-Exhaust gas temperature from the second sensor",
 		CapabilityByteIndex: 40,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -1853,11 +2331,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0])*5 + 200, units.C}
 		},
 		DefaultUnit: units.C,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(1000)},
 	},
 	"P117": {
 		Id:                  "P117",
 		Name:                "Air/Fuel Correction #4",
-		Description:         "P117",
+		Description:         "P117-Fuel trim #4 reported by 32-bit ECUs",
 		CapabilityByteIndex: 41,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -1868,11 +2348,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 64) / 128 * 10, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(-5), Max: bound(15)},
 	},
 	"P118": {
 		Id:                  "P118",
 		Name:                "Air/Fuel Learning #4",
-		Description:         "P118",
+		Description:         "P118-Fuel trim learned for A/F correction #4",
 		CapabilityByteIndex: 41,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -1883,11 +2365,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) / 128 * 100, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(-50), Max: bound(50)},
 	},
 	"P119": {
 		Id:                  "P119",
 		Name:                "Fuel Level Sensor Resistance",
-		Description:         "P119",
+		Description:         "P119-Resistance of the fuel level sender",
 		CapabilityByteIndex: 41,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -1898,6 +2382,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 4 / 2, units.Ohms}
 		},
 		DefaultUnit: units.Ohms,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0), Max: bound(510)},
 	},
 	"P120": {
 		Id:                  "P120",
@@ -1913,11 +2399,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)) * 2, units.Kilometers}
 		},
 		DefaultUnit: units.Kilometers,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0)},
 	},
 	"P121": {
 		Id:                  "P121",
 		Name:                "Fuel Tank Air Pressure",
-		Description:         "P121",
+		Description:         "P121-Air pressure in the fuel tank",
 		CapabilityByteIndex: 41,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -1928,11 +2416,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)) / 10, units.BAR}
 		},
 		DefaultUnit: units.BAR,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(0)},
 	},
 	"P122": {
 		Id:                  "P122",
 		Name:                "Oil Temperature",
-		Description:         "P122",
+		Description:         "P122-Engine oil temperature",
 		CapabilityByteIndex: 42,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -1943,11 +2433,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) - 40, units.C}
 		},
 		DefaultUnit: units.C,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(-40), Max: bound(150)},
+		Warning:     Range{Max: bound(130)},
 	},
 	"P123": {
 		Id:                  "P123",
 		Name:                "Oil Switching Solenoid Valve (OSV) Duty (Right)",
-		Description:         "P123",
+		Description:         "P123-Duty cycle of the right bank's oil switching solenoid valve, which switches the valve lift",
 		CapabilityByteIndex: 42,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -1958,11 +2451,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 255 * 100, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P124": {
 		Id:                  "P124",
 		Name:                "Oil Switching Solenoid Valve (OSV) Duty (Left)",
-		Description:         "P124",
+		Description:         "P124-Duty cycle of the left bank's oil switching solenoid valve, which switches the valve lift",
 		CapabilityByteIndex: 42,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -1973,11 +2468,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 255 * 100, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P125": {
 		Id:                  "P125",
 		Name:                "Oil Switching Solenoid Valve (OSV) Current (Right)",
-		Description:         "P125",
+		Description:         "P125-Current through the right bank's oil switching solenoid valve",
 		CapabilityByteIndex: 42,
 		CapabilityBitIndex:  1,
 		Address: &Address{
@@ -1988,11 +2485,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 32, units.Milliamps}
 		},
 		DefaultUnit: units.Milliamps,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(2000)},
 	},
 	"P126": {
 		Id:                  "P126",
 		Name:                "Oil Switching Solenoid Valve (OSV) Current (Left)",
-		Description:         "P126",
+		Description:         "P126-Current through the left bank's oil switching solenoid valve",
 		CapabilityByteIndex: 42,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -2003,11 +2502,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 32, units.Milliamps}
 		},
 		DefaultUnit: units.Milliamps,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(2000)},
 	},
 	"P127": {
 		Id:                  "P127",
 		Name:                "VVL Lift Mode",
-		Description:         "P127",
+		Description:         "P127-Valve lift mode selected by the variable valve lift system",
 		CapabilityByteIndex: 43,
 		CapabilityBitIndex:  1,
 		Address: &Address{
@@ -2018,11 +2519,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Raw}
 		},
 		DefaultUnit: units.Raw,
+		Format:      Format{Kind: Enum, Labels: map[int]string{0: "Low lift", 1: "High lift"}},
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P128": {
 		Id:                  "P128",
 		Name:                "H and LR/C Solenoid Valve Current",
-		Description:         "P128",
+		Description:         "P128-Current through the automatic transmission's high and low/reverse clutch solenoid valve",
 		CapabilityByteIndex: 50,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -2033,11 +2537,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 255, units.Amps}
 		},
 		DefaultUnit: units.Amps,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(1)},
 	},
 	"P129": {
 		Id:                  "P129",
 		Name:                "D/C Solenoid Valve Current",
-		Description:         "P129",
+		Description:         "P129-Current through the automatic transmission's direct clutch solenoid valve",
 		CapabilityByteIndex: 50,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -2048,11 +2554,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 255, units.Amps}
 		},
 		DefaultUnit: units.Amps,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(1)},
 	},
 	"P130": {
 		Id:                  "P130",
 		Name:                "F/B Solenoid Valve Current",
-		Description:         "P130",
+		Description:         "P130-Current through the automatic transmission's front brake solenoid valve",
 		CapabilityByteIndex: 50,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -2063,11 +2571,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 255, units.Amps}
 		},
 		DefaultUnit: units.Amps,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(1)},
 	},
 	"P131": {
 		Id:                  "P131",
 		Name:                "I/C Solenoid Valve Current",
-		Description:         "P131",
+		Description:         "P131-Current through the automatic transmission's input clutch solenoid valve",
 		CapabilityByteIndex: 50,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -2078,11 +2588,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 255, units.Amps}
 		},
 		DefaultUnit: units.Amps,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(1)},
 	},
 	"P132": {
 		Id:                  "P132",
 		Name:                "P/L Solenoid Valve Current",
-		Description:         "P132",
+		Description:         "P132-Current through the automatic transmission's line pressure solenoid valve",
 		CapabilityByteIndex: 50,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -2093,11 +2605,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 255, units.Amps}
 		},
 		DefaultUnit: units.Amps,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(1)},
 	},
 	"P133": {
 		Id:                  "P133",
 		Name:                "L/U Solenoid Valve Current",
-		Description:         "P133",
+		Description:         "P133-Current through the torque converter's lock up solenoid valve",
 		CapabilityByteIndex: 50,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -2108,11 +2622,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 255, units.Amps}
 		},
 		DefaultUnit: units.Amps,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(1)},
 	},
 	"P134": {
 		Id:                  "P134",
 		Name:                "AWD Solenoid Valve Current",
-		Description:         "P134",
+		Description:         "P134-Current through the AWD transfer clutch solenoid valve",
 		CapabilityByteIndex: 50,
 		CapabilityBitIndex:  1,
 		Address: &Address{
@@ -2123,11 +2639,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 255, units.Amps}
 		},
 		DefaultUnit: units.Amps,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(1)},
 	},
 	"P135": {
 		Id:                  "P135",
 		Name:                "Yaw Rate Sensor Voltage",
-		Description:         "P135",
+		Description:         "P135-Output voltage of the yaw rate sensor",
 		CapabilityByteIndex: 50,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -2138,11 +2656,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 51, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryChassis,
+		Range:       Range{Min: bound(0), Max: bound(5)},
 	},
 	"P136": {
 		Id:                  "P136",
 		Name:                "H and LR/C Solenoid Valve Pressure",
-		Description:         "P136",
+		Description:         "P136-Hydraulic pressure of the automatic transmission's high and low/reverse clutch solenoid valve",
 		CapabilityByteIndex: 51,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -2153,11 +2673,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 10, units.KPA}
 		},
 		DefaultUnit: units.KPA,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(2550)},
 	},
 	"P137": {
 		Id:                  "P137",
 		Name:                "D/C Solenoid Valve Pressure",
-		Description:         "P137",
+		Description:         "P137-Hydraulic pressure of the automatic transmission's direct clutch solenoid valve",
 		CapabilityByteIndex: 51,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -2168,11 +2690,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 10, units.KPA}
 		},
 		DefaultUnit: units.KPA,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(2550)},
 	},
 	"P138": {
 		Id:                  "P138",
 		Name:                "F/B Solenoid Valve Pressure",
-		Description:         "P138",
+		Description:         "P138-Hydraulic pressure of the automatic transmission's front brake solenoid valve",
 		CapabilityByteIndex: 51,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -2183,11 +2707,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 10, units.KPA}
 		},
 		DefaultUnit: units.KPA,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(2550)},
 	},
 	"P139": {
 		Id:                  "P139",
 		Name:                "I/C Solenoid Valve Pressure",
-		Description:         "P139",
+		Description:         "P139-Hydraulic pressure of the automatic transmission's input clutch solenoid valve",
 		CapabilityByteIndex: 51,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -2198,11 +2724,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 10, units.KPA}
 		},
 		DefaultUnit: units.KPA,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(2550)},
 	},
 	"P140": {
 		Id:                  "P140",
 		Name:                "P/L Solenoid Valve Pressure",
-		Description:         "P140",
+		Description:         "P140-Hydraulic pressure of the automatic transmission's line pressure solenoid valve",
 		CapabilityByteIndex: 51,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -2213,11 +2741,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 10, units.KPA}
 		},
 		DefaultUnit: units.KPA,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(2550)},
 	},
 	"P141": {
 		Id:                  "P141",
 		Name:                "L/U Solenoid Valve Pressure",
-		Description:         "P141",
+		Description:         "P141-Hydraulic pressure of the torque converter's lock up solenoid valve",
 		CapabilityByteIndex: 51,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -2228,11 +2758,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 10, units.KPA}
 		},
 		DefaultUnit: units.KPA,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(2550)},
 	},
 	"P142": {
 		Id:                  "P142",
 		Name:                "AWD Solenoid Valve Pressure",
-		Description:         "P142",
+		Description:         "P142-Hydraulic pressure of the AWD transfer clutch solenoid valve",
 		CapabilityByteIndex: 51,
 		CapabilityBitIndex:  1,
 		Address: &Address{
@@ -2243,11 +2775,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 10, units.KPA}
 		},
 		DefaultUnit: units.KPA,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(2550)},
 	},
 	"P143": {
 		Id:                  "P143",
 		Name:                "Yaw Rate and  G Sensor Reference Voltage",
-		Description:         "P143",
+		Description:         "P143-Reference voltage supplied to the yaw rate and G sensors",
 		CapabilityByteIndex: 51,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -2258,11 +2792,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 51, units.Volts}
 		},
 		DefaultUnit: units.Volts,
+		Category:    CategoryChassis,
+		Range:       Range{Min: bound(0), Max: bound(5)},
 	},
 	"P144": {
 		Id:                  "P144",
 		Name:                "Wheel Speed Front Right",
-		Description:         "P144",
+		Description:         "P144-Speed of the front right wheel",
 		CapabilityByteIndex: 52,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -2273,11 +2809,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.KMH}
 		},
 		DefaultUnit: units.KMH,
+		Category:    CategoryChassis,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P145": {
 		Id:                  "P145",
 		Name:                "Wheel Speed Front Left",
-		Description:         "P145",
+		Description:         "P145-Speed of the front left wheel",
 		CapabilityByteIndex: 52,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -2288,11 +2826,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.KMH}
 		},
 		DefaultUnit: units.KMH,
+		Category:    CategoryChassis,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P146": {
 		Id:                  "P146",
 		Name:                "Wheel Speed Rear Right",
-		Description:         "P146",
+		Description:         "P146-Speed of the rear right wheel",
 		CapabilityByteIndex: 52,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -2303,11 +2843,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.KMH}
 		},
 		DefaultUnit: units.KMH,
+		Category:    CategoryChassis,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P147": {
 		Id:                  "P147",
 		Name:                "Wheel Speed Rear Left",
-		Description:         "P147",
+		Description:         "P147-Speed of the rear left wheel",
 		CapabilityByteIndex: 52,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -2318,6 +2860,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.KMH}
 		},
 		DefaultUnit: units.KMH,
+		Category:    CategoryChassis,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P148": {
 		Id:                  "P148",
@@ -2333,11 +2877,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Degress}
 		},
 		DefaultUnit: units.Degress,
+		Category:    CategoryChassis,
+		Range:       Range{Min: bound(-720), Max: bound(720)},
 	},
 	"P149": {
 		Id:                  "P149",
 		Name:                "Fwd/B Solenoid Valve Current",
-		Description:         "P149",
+		Description:         "P149-Current through the automatic transmission's forward brake solenoid valve",
 		CapabilityByteIndex: 52,
 		CapabilityBitIndex:  1,
 		Address: &Address{
@@ -2348,11 +2894,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 255, units.Amps}
 		},
 		DefaultUnit: units.Amps,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(1)},
 	},
 	"P150": {
 		Id:                  "P150",
 		Name:                "Fwd/B Solenoid Valve Target Pressure",
-		Description:         "P150",
+		Description:         "P150-Hydraulic pressure targeted for the automatic transmission's forward brake solenoid valve",
 		CapabilityByteIndex: 52,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -2363,11 +2911,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 10, units.KPA}
 		},
 		DefaultUnit: units.KPA,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(2550)},
 	},
 	"P151": {
 		Id:                  "P151",
 		Name:                "Roughness Monitor Cylinder #5",
-		Description:         "P151",
+		Description:         "P151-Roughness (misfire) count of cylinder #5 monitored by the ECU",
 		CapabilityByteIndex: 55,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -2378,11 +2928,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.MisfireCount}
 		},
 		DefaultUnit: units.MisfireCount,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryIgnition,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P152": {
 		Id:                  "P152",
 		Name:                "Roughness Monitor Cylinder #6",
-		Description:         "P152",
+		Description:         "P152-Roughness (misfire) count of cylinder #6 monitored by the ECU",
 		CapabilityByteIndex: 55,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -2393,6 +2946,9 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.MisfireCount}
 		},
 		DefaultUnit: units.MisfireCount,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryIgnition,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P153": {
 		Id:                  "P153",
@@ -2408,11 +2964,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 16, units.Degress}
 		},
 		DefaultUnit: units.Degress,
+		Category:    CategoryIgnition,
+		Range:       Range{Min: bound(0), Max: bound(16)},
 	},
 	"P154": {
 		Id:                  "P154",
 		Name:                "Fuel Tank Pressure",
-		Description:         "P154",
+		Description:         "P154-Pressure in the fuel tank relative to atmospheric pressure, used to check the evaporative emissions system",
 		CapabilityByteIndex: 59,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -2423,11 +2981,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) / 2, units.HPA}
 		},
 		DefaultUnit: units.HPA,
+		Category:    CategoryFueling,
+		Range:       Range{Min: bound(-64), Max: bound(64)},
 	},
 	"P155": {
 		Id:                  "P155",
 		Name:                "Main Injection Period",
-		Description:         "P155",
+		Description:         "P155-Start of the main injection in degrees of crank rotation",
 		CapabilityByteIndex: 60,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -2438,11 +2998,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0])/5 - 15, units.DegreesCrankAngle}
 		},
 		DefaultUnit: units.DegreesCrankAngle,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-15), Max: bound(36)},
 	},
 	"P156": {
 		Id:                  "P156",
 		Name:                "Final Injection Amount",
-		Description:         "P156",
+		Description:         "P156-Volume of fuel injected per stroke after all corrections",
 		CapabilityByteIndex: 60,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -2453,11 +3015,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)) / 256, units.MM3PerStroke}
 		},
 		DefaultUnit: units.MM3PerStroke,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P157": {
 		Id:                  "P157",
 		Name:                "Number of Times Injected",
-		Description:         "P157",
+		Description:         "P157-Number of injections per combustion cycle",
 		CapabilityByteIndex: 60,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -2468,11 +3032,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Count}
 		},
 		DefaultUnit: units.Count,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(10)},
 	},
 	"P158": {
 		Id:                  "P158",
 		Name:                "Target Intake Manifold Pressure",
-		Description:         "P158",
+		Description:         "P158-Intake manifold pressure targeted by the boost control",
 		CapabilityByteIndex: 60,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -2483,11 +3050,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.KPA}
 		},
 		DefaultUnit: units.KPA,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P159": {
 		Id:                  "P159",
 		Name:                "Target Intake Air Amount",
-		Description:         "P159",
+		Description:         "P159-Intake air mass per cylinder targeted by the ECU",
 		CapabilityByteIndex: 60,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -2498,11 +3067,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 10, units.MGPerCylinder}
 		},
 		DefaultUnit: units.MGPerCylinder,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(2000)},
 	},
 	"P160": {
 		Id:                  "P160",
 		Name:                "Air Mass",
-		Description:         "P160",
+		Description:         "P160-Intake air mass per cylinder, measured by the MAF sensor",
 		CapabilityByteIndex: 60,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -2513,11 +3084,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 10, units.MGPerCylinder}
 		},
 		DefaultUnit: units.MGPerCylinder,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(2000)},
 	},
 	"P161": {
 		Id:                  "P161",
 		Name:                "Exhaust Gas Recirculation (EGR) Target Valve Opening Angle",
-		Description:         "P161",
+		Description:         "P161-Opening angle of the EGR valve targeted by the ECU",
 		CapabilityByteIndex: 60,
 		CapabilityBitIndex:  1,
 		Address: &Address{
@@ -2528,11 +3101,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) - 50, units.Degress}
 		},
 		DefaultUnit: units.Degress,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(90)},
 	},
 	"P162": {
 		Id:                  "P162",
 		Name:                "Exhaust Gas Recirculation (EGR) Valve Opening Angle",
-		Description:         "P162",
+		Description:         "P162-Opening angle of the EGR valve",
 		CapabilityByteIndex: 60,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -2543,11 +3118,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) - 50, units.Degress}
 		},
 		DefaultUnit: units.Degress,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(90)},
 	},
 	"P163": {
 		Id:                  "P163",
 		Name:                "Exhaust Gas Recirculation (EGR) Duty",
-		Description:         "P163",
+		Description:         "P163-Duty cycle of the EGR valve's actuator",
 		CapabilityByteIndex: 61,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -2558,11 +3135,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P164": {
 		Id:                  "P164",
 		Name:                "Common Rail Target Pressure",
-		Description:         "P164",
+		Description:         "P164-Common rail fuel pressure targeted by the ECU",
 		CapabilityByteIndex: 61,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -2573,11 +3152,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.MPA}
 		},
 		DefaultUnit: units.MPA,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(200)},
 	},
 	"P165": {
 		Id:                  "P165",
 		Name:                "Common Rail Pressure",
-		Description:         "P165",
+		Description:         "P165-Common rail fuel pressure",
 		CapabilityByteIndex: 61,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -2588,11 +3169,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.MPA}
 		},
 		DefaultUnit: units.MPA,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(200)},
 	},
 	"P166": {
 		Id:                  "P166",
 		Name:                "Intake Air Temperature (combined)",
-		Description:         "P166",
+		Description:         "P166-Intake air temperature combined from the intake air temperature sensors",
 		CapabilityByteIndex: 61,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -2603,11 +3186,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) - 40, units.C}
 		},
 		DefaultUnit: units.C,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-40), Max: bound(150)},
 	},
 	"P167": {
 		Id:                  "P167",
 		Name:                "Target Engine Speed",
-		Description:         "P167",
+		Description:         "P167-Engine speed targeted by the idle speed control",
 		CapabilityByteIndex: 61,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -2618,11 +3203,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)) / 4, units.RPM}
 		},
 		DefaultUnit: units.RPM,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(9000)},
 	},
 	"P168": {
 		Id:                  "P168",
 		Name:                "Boost Pressure Feedback",
-		Description:         "P168",
+		Description:         "P168-Boost control feedback correction",
 		CapabilityByteIndex: 61,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -2633,11 +3221,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) - 128, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-100), Max: bound(100)},
 	},
 	"P169": {
 		Id:                  "P169",
 		Name:                "Electric Power Steering Current",
-		Description:         "P169",
+		Description:         "P169-Current drawn by the electric power steering's motor",
 		CapabilityByteIndex: 61,
 		CapabilityBitIndex:  1,
 		Address: &Address{
@@ -2648,6 +3238,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Amps}
 		},
 		DefaultUnit: units.Amps,
+		Category:    CategoryChassis,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P170": {
 		Id:                  "P170",
@@ -2663,6 +3255,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)), units.Milliamps}
 		},
 		DefaultUnit: units.Milliamps,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(3000)},
 	},
 	"P171": {
 		Id:                  "P171",
@@ -2678,6 +3272,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 0.19118, units.DegreesPerSecond}
 		},
 		DefaultUnit: units.DegreesPerSecond,
+		Category:    CategoryChassis,
+		Range:       Range{Min: bound(-100), Max: bound(100)},
 	},
 	"P172": {
 		Id:                  "P172",
@@ -2693,11 +3289,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 1.0862, units.MetersPerSecondSquared}
 		},
 		DefaultUnit: units.MetersPerSecondSquared,
+		Category:    CategoryChassis,
+		Range:       Range{Min: bound(-20), Max: bound(20)},
 	},
 	"P173": {
 		Id:                  "P173",
 		Name:                "Drivers Control Center Differential (DCCD) Torque Allocation",
-		Description:         "P173",
+		Description:         "P173-Torque allocation of the driver's control center differential, as reported by the ECU",
 		CapabilityByteIndex: 62,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -2708,11 +3306,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Raw}
 		},
 		DefaultUnit: units.Raw,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P174": {
 		Id:                  "P174",
 		Name:                "Drivers Control Center Differential (DCCD) Mode",
-		Description:         "P174",
+		Description:         "P174-Mode of the driver's control center differential, as reported by the ECU",
 		CapabilityByteIndex: 62,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -2723,6 +3324,9 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Raw}
 		},
 		DefaultUnit: units.Raw,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P175": {
 		Id:                  "P175",
@@ -2738,11 +3342,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)), units.Milliamps}
 		},
 		DefaultUnit: units.Milliamps,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(3000)},
 	},
 	"P176": {
 		Id:                  "P176",
 		Name:                "Mileage after Injector Learning",
-		Description:         "P176",
+		Description:         "P176-Distance driven since the injectors were last learned",
 		CapabilityByteIndex: 63,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -2753,11 +3359,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)) * 5, units.Kilometers}
 		},
 		DefaultUnit: units.Kilometers,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0)},
 	},
 	"P177": {
 		Id:                  "P177",
 		Name:                "Mileage after Injector Replacement",
-		Description:         "P177",
+		Description:         "P177-Distance driven since the injectors were replaced",
 		CapabilityByteIndex: 63,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -2768,11 +3376,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)) * 5, units.Kilometers}
 		},
 		DefaultUnit: units.Kilometers,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0)},
 	},
 	"P178": {
 		Id:                  "P178",
 		Name:                "Interior Heater",
-		Description:         "P178",
+		Description:         "P178-Step of the auxiliary interior heater's heat output",
 		CapabilityByteIndex: 63,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -2783,11 +3393,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Steps}
 		},
 		DefaultUnit: units.Steps,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P179": {
 		Id:                  "P179",
 		Name:                "Quantity Correction Cylinder #1",
-		Description:         "P179",
+		Description:         "P179-Injection quantity correction for cylinder #1, learned for the injector",
 		CapabilityByteIndex: 63,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -2798,11 +3411,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 100) * 10, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-1000), Max: bound(1550)},
 	},
 	"P180": {
 		Id:                  "P180",
 		Name:                "Quantity Correction Cylinder #2",
-		Description:         "P180",
+		Description:         "P180-Injection quantity correction for cylinder #2, learned for the injector",
 		CapabilityByteIndex: 63,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -2813,11 +3428,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 100) * 10, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-1000), Max: bound(1550)},
 	},
 	"P181": {
 		Id:                  "P181",
 		Name:                "Quantity Correction Cylinder #3",
-		Description:         "P181",
+		Description:         "P181-Injection quantity correction for cylinder #3, learned for the injector",
 		CapabilityByteIndex: 63,
 		CapabilityBitIndex:  1,
 		Address: &Address{
@@ -2828,11 +3445,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 100) * 10, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-1000), Max: bound(1550)},
 	},
 	"P182": {
 		Id:                  "P182",
 		Name:                "Quantity Correction Cylinder #4",
-		Description:         "P182",
+		Description:         "P182-Injection quantity correction for cylinder #4, learned for the injector",
 		CapabilityByteIndex: 63,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -2843,11 +3462,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 100) * 10, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-1000), Max: bound(1550)},
 	},
 	"P183": {
 		Id:                  "P183",
 		Name:                "Battery Current",
-		Description:         "P183",
+		Description:         "P183-Current measured by the battery current sensor",
 		CapabilityByteIndex: 64,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -2858,11 +3479,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) - 128, units.Amps}
 		},
 		DefaultUnit: units.Amps,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(-128), Max: bound(128)},
 	},
 	"P184": {
 		Id:                  "P184",
 		Name:                "Battery Temperature",
-		Description:         "P184",
+		Description:         "P184-Temperature of the battery",
 		CapabilityByteIndex: 64,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -2873,6 +3496,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) - 40, units.C}
 		},
 		DefaultUnit: units.C,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(-40), Max: bound(80)},
 	},
 	"P185": {
 		Id:                  "P185",
@@ -2888,11 +3513,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Index}
 		},
 		DefaultUnit: units.Index,
+		Format:      Format{Kind: Enum, Labels: map[int]string{0: "High", 1: "ExHigh", 2: "Low", 3: "Mid"}},
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(3)},
 	},
 	"P186": {
 		Id:                  "P186",
 		Name:                "Cumulative Ash Ratio",
-		Description:         "P186",
+		Description:         "P186-Ash accumulated in the DPF relative to its capacity",
 		CapabilityByteIndex: 70,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -2903,11 +3531,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P187": {
 		Id:                  "P187",
 		Name:                "Pressure Difference between Diesel Particulate Filter (DPF) Inlet and Outlet",
-		Description:         "P187",
+		Description:         "P187-Pressure difference across the DPF, which rises as soot accumulates",
 		CapabilityByteIndex: 70,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -2918,11 +3548,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.KPA}
 		},
 		DefaultUnit: units.KPA,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P188": {
 		Id:                  "P188",
 		Name:                "Exhaust Gas Temperature at Catalyst Inlet",
-		Description:         "P188",
+		Description:         "P188-Exhaust gas temperature at the oxidation catalyst's inlet",
 		CapabilityByteIndex: 70,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -2933,11 +3565,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0])*5 - 40, units.C}
 		},
 		DefaultUnit: units.C,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-40), Max: bound(1000)},
 	},
 	"P189": {
 		Id:                  "P189",
 		Name:                "Exhaust Gas Temperature at Diesel Particulate Filter (DPF) Inlet",
-		Description:         "P189",
+		Description:         "P189-Exhaust gas temperature at the DPF's inlet",
 		CapabilityByteIndex: 70,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -2948,11 +3582,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0])*5 - 40, units.C}
 		},
 		DefaultUnit: units.C,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-40), Max: bound(1000)},
 	},
 	"P190": {
 		Id:                  "P190",
 		Name:                "Estimated Catalyst Temperature",
-		Description:         "P190",
+		Description:         "P190-Temperature of the oxidation catalyst estimated by the ECU",
 		CapabilityByteIndex: 70,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -2963,11 +3599,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0])*5 - 40, units.C}
 		},
 		DefaultUnit: units.C,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-40), Max: bound(1000)},
 	},
 	"P191": {
 		Id:                  "P191",
 		Name:                "Estimated Temperature of the Diesel Particulate Filter (DPF)",
-		Description:         "P191",
+		Description:         "P191-Temperature of the DPF estimated by the ECU",
 		CapabilityByteIndex: 70,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -2978,11 +3616,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0])*5 - 40, units.C}
 		},
 		DefaultUnit: units.C,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-40), Max: bound(1000)},
 	},
 	"P192": {
 		Id:                  "P192",
 		Name:                "Soot Accumulation Ratio",
-		Description:         "P192",
+		Description:         "P192-Soot accumulated in the DPF relative to the amount that starts a regeneration",
 		CapabilityByteIndex: 70,
 		CapabilityBitIndex:  1,
 		Address: &Address{
@@ -2993,11 +3633,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(200)},
 	},
 	"P193": {
 		Id:                  "P193",
 		Name:                "Oil Dilution Ratio",
-		Description:         "P193",
+		Description:         "P193-Fuel diluted into the engine oil relative to its limit",
 		CapabilityByteIndex: 70,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -3008,11 +3650,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P194": {
 		Id:                  "P194",
 		Name:                "Front-Rear Wheel Rotation Ratio",
-		Description:         "P194",
+		Description:         "P194-Ratio of the front wheels' speed to the rear wheels' speed",
 		CapabilityByteIndex: 71,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -3023,11 +3667,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 128, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryChassis,
+		Range:       Range{Min: bound(0), Max: bound(2)},
 	},
 	"P195": {
 		Id:                  "P195",
 		Name:                "ABS/VDC Front Wheel Mean Wheel Speed",
-		Description:         "P195",
+		Description:         "P195-Mean speed of the front wheels from the ABS/VDC unit",
 		CapabilityByteIndex: 71,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -3038,11 +3684,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 143 / 255, units.MPH}
 		},
 		DefaultUnit: units.MPH,
+		Category:    CategoryChassis,
+		Range:       Range{Min: bound(0), Max: bound(160)},
 	},
 	"P196": {
 		Id:                  "P196",
 		Name:                "ABS/VDC Rear Wheel Mean Wheel Speed",
-		Description:         "P196",
+		Description:         "P196-Mean speed of the rear wheels from the ABS/VDC unit",
 		CapabilityByteIndex: 71,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -3053,11 +3701,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 143 / 255, units.MPH}
 		},
 		DefaultUnit: units.MPH,
+		Category:    CategoryChassis,
+		Range:       Range{Min: bound(0), Max: bound(160)},
 	},
 	"P197": {
 		Id:                  "P197",
 		Name:                "Automatic Transmission Fluid (ATF) Deterioration Degree",
-		Description:         "P197",
+		Description:         "P197-Deterioration of the automatic transmission fluid estimated by the transmission control unit",
 		CapabilityByteIndex: 71,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -3068,11 +3718,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)) * 40 / 13107, units.Percent}
 		},
 		DefaultUnit: units.Percent,
+		Category:    CategoryTransmission,
+		Range:       Range{Min: bound(0), Max: bound(100)},
 	},
 	"P198": {
 		Id:                  "P198",
 		Name:                "Accumulated Count of Overspeed Instances (Very High RPM)",
-		Description:         "P198",
+		Description:         "P198-Number of times the engine speed went over the very high RPM threshold",
 		CapabilityByteIndex: 72,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -3083,11 +3735,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Time}
 		},
 		DefaultUnit: units.Time,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P199": {
 		Id:                  "P199",
 		Name:                "Accumulated Count of Overspeed Instances (High RPM)",
-		Description:         "P199",
+		Description:         "P199-Number of times the engine speed went over the high RPM threshold",
 		CapabilityByteIndex: 72,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -3098,11 +3752,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.Time}
 		},
 		DefaultUnit: units.Time,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P204": {
 		Id:                  "P204",
 		Name:                "Actual Common Rail Pressure (Time Synchronized)",
-		Description:         "P204",
+		Description:         "P204-Common rail fuel pressure sampled in sync with the injections",
 		CapabilityByteIndex: 72,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -3113,11 +3769,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.MPA}
 		},
 		DefaultUnit: units.MPA,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(200)},
 	},
 	"P205": {
 		Id:                  "P205",
 		Name:                "Estimated Distance to Oil Change",
-		Description:         "P205",
+		Description:         "P205-Distance left until the oil change the ECU estimates is due",
 		CapabilityByteIndex: 72,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -3128,11 +3786,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 62, units.Miles}
 		},
 		DefaultUnit: units.Miles,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0)},
 	},
 	"P206": {
 		Id:                  "P206",
 		Name:                "Running Distance since last Diesel Particulate Filter (DPF) Regeneration",
-		Description:         "P206",
+		Description:         "P206-Distance driven since the last DPF regeneration",
 		CapabilityByteIndex: 72,
 		CapabilityBitIndex:  3,
 		Address: &Address{
@@ -3143,11 +3803,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)), units.Kilometers}
 		},
 		DefaultUnit: units.Kilometers,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0)},
 	},
 	"P207": {
 		Id:                  "P207",
 		Name:                "Diesel Particulate Filter (DPF) Regeneration Count",
-		Description:         "P207",
+		Description:         "P207-Number of DPF regenerations",
 		CapabilityByteIndex: 72,
 		CapabilityBitIndex:  2,
 		Address: &Address{
@@ -3158,6 +3820,9 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)), units.Times}
 		},
 		DefaultUnit: units.Times,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0)},
 	},
 	"P208": {
 		Id:                  "P208",
@@ -3173,6 +3838,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P209": {
 		Id:                  "P209",
@@ -3188,6 +3855,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P210": {
 		Id:                  "P210",
@@ -3203,6 +3872,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P211": {
 		Id:                  "P211",
@@ -3218,6 +3889,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P212": {
 		Id:                  "P212",
@@ -3233,6 +3906,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P213": {
 		Id:                  "P213",
@@ -3248,6 +3923,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P214": {
 		Id:                  "P214",
@@ -3263,6 +3940,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P215": {
 		Id:                  "P215",
@@ -3278,6 +3957,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P216": {
 		Id:                  "P216",
@@ -3293,6 +3974,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P217": {
 		Id:                  "P217",
@@ -3308,6 +3991,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P218": {
 		Id:                  "P218",
@@ -3323,6 +4008,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P219": {
 		Id:                  "P219",
@@ -3338,6 +4025,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P220": {
 		Id:                  "P220",
@@ -3353,6 +4042,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P221": {
 		Id:                  "P221",
@@ -3368,6 +4059,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P222": {
 		Id:                  "P222",
@@ -3383,6 +4076,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P223": {
 		Id:                  "P223",
@@ -3398,6 +4093,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P224": {
 		Id:                  "P224",
@@ -3413,6 +4110,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P225": {
 		Id:                  "P225",
@@ -3428,6 +4127,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P226": {
 		Id:                  "P226",
@@ -3443,6 +4144,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P227": {
 		Id:                  "P227",
@@ -3458,11 +4161,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{(float32(v[0]) - 128) * 5, units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-640), Max: bound(640)},
 	},
 	"P228": {
 		Id:                  "P228",
 		Name:                "Individual Pump Difference Learning Value",
-		Description:         "P228",
+		Description:         "P228-Learned difference of the fuel pump's control current from its nominal current",
 		CapabilityByteIndex: 76,
 		CapabilityBitIndex:  5,
 		Address: &Address{
@@ -3473,11 +4178,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)) - 1000, units.Milliamps}
 		},
 		DefaultUnit: units.Milliamps,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-1000), Max: bound(1000)},
 	},
 	"P229": {
 		Id:                  "P229",
 		Name:                "Final Main Injection Period",
-		Description:         "P229",
+		Description:         "P229-Duration of the main injection after all corrections",
 		CapabilityByteIndex: 76,
 		CapabilityBitIndex:  4,
 		Address: &Address{
@@ -3488,11 +4195,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)), units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(10000)},
 	},
 	"P233": {
 		Id:                  "P233",
 		Name:                "Pre-Injection Final Period",
-		Description:         "P233-Diesel parameter",
+		Description:         "P233-Duration of the pilot (pre-) injection after all corrections",
 		CapabilityByteIndex: 60,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -3503,11 +4212,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)), units.US}
 		},
 		DefaultUnit: units.US,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(5000)},
 	},
 	"P234": {
 		Id:                  "P234",
 		Name:                "Pre-Injection Amount",
-		Description:         "P234-Volume",
+		Description:         "P234-Volume of fuel injected per stroke by the pilot (pre-) injection",
 		CapabilityByteIndex: 60,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -3518,6 +4229,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v))/256 - 30, units.MM3PerStroke}
 		},
 		DefaultUnit: units.MM3PerStroke,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(20)},
 	},
 	"P235": {
 		Id:                  "P235",
@@ -3533,6 +4246,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) / 50, units.DegreesCrankAngle}
 		},
 		DefaultUnit: units.DegreesCrankAngle,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(5.1)},
 	},
 	"P236": {
 		Id:                  "P236",
@@ -3548,6 +4263,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]) * 5, units.Grams}
 		},
 		DefaultUnit: units.Grams,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(0), Max: bound(1275)},
 	},
 	"P238": {
 		Id:                  "P238",
@@ -3563,6 +4280,8 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)) - 50, units.Nm}
 		},
 		DefaultUnit: units.Nm,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-50), Max: bound(500)},
 	},
 	"P239": {
 		Id:                  "P239",
@@ -3578,11 +4297,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{0 - float32(v[0]), units.Degress}
 		},
 		DefaultUnit: units.Degress,
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-255), Max: bound(0)},
 	},
 	"P240": {
 		Id:                  "P240",
 		Name:                "Engine Idle Speed User Adjustment (A/C off)",
-		Description:         "P240-This is the fixed amount of idle speed adjustmnet while the A/C is off - set by the user",
+		Description:         "P240-Fixed amount of idle speed adjustment while the A/C is off - set by the user",
 		CapabilityByteIndex: 8,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -3593,11 +4314,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0])*25 - 3200, units.RPM}
 		},
 		DefaultUnit: units.RPM,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-3200), Max: bound(3200)},
 	},
 	"P241": {
 		Id:                  "P241",
 		Name:                "Engine Idle Speed User Adjustment (A/C on)",
-		Description:         "P241-This is the fixed amount of idle speed adjustmnet while the A/C is on - set by the user",
+		Description:         "P241-Fixed amount of idle speed adjustment while the A/C is on - set by the user",
 		CapabilityByteIndex: 8,
 		CapabilityBitIndex:  0,
 		Address: &Address{
@@ -3608,11 +4332,14 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0])*25 - 3200, units.RPM}
 		},
 		DefaultUnit: units.RPM,
+		Format:      Format{Kind: Integer},
+		Category:    CategoryDiesel,
+		Range:       Range{Min: bound(-3200), Max: bound(3200)},
 	},
 	"P244": {
 		Id:                  "P244",
 		Name:                "Secondary Air Piping Pressure",
-		Description:         "P244",
+		Description:         "P244-Pressure in the secondary air injection piping, used to check the secondary air system",
 		CapabilityByteIndex: 41,
 		CapabilityBitIndex:  7,
 		Address: &Address{
@@ -3623,11 +4350,13 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(v[0]), units.KPA}
 		},
 		DefaultUnit: units.KPA,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(255)},
 	},
 	"P245": {
 		Id:                  "P245",
 		Name:                "Secondary Air Flow",
-		Description:         "P245",
+		Description:         "P245-Air flow from the secondary air pump during cold starts",
 		CapabilityByteIndex: 41,
 		CapabilityBitIndex:  6,
 		Address: &Address{
@@ -3638,17 +4367,24 @@ var Parameters = map[string]Parameter{
 			return ParameterValue{float32(binary.BigEndian.Uint16(v)) / 100, units.GS}
 		},
 		DefaultUnit: units.GS,
+		Category:    CategoryEngine,
+		Range:       Range{Min: bound(0), Max: bound(50)},
 	},
 }
 
+// DerivedParameters defines the parameters calculated from other parameters. Use Catalog
+// to read it while user-defined parameters may be registered.
 var DerivedParameters = map[string]DerivedParameter{
 	"P200": {
 		Id:                  "P200",
 		Name:                "Engine Load (Calculated)",
 		Description:         "P200-Engine load as calculated from MAF and RPM.",
 		DefaultUnit:         units.GramsPerRev,
+		Format:              Format{Precision: 3},
+		Category:            CategoryEngine,
+		Range:               Range{Min: bound(0), Max: bound(5)},
 		DependsOnParameters: []string{"P8", "P12"},
-		Value: func(params map[string]ParameterValue) (*ParameterValue, error) {
+		Value: func(params map[string]ParameterValue, v Vehicle) (*ParameterValue, error) {
 			return &ParameterValue{((params["P12"].Value) * 60) / (params["P8"].Value), units.GramsPerRev}, nil
 		},
 	},
@@ -3657,9 +4393,12 @@ var DerivedParameters = map[string]DerivedParameter{
 		Name:                "Injector Duty Cycle",
 		Description:         "P201-IDC as calculated from RPM and injector PW.",
 		DefaultUnit:         units.Percent,
+		Category:            CategoryFueling,
+		Range:               Range{Min: bound(0), Max: bound(100)},
+		Warning:             Range{Max: bound(85)},
 		DependsOnParameters: []string{"P8", "P21"},
-		Value: func(params map[string]ParameterValue) (*ParameterValue, error) {
-			return &ParameterValue{((params["P8"].Value) * (params["P21"].SafeConvertTo(units.US).Value)) / 1200, units.Percent}, nil
+		Value: func(params map[string]ParameterValue, v Vehicle) (*ParameterValue, error) {
+			return &ParameterValue{injectorDutyCycle(params["P8"], params["P21"]), units.Percent}, nil
 		},
 	},
 	"P202": {
@@ -3667,8 +4406,10 @@ var DerivedParameters = map[string]DerivedParameter{
 		Name:                "Manifold Relative Pressure (Corrected)",
 		Description:         "P202-Difference between Manifold Absolute Pressure and Atmospheric Pressure.",
 		DefaultUnit:         units.PSI,
+		Category:            CategoryEngine,
+		Range:               Range{Min: bound(-15), Max: bound(30)},
 		DependsOnParameters: []string{"P7", "P24"},
-		Value: func(params map[string]ParameterValue) (*ParameterValue, error) {
+		Value: func(params map[string]ParameterValue, v Vehicle) (*ParameterValue, error) {
 			return &ParameterValue{(params["P7"].SafeConvertTo(units.KPA).Value) - (params["P24"].SafeConvertTo(units.KPA).Value), units.PSI}, nil
 		},
 	},
@@ -3677,39 +4418,50 @@ var DerivedParameters = map[string]DerivedParameter{
 		Name:                "Fuel Consumption (Est.)",
 		Description:         "P203-Estimated fuel consumption based on MAF, AFR and vehicle speed.",
 		DefaultUnit:         units.MPGUS,
+		Category:            CategoryFueling,
+		Range:               Range{Min: bound(0), Max: bound(100)},
 		DependsOnParameters: []string{"P9", "P12", "P58"},
-		Value: func(params map[string]ParameterValue) (*ParameterValue, error) {
-			return &ParameterValue{((params["P9"].SafeConvertTo(units.KMH).Value) / 3600) / (((params["P12"].Value) / (params["P58"].SafeConvertTo(units.Lambda).Value)) / 2880), units.MPGUS}, nil
+		Value: func(params map[string]ParameterValue, v Vehicle) (*ParameterValue, error) {
+			// fuel flow (L/s) = MAF (g/s) / (lambda * stoich AFR) / fuel density (g/L)
+			fuelFlow := (params["P12"].Value) / ((params["P58"].SafeConvertTo(units.Lambda).Value) * v.Fuel.Stoich()) / v.Fuel.Density()
+			kmPerL := ((params["P9"].SafeConvertTo(units.KMH).Value) / 3600) / fuelFlow
+			return &ParameterValue{kmPerL * mpgUSPerKMPerL, units.MPGUS}, nil
 		},
 	},
 	"P230": {
 		Id:                  "P230",
 		Name:                "Final Injection Amount (Fuel Temperature Corrected)",
-		Description:         "P230",
+		Description:         "P230-Final injection amount converted to mass using the diesel density at the fuel temperature (835 kg/m3 at 15C)",
 		DefaultUnit:         units.MGPerCylinder,
+		Category:            CategoryDiesel,
+		Range:               Range{Min: bound(0), Max: bound(100)},
 		DependsOnParameters: []string{"P156", "P31"},
-		Value: func(params map[string]ParameterValue) (*ParameterValue, error) {
+		Value: func(params map[string]ParameterValue, v Vehicle) (*ParameterValue, error) {
 			return &ParameterValue{(params["P156"].Value) * (835 - (0.7 * ((params["P31"].SafeConvertTo(units.C).Value) - 15))) / 1000, units.MGPerCylinder}, nil
 		},
 	},
 	"P231": {
 		Id:                  "P231",
 		Name:                "Angle of Main Injection",
-		Description:         "P231",
+		Description:         "P231-Duration of the main injection converted to degrees of crank rotation at the current engine speed",
 		DefaultUnit:         units.DegreesCrankAngle,
+		Category:            CategoryDiesel,
+		Range:               Range{Min: bound(0), Max: bound(60)},
 		DependsOnParameters: []string{"P229", "P8"},
-		Value: func(params map[string]ParameterValue) (*ParameterValue, error) {
+		Value: func(params map[string]ParameterValue, v Vehicle) (*ParameterValue, error) {
 			return &ParameterValue{(params["P229"].SafeConvertTo(units.US).Value) / (2777.77 / ((params["P8"].Value) / 60)), units.DegreesCrankAngle}, nil
 		},
 	},
 	"P232": {
 		Id:                  "P232",
 		Name:                "Lambda (Smoke Behaviour)",
-		Description:         "P232",
+		Description:         "P232-Lambda calculated from the air mass and the corrected injection amount, used to judge smoke. Lower values mean more smoke.",
 		DefaultUnit:         units.Lambda,
+		Category:            CategoryDiesel,
+		Range:               Range{Min: bound(0), Max: bound(10)},
 		DependsOnParameters: []string{"P160", "P230"},
-		Value: func(params map[string]ParameterValue) (*ParameterValue, error) {
-			return &ParameterValue{((params["P160"].Value) / (params["P230"].Value)) / 14.7, units.Lambda}, nil
+		Value: func(params map[string]ParameterValue, v Vehicle) (*ParameterValue, error) {
+			return &ParameterValue{((params["P160"].Value) / (params["P230"].Value)) / v.Fuel.Stoich(), units.Lambda}, nil
 		},
 	},
 	"P237": {
@@ -3717,29 +4469,137 @@ var DerivedParameters = map[string]DerivedParameter{
 		Name:                "Air mass/charge pressure coefficient (TD)",
 		Description:         "P237-Coefficient for determining the turbocharger efficiency",
 		DefaultUnit:         units.Coefficient,
+		Category:            CategoryDiesel,
+		Range:               Range{Min: bound(0)},
 		DependsOnParameters: []string{"P160", "P7"},
-		Value: func(params map[string]ParameterValue) (*ParameterValue, error) {
+		Value: func(params map[string]ParameterValue, v Vehicle) (*ParameterValue, error) {
 			return &ParameterValue{(params["P160"].Value) / (params["P7"].SafeConvertTo(units.KPA).Value), units.Coefficient}, nil
 		},
 	},
 	"P242": {
 		Id:                  "P242",
-		Name:                "Volumetric Efficiency 2.0L (Calculated)",
-		Description:         "P242-VE calculated from IGL, MMA, MAF, IAT, absolute manifold pressure, assuming engine displacement of 122.04 CID (EJ207)",
+		Name:                "Volumetric Efficiency (Calculated)",
+		Description:         "P242-VE calculated from IGL, MMA, MAF, IAT, absolute manifold pressure, and the vehicle's engine displacement",
 		DefaultUnit:         units.Percent,
+		Category:            CategoryEngine,
+		Range:               Range{Min: bound(0), Max: bound(150)},
 		DependsOnParameters: []string{"P200", "P11", "P7"},
-		Value: func(params map[string]ParameterValue) (*ParameterValue, error) {
-			return &ParameterValue{((params["P200"].Value) * 2 * 8.314472 * ((params["P11"].SafeConvertTo(units.C).Value) + 273.15)) / ((params["P7"].SafeConvertTo(units.KPA).Value) * 2.0 * 28.97) * 100, units.Percent}, nil
+		Value: func(params map[string]ParameterValue, v Vehicle) (*ParameterValue, error) {
+			return &ParameterValue{((params["P200"].Value) * revolutionsPerCycle * 8.314472 * ((params["P11"].SafeConvertTo(units.C).Value) + 273.15)) / ((params["P7"].SafeConvertTo(units.KPA).Value) * v.Displacement * 28.97) * 100, units.Percent}, nil
+		},
+	},
+	"P246": {
+		Id:                  "P246",
+		Name:                "Engine Speed Rate of Change (Calculated)",
+		Description:         "P246-Rate of change of the engine speed",
+		DefaultUnit:         units.RPMPerSecond,
+		Category:            CategoryEngine,
+		Range:               Range{Min: bound(-10000), Max: bound(10000)},
+		DependsOnParameters: []string{"P8"},
+		NewCalculator:       Derivative("P8", units.RPMPerSecond),
+	},
+	"P247": {
+		Id:                  "P247",
+		Name:                "Boost Rise Rate (Calculated)",
+		Description:         "P247-Rate of change of the manifold relative pressure",
+		DefaultUnit:         units.KPAPerSecond,
+		Category:            CategoryEngine,
+		Range:               Range{Min: bound(-500), Max: bound(500)},
+		DependsOnParameters: []string{"P25"},
+		NewCalculator:       Derivative("P25", units.KPAPerSecond),
+	},
+	"P248": {
+		Id:                  "P248",
+		Name:                "Distance Travelled (Calculated)",
+		Description:         "P248-Distance travelled since logging started, calculated from vehicle speed",
+		DefaultUnit:         units.Kilometers,
+		Category:            CategoryTransmission,
+		Range:               Range{Min: bound(0)},
+		DependsOnParameters: []string{"P9"},
+		NewCalculator:       Integral("P9", 1.0/3600, units.Kilometers), // km/h * s -> km
+	},
+	"P249": {
+		Id:                  "P249",
+		Name:                "Fuel Used (Calculated)",
+		Description:         "P249-Fuel used since logging started, calculated from the fuel flow",
+		DefaultUnit:         units.Liters,
+		Category:            CategoryFueling,
+		Range:               Range{Min: bound(0)},
+		DependsOnParameters: []string{"P255"},
+		NewCalculator:       Integral("P255", 1.0/60/1000, units.Liters), // cc/min * s -> L
+	},
+	"P250": {
+		Id:                  "P250",
+		Name:                "A/F Sensor #1 Smoothed (Calculated)",
+		Description:         "P250-Exponential moving average of A/F sensor #1 with a 0.5s time constant",
+		DefaultUnit:         units.Lambda,
+		Format:              Format{Precision: 3},
+		Category:            CategoryFueling,
+		Range:               Range{Min: bound(0.5), Max: bound(1.5)},
+		DependsOnParameters: []string{"P58"},
+		NewCalculator:       EMA("P58", time.Second/2),
+	},
+	"P251": {
+		Id:                  "P251",
+		Name:                "Peak Boost (Calculated)",
+		Description:         "P251-Highest manifold relative pressure over the last 5 seconds",
+		DefaultUnit:         units.KPA,
+		Category:            CategoryEngine,
+		Range:               Range{Min: bound(-101.3), Max: bound(200)},
+		DependsOnParameters: []string{"P25"},
+		NewCalculator:       WindowMax("P25", time.Second*5),
+	},
+	"P252": {
+		Id:                  "P252",
+		Name:                "Richest A/F Sensor #1 (Calculated)",
+		Description:         "P252-Lowest A/F sensor #1 value over the last 5 seconds",
+		DefaultUnit:         units.Lambda,
+		Format:              Format{Precision: 3},
+		Category:            CategoryFueling,
+		Range:               Range{Min: bound(0.5), Max: bound(1.5)},
+		DependsOnParameters: []string{"P58"},
+		NewCalculator:       WindowMin("P58", time.Second*5),
+	},
+	"P253": {
+		Id:                  "P253",
+		Name:                "Time at Wide Open Throttle (Calculated)",
+		Description:         "P253-Time spent with the throttle opened at least 80% since logging started",
+		DefaultUnit:         units.S,
+		Category:            CategoryEngine,
+		Range:               Range{Min: bound(0)},
+		DependsOnParameters: []string{"P13"},
+		NewCalculator: TimeIn(func(params map[string]ParameterValue) bool {
+			return params["P13"].Value >= wideOpenThrottle
+		}),
+	},
+	"P254": {
+		Id:                  "P254",
+		Name:                "Gear Position (Calculated)",
+		Description:         "P254-Gear calculated from RPM, vehicle speed, and the vehicle's tire size, gear ratios, and final drive. 0 when stopped.",
+		DefaultUnit:         units.Gear,
+		Format:              Format{Kind: Enum, Labels: calculatedGearLabels},
+		Category:            CategoryTransmission,
+		Range:               Range{Min: bound(0), Max: bound(6)},
+		DependsOnParameters: []string{"P8", "P9"},
+		Value: func(params map[string]ParameterValue, v Vehicle) (*ParameterValue, error) {
+			gear, err := v.Gear(params["P8"].Value, params["P9"].SafeConvertTo(units.KMH).Value)
+			if err != nil {
+				return nil, err
+			}
+			return &ParameterValue{float32(gear), units.Gear}, nil
 		},
 	},
-	"P243": {
-		Id:                  "P243",
-		Name:                "Volumetric Efficiency 2.5L (Calculated)",
-		Description:         "P243-VE calculated from IGL, MMA, MAF, IAT, absolute manifold pressure, assuming engine displacement of 149.9 CID (EJ257)",
-		DefaultUnit:         units.Percent,
-		DependsOnParameters: []string{"P200", "P11", "P7"},
-		Value: func(params map[string]ParameterValue) (*ParameterValue, error) {
-			return &ParameterValue{((params["P200"].Value) * 2 * 8.314472 * ((params["P11"].SafeConvertTo(units.C).Value) + 273.15)) / ((params["P7"].SafeConvertTo(units.KPA).Value) * 2.5 * 28.97) * 100, units.Percent}, nil
+	"P255": {
+		Id:                  "P255",
+		Name:                "Fuel Flow (Calculated)",
+		Description:         "P255-Total fuel flow calculated from IDC and the vehicle's injector size and cylinder count.",
+		DefaultUnit:         units.CCPerMinute,
+		Category:            CategoryFueling,
+		Range:               Range{Min: bound(0), Max: bound(5000)},
+		DependsOnParameters: []string{"P8", "P21"},
+		Value: func(params map[string]ParameterValue, v Vehicle) (*ParameterValue, error) {
+			idc := injectorDutyCycle(params["P8"], params["P21"])
+			return &ParameterValue{idc / 100 * v.InjectorSize * float32(v.Cylinders), units.CCPerMinute}, nil
 		},
 	},
 }
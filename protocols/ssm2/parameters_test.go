@@ -53,6 +53,51 @@ func TestAddress_Add(t *testing.T) {
 	}
 }
 
+func TestParseAddress(t *testing.T) {
+	for s, want := range map[string][3]byte{
+		"FF6B1C":   {0xFF, 0x6B, 0x1C},
+		"0xff6b1c": {0xFF, 0x6B, 0x1C},
+		"1C":       {0x00, 0x00, 0x1C},
+	} {
+		got, err := ssm2.ParseAddress(s)
+		if err != nil {
+			t.Fatalf("ParseAddress(%q): %v", s, err)
+		}
+		if got != want {
+			t.Fatalf("ParseAddress(%q) = %X, want %X", s, got, want)
+		}
+	}
+
+	for _, s := range []string{"", "0x", "1FF6B1C", "FF6B1G"} {
+		if _, err := ssm2.ParseAddress(s); err == nil {
+			t.Fatalf("expected an error for %q", s)
+		}
+	}
+}
+
+func TestECU_UpdateCustomParameters(t *testing.T) {
+	custom := ssm2.Parameter{Id: "boost_target", Address: &ssm2.Address{Address: [3]byte{0xFF, 0x6B, 0x1C}, Length: 1}, Custom: true}
+	ssm2.Parameters[custom.Id] = custom
+	defer delete(ssm2.Parameters, custom.Id)
+
+	ecu := &ssm2.ECU{SupportedParameters: []ssm2.Parameter{
+		ssm2.Parameters["P8"],
+		{Id: "removed", Custom: true},
+	}}
+	ecu.UpdateCustomParameters()
+
+	var ids []string
+	for _, p := range ecu.SupportedParameters {
+		ids = append(ids, p.Id)
+	}
+	if !reflect.DeepEqual(ids, []string{"P8", "boost_target"}) {
+		t.Fatalf("want the built-in and current custom parameters. got: %v", ids)
+	}
+	if len(ecu.SupportedDerivedParameters) == 0 {
+		t.Fatal("want the supported derived parameters updated")
+	}
+}
+
 func TestParameterValue_ConvertTo(t *testing.T) {
 	type fields struct {
 		Value float32
@@ -76,7 +121,7 @@ func TestParameterValue_ConvertTo(t *testing.T) {
 			"Valid conversion",
 			fields{25, units.MPH},
 			units.KMH,
-			&ssm2.ParameterValue{40.233498, units.KMH},
+			&ssm2.ParameterValue{40.2335, units.KMH},
 			false,
 		},
 		{
@@ -120,7 +165,7 @@ func TestParameterValue_SafeConvertTo(t *testing.T) {
 			"Valid conversion",
 			fields{25, units.MPH},
 			units.KMH,
-			ssm2.ParameterValue{40.233498, units.KMH},
+			ssm2.ParameterValue{40.2335, units.KMH},
 		},
 		{
 			"Invalid conversion",
@@ -154,6 +199,9 @@ func TestAvailableDerivedParameters(t *testing.T) {
 		ssm2.DerivedParameters["P200"],
 		ssm2.DerivedParameters["P201"],
 		ssm2.DerivedParameters["P237"],
+		ssm2.DerivedParameters["P255"],
+		ssm2.DerivedParameters["P246"],
+		ssm2.DerivedParameters["P249"],
 	}
 
 	got := ssm2.AvailableDerivedParameters(params)
@@ -179,3 +227,97 @@ func TestAvailableDerivedParameters(t *testing.T) {
 		t.Errorf("AvailableDerivedParameters() = %v, want %v", got, want)
 	}
 }
+
+func TestAvailableDerivedParameters_DerivedDependencies(t *testing.T) {
+	params := []ssm2.Parameter{
+		ssm2.Parameters["P7"],
+		ssm2.Parameters["P8"],
+		ssm2.Parameters["P11"],
+		ssm2.Parameters["P12"],
+	}
+
+	found := false
+	for _, p := range ssm2.AvailableDerivedParameters(params) {
+		if p.Id == "P242" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("want P242 to be available since P200 is available")
+	}
+
+	for _, p := range ssm2.AvailableDerivedParameters(params[1:]) {
+		if p.Id == "P242" {
+			t.Fatal("want P242 to be unavailable without P7")
+		}
+	}
+}
+
+func TestResolveDerivedParameters(t *testing.T) {
+	ids := func(derived []ssm2.DerivedParameter, params []ssm2.Parameter) []string {
+		var ids []string
+		for _, p := range derived {
+			ids = append(ids, p.Id)
+		}
+		for _, p := range params {
+			ids = append(ids, p.Id)
+		}
+		return ids
+	}
+	noop := func(map[string]ssm2.ParameterValue, ssm2.Vehicle) (*ssm2.ParameterValue, error) { return nil, nil }
+
+	tests := []struct {
+		name    string
+		derived []ssm2.DerivedParameter
+		want    []string
+		wantErr bool
+	}{
+		{
+			"dependencies first",
+			[]ssm2.DerivedParameter{ssm2.DerivedParameters["P242"]},
+			[]string{"P200", "P242", "P8", "P12", "P11", "P7"},
+			false,
+		},
+		{
+			"no duplicates",
+			[]ssm2.DerivedParameter{ssm2.DerivedParameters["P242"], ssm2.DerivedParameters["P200"], ssm2.DerivedParameters["P201"]},
+			[]string{"P200", "P242", "P201", "P8", "P12", "P11", "P7", "P21"},
+			false,
+		},
+		{
+			"unknown dependency",
+			[]ssm2.DerivedParameter{{Id: "X1", DependsOnParameters: []string{"X2"}, Value: noop}},
+			nil,
+			true,
+		},
+		{
+			"cycle",
+			[]ssm2.DerivedParameter{
+				{Id: "X1", DependsOnParameters: []string{"P8", "X2"}, Value: noop},
+				{Id: "X2", DependsOnParameters: []string{"X3"}, Value: noop},
+				{Id: "X3", DependsOnParameters: []string{"X1"}, Value: noop},
+			},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			derived, params, err := ssm2.ResolveDerivedParameters(tt.derived)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveDerivedParameters() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := ids(derived, params); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveDerivedParameters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDerivedParameters_Resolvable(t *testing.T) {
+	for id, p := range ssm2.DerivedParameters {
+		if _, _, err := ssm2.ResolveDerivedParameters([]ssm2.DerivedParameter{p}); err != nil {
+			t.Errorf("%s: %v", id, err)
+		}
+	}
+}
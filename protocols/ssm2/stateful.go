@@ -0,0 +1,141 @@
+package ssm2
+
+import (
+	"math"
+	"time"
+
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+// Calculator calculates a stateful derived parameter from the samples in a
+// logging session, e.g. a rate of change or a running total.
+type Calculator interface {
+	// Next returns the parameter's value for the sample read at t. Samples are
+	// passed in the order they're read.
+	Next(t time.Time, params map[string]ParameterValue, v Vehicle) (*ParameterValue, error)
+}
+
+// CalculatorFunc is a function that implements Calculator.
+type CalculatorFunc func(t time.Time, params map[string]ParameterValue, v Vehicle) (*ParameterValue, error)
+
+// Next calls f.
+func (f CalculatorFunc) Next(t time.Time, params map[string]ParameterValue, v Vehicle) (*ParameterValue, error) {
+	return f(t, params, v)
+}
+
+// Derivative returns a calculator for the rate of change per second of the parameter's value.
+// The first sample's rate is 0.
+func Derivative(id string, unit units.Unit) func() Calculator {
+	return func() Calculator {
+		var (
+			prev     float32
+			prevTime time.Time
+		)
+		return CalculatorFunc(func(t time.Time, params map[string]ParameterValue, _ Vehicle) (*ParameterValue, error) {
+			val := params[id].Value
+			rate := float32(0)
+			if dt := t.Sub(prevTime).Seconds(); !prevTime.IsZero() && dt > 0 {
+				rate = (val - prev) / float32(dt)
+			}
+			prev, prevTime = val, t
+			return &ParameterValue{rate, unit}, nil
+		})
+	}
+}
+
+// Integral returns a calculator for the running total of the parameter's value over
+// time in seconds, multiplied by scale (e.g. 1/3600 to integrate km/h into km).
+func Integral(id string, scale float32, unit units.Unit) func() Calculator {
+	return func() Calculator {
+		var (
+			total    float64
+			prev     float32
+			prevTime time.Time
+		)
+		return CalculatorFunc(func(t time.Time, params map[string]ParameterValue, _ Vehicle) (*ParameterValue, error) {
+			val := params[id].Value
+			if dt := t.Sub(prevTime).Seconds(); !prevTime.IsZero() && dt > 0 {
+				// trapezoidal rule
+				total += float64(prev+val) / 2 * dt * float64(scale)
+			}
+			prev, prevTime = val, t
+			return &ParameterValue{float32(total), unit}, nil
+		})
+	}
+}
+
+// EMA returns a calculator for the exponential moving average of the parameter's value.
+// The time constant is how long it takes the average to move ~63% of the way to a new value,
+// so the smoothing doesn't depend on the sample rate.
+func EMA(id string, timeConstant time.Duration) func() Calculator {
+	return func() Calculator {
+		var (
+			avg      float32
+			prevTime time.Time
+		)
+		return CalculatorFunc(func(t time.Time, params map[string]ParameterValue, _ Vehicle) (*ParameterValue, error) {
+			val := params[id]
+			if prevTime.IsZero() {
+				avg = val.Value
+			} else if dt := t.Sub(prevTime); dt > 0 {
+				alpha := float32(1 - math.Exp(-dt.Seconds()/timeConstant.Seconds()))
+				avg += alpha * (val.Value - avg)
+			}
+			prevTime = t
+			return &ParameterValue{avg, val.Unit}, nil
+		})
+	}
+}
+
+// WindowMin returns a calculator for the minimum of the parameter's value over the window.
+func WindowMin(id string, window time.Duration) func() Calculator {
+	return windowExtreme(id, window, func(a, b float32) bool { return a < b })
+}
+
+// WindowMax returns a calculator for the maximum of the parameter's value over the window.
+func WindowMax(id string, window time.Duration) func() Calculator {
+	return windowExtreme(id, window, func(a, b float32) bool { return a > b })
+}
+
+// windowExtreme returns a calculator for the most extreme value over the window, where
+// better(a, b) returns true if a is more extreme than b.
+func windowExtreme(id string, window time.Duration, better func(a, b float32) bool) func() Calculator {
+	type sample struct {
+		t time.Time
+		v float32
+	}
+	return func() Calculator {
+		// the samples that can still become the extreme, in time order with
+		// each one more extreme than the ones after it (a monotonic queue)
+		var candidates []sample
+		return CalculatorFunc(func(t time.Time, params map[string]ParameterValue, _ Vehicle) (*ParameterValue, error) {
+			val := params[id]
+			for len(candidates) > 0 && !better(candidates[len(candidates)-1].v, val.Value) {
+				candidates = candidates[:len(candidates)-1]
+			}
+			candidates = append(candidates, sample{t, val.Value})
+			for t.Sub(candidates[0].t) > window {
+				candidates = candidates[1:]
+			}
+			return &ParameterValue{candidates[0].v, val.Unit}, nil
+		})
+	}
+}
+
+// TimeIn returns a calculator for the total seconds the condition has been true.
+// The time between two samples is counted when the condition is true for the later one.
+func TimeIn(condition func(params map[string]ParameterValue) bool) func() Calculator {
+	return func() Calculator {
+		var (
+			total    float64
+			prevTime time.Time
+		)
+		return CalculatorFunc(func(t time.Time, params map[string]ParameterValue, _ Vehicle) (*ParameterValue, error) {
+			if condition(params) && !prevTime.IsZero() {
+				total += t.Sub(prevTime).Seconds()
+			}
+			prevTime = t
+			return &ParameterValue{float32(total), units.S}, nil
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package ssm2_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+// runCalculator passes the values to a new calculator as samples taken every interval,
+// returning the calculated values.
+func runCalculator(t *testing.T, newCalculator func() ssm2.Calculator, id string, unit units.Unit,
+	interval time.Duration, values ...float32) []float32 {
+	t.Helper()
+
+	c := newCalculator()
+	start := time.Date(2024, 3, 9, 14, 5, 6, 0, time.UTC)
+	got := make([]float32, len(values))
+	for i, v := range values {
+		val, err := c.Next(start.Add(interval*time.Duration(i)),
+			map[string]ssm2.ParameterValue{id: {Value: v, Unit: unit}}, ssm2.DefaultVehicle())
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[i] = val.Value
+	}
+	return got
+}
+
+func equalValues(got, want []float32, tolerance float32) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if !approxEqual(got[i], want[i], tolerance) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCalculators(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            string
+		newCalculator func() ssm2.Calculator
+		interval      time.Duration
+		values        []float32
+		want          []float32
+	}{
+		{
+			"derivative",
+			"P8",
+			ssm2.Derivative("P8", units.RPMPerSecond),
+			time.Second / 2,
+			[]float32{3000, 3500, 4500, 4500},
+			[]float32{0, 1000, 2000, 0},
+		},
+		{
+			"integral",
+			"P9",
+			ssm2.Integral("P9", 1.0/3600, units.Kilometers),
+			time.Second,
+			[]float32{0, 36, 36, 72},
+			[]float32{0, 0.005, 0.015, 0.03},
+		},
+		{
+			"exponential moving average",
+			"P58",
+			ssm2.EMA("P58", time.Second),
+			time.Second,
+			[]float32{1, 2, 2},
+			[]float32{1, 1.632, 1.865},
+		},
+		{
+			"window max",
+			"P25",
+			ssm2.WindowMax("P25", time.Second*2),
+			time.Second,
+			[]float32{50, 100, 80, 60, 70, 40},
+			[]float32{50, 100, 100, 100, 80, 70},
+		},
+		{
+			"window min",
+			"P25",
+			ssm2.WindowMin("P25", time.Second*2),
+			time.Second,
+			[]float32{50, 100, 80, 60, 70, 40},
+			[]float32{50, 50, 50, 60, 60, 40},
+		},
+		{
+			"time in condition",
+			"P13",
+			ssm2.TimeIn(func(params map[string]ssm2.ParameterValue) bool { return params["P13"].Value >= 80 }),
+			time.Second / 4,
+			[]float32{100, 100, 50, 90, 90},
+			[]float32{0, 0.25, 0.25, 0.5, 0.75},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runCalculator(t, tt.newCalculator, tt.id, units.Raw, tt.interval, tt.values...)
+			if !equalValues(got, tt.want, 0.001) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculators_IndependentState(t *testing.T) {
+	newCalculator := ssm2.Integral("P9", 1, units.Kilometers)
+	first := runCalculator(t, newCalculator, "P9", units.KMH, time.Second, 10, 10)
+	second := runCalculator(t, newCalculator, "P9", units.KMH, time.Second, 10, 10)
+	if !equalValues(first, second, 0) {
+		t.Fatalf("want each calculator to have its own state. got: %v and %v", first, second)
+	}
+}
+
+func TestLoggingSession_Stateful(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	session, err := ssm2.LoggingSession(ctx, ssm2.NewFakeConnection(time.Millisecond), nil,
+		[]ssm2.DerivedParameter{ssm2.DerivedParameters["P248"], ssm2.DerivedParameters["P249"]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var prev float32
+	for i := 0; i < 5; i++ {
+		values := <-session
+		distance, ok := values["P248"]
+		if !ok {
+			t.Fatalf("want a value for P248. got: %v", values)
+		}
+		if _, ok = values["P249"]; !ok {
+			t.Fatalf("want a value for P249. got: %v", values)
+		}
+		if distance.Value < prev {
+			t.Fatalf("want the distance to only increase. got %v after %v", distance.Value, prev)
+		}
+		prev = distance.Value
+	}
+}
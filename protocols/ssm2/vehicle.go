@@ -0,0 +1,200 @@
+package ssm2
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+// FuelType is the type of fuel a vehicle runs on.
+type FuelType string
+
+// The supported fuel types.
+const (
+	FuelGasoline FuelType = "gasoline"
+	// FuelEthanolBlend is a gasoline/ethanol blend like E85. The
+	// blend is described by Fuel.EthanolPercent.
+	FuelEthanolBlend FuelType = "ethanol"
+	FuelDiesel       FuelType = "diesel"
+)
+
+// FuelTypes contains all the valid FuelType values.
+var FuelTypes = []FuelType{FuelGasoline, FuelEthanolBlend, FuelDiesel}
+
+// The stoichiometric air/fuel ratios and densities (g/L) of the pure fuels.
+const (
+	StoichGasoline  float32 = 14.7
+	StoichEthanol   float32 = 9.0
+	StoichDiesel    float32 = 14.5
+	densityGasoline float32 = 745
+	densityEthanol  float32 = 789
+	densityDiesel   float32 = 832
+)
+
+// Fuel describes the fuel a vehicle runs on.
+type Fuel struct {
+	Type FuelType `yaml:"type"`
+	// EthanolPercent is the ethanol content by volume of an ethanol blend, e.g. 85 for E85.
+	EthanolPercent float32 `yaml:"ethanolPercent,omitempty"`
+	// StoichAFR overrides the stoichiometric air/fuel ratio calculated from the fuel type when set.
+	StoichAFR float32 `yaml:"stoichAFR,omitempty"`
+}
+
+// Stoich returns the stoichiometric air/fuel ratio of the fuel.
+func (f Fuel) Stoich() float32 {
+	if f.StoichAFR > 0 {
+		return f.StoichAFR
+	}
+
+	switch f.Type {
+	case FuelDiesel:
+		return StoichDiesel
+	case FuelEthanolBlend:
+		// the stoich ratios blend by mass, not volume
+		e := f.ethanolMassFraction()
+		return StoichGasoline*(1-e) + StoichEthanol*e
+	}
+	return StoichGasoline
+}
+
+// Converter returns a unit converter that converts between AFR and Lambda using the fuel's stoichiometric AFR.
+func (f Fuel) Converter() units.Converter {
+	return units.Converter{Stoich: float64(f.Stoich())}
+}
+
+// Density returns the density of the fuel in g/L.
+func (f Fuel) Density() float32 {
+	switch f.Type {
+	case FuelDiesel:
+		return densityDiesel
+	case FuelEthanolBlend:
+		e := f.ethanolVolumeFraction()
+		return densityGasoline*(1-e) + densityEthanol*e
+	}
+	return densityGasoline
+}
+
+func (f Fuel) ethanolVolumeFraction() float32 {
+	return float32(math.Max(0, math.Min(100, float64(f.EthanolPercent)))) / 100
+}
+
+func (f Fuel) ethanolMassFraction() float32 {
+	e := f.ethanolVolumeFraction()
+	ethanol := e * densityEthanol
+	return ethanol / (ethanol + (1-e)*densityGasoline)
+}
+
+// Vehicle contains the facts about a vehicle that derived parameters are calculated from.
+type Vehicle struct {
+	// Displacement is the engine displacement in liters.
+	Displacement float32 `yaml:"displacement"`
+	Cylinders    int     `yaml:"cylinders"`
+	// InjectorSize is the flow rate of each injector in cc/min.
+	InjectorSize float32 `yaml:"injectorSize"`
+	Fuel         Fuel    `yaml:"fuel"`
+	// TireSize is the size in the format printed on the tire sidewall, e.g. 225/45R17.
+	TireSize string `yaml:"tireSize"`
+	// GearRatios are the transmission's ratios, starting with first gear.
+	GearRatios []float32 `yaml:"gearRatios,flow"`
+	FinalDrive float32   `yaml:"finalDrive"`
+}
+
+// DefaultVehicle returns the vehicle assumed when one isn't configured: a 2.5L
+// flat four on gasoline with a 5-speed manual transmission.
+func DefaultVehicle() Vehicle {
+	return Vehicle{
+		Displacement: 2.5,
+		Cylinders:    4,
+		InjectorSize: 565,
+		Fuel:         Fuel{Type: FuelGasoline},
+		TireSize:     "225/45R17",
+		GearRatios:   []float32{3.454, 1.947, 1.296, 0.972, 0.738},
+		FinalDrive:   3.9,
+	}
+}
+
+// WithDefaults returns a copy of the vehicle with the zero or invalid values replaced by the defaults.
+func (v Vehicle) WithDefaults() Vehicle {
+	d := DefaultVehicle()
+	if v.Displacement <= 0 {
+		v.Displacement = d.Displacement
+	}
+	if v.Cylinders <= 0 {
+		v.Cylinders = d.Cylinders
+	}
+	if v.InjectorSize <= 0 {
+		v.InjectorSize = d.InjectorSize
+	}
+	switch v.Fuel.Type {
+	case FuelGasoline, FuelEthanolBlend, FuelDiesel:
+	default:
+		v.Fuel.Type = d.Fuel.Type
+	}
+	if _, err := ParseTireSize(v.TireSize); err != nil {
+		v.TireSize = d.TireSize
+	}
+	if len(v.GearRatios) == 0 {
+		v.GearRatios = d.GearRatios
+	}
+	if v.FinalDrive <= 0 {
+		v.FinalDrive = d.FinalDrive
+	}
+	return v
+}
+
+// TireCircumference returns the circumference of the vehicle's tires in meters.
+func (v Vehicle) TireCircumference() (float32, error) {
+	diameter, err := ParseTireSize(v.TireSize)
+	if err != nil {
+		return 0, err
+	}
+	return diameter * math.Pi, nil
+}
+
+var tireSizeRegexp = regexp.MustCompile(`^[A-Za-z]*(\d+)/(\d+)\s*[A-Za-z]+\s*(\d+(?:\.\d+)?)$`)
+
+// ParseTireSize returns the diameter in meters of a tire with a metric size
+// like 225/45R17 (width in mm / sidewall aspect ratio, wheel diameter in inches).
+func ParseTireSize(size string) (float32, error) {
+	m := tireSizeRegexp.FindStringSubmatch(size)
+	if m == nil {
+		return 0, fmt.Errorf("invalid tire size '%s'", size)
+	}
+
+	width, _ := strconv.ParseFloat(m[1], 32)
+	aspect, _ := strconv.ParseFloat(m[2], 32)
+	wheel, _ := strconv.ParseFloat(m[3], 32)
+	if width == 0 || aspect == 0 || wheel == 0 {
+		return 0, fmt.Errorf("invalid tire size '%s'", size)
+	}
+
+	sidewall := width * aspect / 100 / 1000
+	return float32(wheel*0.0254 + 2*sidewall), nil
+}
+
+// Gear returns the gear (starting at 1) with the ratio closest to the ratio
+// between the engine speed (rpm) and the wheel speed for the vehicle speed (km/h).
+// 0 is returned when the vehicle is stopped.
+func (v Vehicle) Gear(rpm, kmh float32) (int, error) {
+	circumference, err := v.TireCircumference()
+	if err != nil {
+		return 0, err
+	}
+	if kmh < 1 || rpm <= 0 || len(v.GearRatios) == 0 || v.FinalDrive <= 0 {
+		return 0, nil
+	}
+
+	wheelRPM := kmh * 1000 / 60 / circumference
+	ratio := rpm / wheelRPM / v.FinalDrive
+
+	gear, diff := 0, float32(math.MaxFloat32)
+	for i, r := range v.GearRatios {
+		if d := float32(math.Abs(float64(r - ratio))); d < diff {
+			gear, diff = i+1, d
+		}
+	}
+	return gear, nil
+}
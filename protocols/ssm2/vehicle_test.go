@@ -0,0 +1,195 @@
+package ssm2_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/gavinwade12/ecLogger/units"
+)
+
+func approxEqual(a, b, tolerance float32) bool {
+	return math.Abs(float64(a-b)) <= float64(tolerance)
+}
+
+func TestFuel_Stoich(t *testing.T) {
+	tests := []struct {
+		name string
+		fuel ssm2.Fuel
+		want float32
+	}{
+		{"gasoline", ssm2.Fuel{Type: ssm2.FuelGasoline}, 14.7},
+		{"diesel", ssm2.Fuel{Type: ssm2.FuelDiesel}, 14.5},
+		{"E0", ssm2.Fuel{Type: ssm2.FuelEthanolBlend}, 14.7},
+		{"E85", ssm2.Fuel{Type: ssm2.FuelEthanolBlend, EthanolPercent: 85}, 9.81},
+		{"E100", ssm2.Fuel{Type: ssm2.FuelEthanolBlend, EthanolPercent: 100}, 9.0},
+		{"override", ssm2.Fuel{Type: ssm2.FuelEthanolBlend, EthanolPercent: 85, StoichAFR: 9.76}, 9.76},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fuel.Stoich(); !approxEqual(got, tt.want, 0.01) {
+				t.Errorf("Fuel.Stoich() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTireSize(t *testing.T) {
+	tests := []struct {
+		size    string
+		want    float32
+		wantErr bool
+	}{
+		{"225/45R17", 0.6343, false},
+		{"P215/60R16", 0.6644, false},
+		{"235/40 ZR 18", 0.6452, false},
+		{"225/45", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.size, func(t *testing.T) {
+			got, err := ssm2.ParseTireSize(tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTireSize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !approxEqual(got, tt.want, 0.0001) {
+				t.Errorf("ParseTireSize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVehicle_Gear(t *testing.T) {
+	v := ssm2.DefaultVehicle()
+	tests := []struct {
+		name string
+		rpm  float32
+		kmh  float32
+		want int
+	}{
+		{"stopped", 800, 0, 0},
+		{"first", 3000, 20, 1},
+		{"third", 3000, 60, 3},
+		{"fifth", 2400, 100, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Gear(tt.rpm, tt.kmh)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Vehicle.Gear() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	v.TireSize = "invalid"
+	if _, err := v.Gear(3000, 60); err == nil {
+		t.Error("expected an error for an invalid tire size")
+	}
+}
+
+func TestVehicle_WithDefaults(t *testing.T) {
+	v := ssm2.Vehicle{Displacement: 2.0, Fuel: ssm2.Fuel{Type: "kerosene"}}.WithDefaults()
+	d := ssm2.DefaultVehicle()
+
+	if v.Displacement != 2.0 {
+		t.Errorf("want the configured displacement kept. got: %v", v.Displacement)
+	}
+	if v.Cylinders != d.Cylinders || v.InjectorSize != d.InjectorSize || v.TireSize != d.TireSize {
+		t.Errorf("want the unset specs defaulted. got: %+v", v)
+	}
+	if v.Fuel.Type != ssm2.FuelGasoline {
+		t.Errorf("want an invalid fuel type defaulted. got: %v", v.Fuel.Type)
+	}
+}
+
+func TestDerivedParameters_Vehicle(t *testing.T) {
+	e85 := ssm2.DefaultVehicle()
+	e85.Fuel = ssm2.Fuel{Type: ssm2.FuelEthanolBlend, EthanolPercent: 85}
+	twoLiter := ssm2.DefaultVehicle()
+	twoLiter.Displacement = 2.0
+
+	tests := []struct {
+		name    string
+		id      string
+		params  map[string]ssm2.ParameterValue
+		vehicle ssm2.Vehicle
+		want    ssm2.ParameterValue
+	}{
+		{
+			"injector duty cycle",
+			"P201",
+			map[string]ssm2.ParameterValue{
+				"P8":  {Value: 3000, Unit: units.RPM},
+				"P21": {Value: 10000, Unit: units.US},
+			},
+			ssm2.DefaultVehicle(),
+			ssm2.ParameterValue{Value: 25, Unit: units.Percent},
+		},
+		{
+			"fuel flow",
+			"P255",
+			map[string]ssm2.ParameterValue{
+				"P8":  {Value: 3000, Unit: units.RPM},
+				"P21": {Value: 10000, Unit: units.US},
+			},
+			ssm2.DefaultVehicle(),
+			ssm2.ParameterValue{Value: 565, Unit: units.CCPerMinute},
+		},
+		{
+			"gear",
+			"P254",
+			map[string]ssm2.ParameterValue{
+				"P8": {Value: 3000, Unit: units.RPM},
+				"P9": {Value: 60, Unit: units.KMH},
+			},
+			ssm2.DefaultVehicle(),
+			ssm2.ParameterValue{Value: 3, Unit: units.Gear},
+		},
+		{
+			"lambda on E85",
+			"P232",
+			map[string]ssm2.ParameterValue{
+				"P160": {Value: 500, Unit: units.MGPerCylinder},
+				"P230": {Value: 50, Unit: units.MGPerCylinder},
+			},
+			e85,
+			ssm2.ParameterValue{Value: 1.019, Unit: units.Lambda},
+		},
+		{
+			"volumetric efficiency scales with displacement",
+			"P242",
+			map[string]ssm2.ParameterValue{
+				"P200": {Value: 1, Unit: units.GramsPerRev},
+				"P11":  {Value: 20, Unit: units.C},
+				"P7":   {Value: 100, Unit: units.KPA},
+			},
+			twoLiter,
+			ssm2.ParameterValue{Value: 84.13, Unit: units.Percent},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ssm2.DerivedParameters[tt.id].Value(tt.params, tt.vehicle)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Unit != tt.want.Unit || !approxEqual(got.Value, tt.want.Value, 0.01) {
+				t.Errorf("%s.Value() = %+v, want %+v", tt.id, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuel_Converter(t *testing.T) {
+	fuel := ssm2.Fuel{Type: ssm2.FuelEthanolBlend, EthanolPercent: 85}
+	afr, err := ssm2.ParameterValue{Value: 1, Unit: units.Lambda}.ConvertWith(units.AFR, fuel.Converter())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !approxEqual(afr.Value, fuel.Stoich(), 0.001) {
+		t.Fatalf("want lambda 1 to be the fuel's stoich AFR %v. got: %v", fuel.Stoich(), afr.Value)
+	}
+}
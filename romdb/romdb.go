@@ -0,0 +1,186 @@
+// Package romdb identifies vehicles by the ROM ID and SSM ID returned by their ECU.
+package romdb
+
+import (
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// The lengths of the IDs returned by the ECU.
+const (
+	ROMIDLength = 5
+	SSMIDLength = 3
+)
+
+//go:embed vehicles.yaml
+var builtinYAML []byte
+
+// Entry identifies the vehicles whose ECU returns its ROM ID and/or SSM ID.
+type Entry struct {
+	// ROMID is the hex-encoded ROM ID. An entry without one matches any ROM ID with its SSM ID.
+	ROMID string `yaml:"romId,omitempty" json:"romId,omitempty"`
+	// SSMID is the hex-encoded SSM ID. An entry without one matches its ROM ID with any SSM ID.
+	SSMID string `yaml:"ssmId,omitempty" json:"ssmId,omitempty"`
+	// Market is where the vehicle was sold, e.g. USDM, JDM, EDM, or ADM.
+	Market string `yaml:"market,omitempty" json:"market,omitempty"`
+	Model  string `yaml:"model" json:"model"`
+	Year   int    `yaml:"year,omitempty" json:"year,omitempty"`
+	// Engine is the engine code, e.g. EJ255.
+	Engine string `yaml:"engine,omitempty" json:"engine,omitempty"`
+	// Transmission is the number of gears and type, e.g. 5MT or 4AT.
+	Transmission string `yaml:"transmission,omitempty" json:"transmission,omitempty"`
+	// Profile is the name of the profile selected the first time the vehicle is logged.
+	// It's added from the database's profiles when there isn't a profile with the name.
+	Profile string `yaml:"profile,omitempty" json:"profile,omitempty"`
+}
+
+// Label describes the vehicle, e.g. "2006 WRX 5MT, USDM". Empty fields are left out.
+func (e Entry) Label() string {
+	var parts []string
+	if e.Year > 0 {
+		parts = append(parts, strconv.Itoa(e.Year))
+	}
+	for _, s := range []string{e.Model, e.Transmission} {
+		if s = strings.TrimSpace(s); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	label := strings.Join(parts, " ")
+	if m := strings.TrimSpace(e.Market); m != "" {
+		if label == "" {
+			return m
+		}
+		label += ", " + m
+	}
+	return label
+}
+
+// Validate returns an error if the entry doesn't have a model or a valid ROM ID or SSM ID.
+func (e Entry) Validate() error {
+	if strings.TrimSpace(e.Model) == "" {
+		return errors.New("the model is required")
+	}
+	if e.ROMID == "" && e.SSMID == "" {
+		return errors.New("a ROM ID or SSM ID is required")
+	}
+	if err := validateID(e.ROMID, ROMIDLength); err != nil {
+		return errors.Wrapf(err, "invalid ROM ID '%s'", e.ROMID)
+	}
+	if err := validateID(e.SSMID, SSMIDLength); err != nil {
+		return errors.Wrapf(err, "invalid SSM ID '%s'", e.SSMID)
+	}
+	return nil
+}
+
+func validateID(id string, length int) error {
+	if id == "" {
+		return nil
+	}
+	b, err := hex.DecodeString(id)
+	if err != nil {
+		return err
+	}
+	if len(b) != length {
+		return fmt.Errorf("must be %d bytes", length)
+	}
+	return nil
+}
+
+// Profile is a default set of parameters for the vehicles with an engine or
+// transmission in common, e.g. turbocharged gasoline engines.
+type Profile struct {
+	Name string `yaml:"name"`
+	// Parameters are the IDs of the parameters and derived parameters to log.
+	Parameters []string `yaml:"parameters"`
+}
+
+// Database maps the IDs returned by ECUs to the vehicles they're from.
+type Database struct {
+	Vehicles []Entry   `yaml:"vehicles"`
+	Profiles []Profile `yaml:"profiles"`
+}
+
+// Parse decodes a database from YAML and validates its entries.
+func Parse(b []byte) (*Database, error) {
+	db := &Database{}
+	if err := yaml.Unmarshal(b, db); err != nil {
+		return nil, err
+	}
+	for i, e := range db.Vehicles {
+		if err := e.Validate(); err != nil {
+			return nil, errors.Wrapf(err, "vehicle %d", i+1)
+		}
+	}
+	return db, nil
+}
+
+var (
+	builtin     *Database
+	builtinOnce sync.Once
+)
+
+// Builtin returns the database embedded in the program. It must not be modified.
+func Builtin() *Database {
+	builtinOnce.Do(func() {
+		db, err := Parse(builtinYAML)
+		if err != nil {
+			panic(errors.Wrap(err, "parsing the built-in ROM ID database"))
+		}
+		builtin = db
+	})
+	return builtin
+}
+
+// With returns a copy of the database with the entries added. The entries take
+// precedence over the database's own when they identify a vehicle equally well.
+func (db *Database) With(entries []Entry) *Database {
+	return &Database{
+		Vehicles: append(append([]Entry{}, entries...), db.Vehicles...),
+		Profiles: db.Profiles,
+	}
+}
+
+// Lookup returns the entry that best identifies the vehicle with the IDs. An entry
+// matching both IDs is preferred to one matching only the ROM ID, which is preferred
+// to one matching only the SSM ID. Either ID can be nil to match it with any entry.
+func (db *Database) Lookup(romID, ssmID []byte) (Entry, bool) {
+	rom, ssm := hex.EncodeToString(romID), hex.EncodeToString(ssmID)
+	best, bestScore := Entry{}, 0
+	for _, e := range db.Vehicles {
+		romScore, romOK := matchID(e.ROMID, rom, 2)
+		ssmScore, ssmOK := matchID(e.SSMID, ssm, 1)
+		if romOK && ssmOK && romScore+ssmScore > bestScore {
+			best, bestScore = e, romScore+ssmScore
+		}
+	}
+	return best, bestScore > 0
+}
+
+// matchID returns weight if both IDs are set and equal. It returns false when
+// they're both set and differ.
+func matchID(entryID, id string, weight int) (int, bool) {
+	if entryID == "" || id == "" {
+		return 0, true
+	}
+	if strings.EqualFold(entryID, id) {
+		return weight, true
+	}
+	return 0, false
+}
+
+// FindProfile returns the profile with the name, or nil.
+func (db *Database) FindProfile(name string) *Profile {
+	for i := range db.Profiles {
+		if db.Profiles[i].Name == name {
+			return &db.Profiles[i]
+		}
+	}
+	return nil
+}
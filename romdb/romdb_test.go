@@ -0,0 +1,89 @@
+package romdb
+
+import (
+	"testing"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+)
+
+func TestBuiltin(t *testing.T) {
+	db := Builtin()
+	for _, e := range db.Vehicles {
+		if e.Profile != "" && db.FindProfile(e.Profile) == nil {
+			t.Errorf("%s: the profile '%s' doesn't exist", e.Label(), e.Profile)
+		}
+	}
+	for _, p := range db.Profiles {
+		for _, id := range p.Parameters {
+			_, isParam := ssm2.Parameters[id]
+			_, isDerived := ssm2.DerivedParameters[id]
+			if !isParam && !isDerived {
+				t.Errorf("profile %s: the parameter %s doesn't exist", p.Name, id)
+			}
+		}
+	}
+}
+
+func TestBuiltinLookup(t *testing.T) {
+	e, ok := Builtin().Lookup([]byte{0x2F, 0x12, 0x78, 0x56, 0x06}, []byte{0xA4, 0x10, 0x0C})
+	if !ok || e.Label() != "2006 WRX 5MT, USDM" || e.Engine != "EJ255" || e.Profile != "turbo" {
+		t.Fatalf("want the 2006 USDM WRX. got: %+v (%v)", e, ok)
+	}
+	if e, ok := Builtin().Lookup([]byte{0x2F, 0x12, 0x78, 0x56, 0x07}, nil); ok {
+		t.Fatalf("want an unknown ROM ID unmatched. got: %+v", e)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	db := (&Database{Vehicles: []Entry{
+		{SSMID: "A4100C", Model: "SSM ID only"},
+		{ROMID: "2F12785606", Model: "ROM ID only"},
+		{ROMID: "2F12785606", SSMID: "A4100C", Model: "Both IDs"},
+		{ROMID: "1111111111", SSMID: "A4100C", Model: "Other ROM ID"},
+	}}).With([]Entry{{ROMID: "2f12785606", Model: "User"}})
+
+	tests := []struct {
+		rom, ssm []byte
+		want     string
+	}{
+		{[]byte{0x2F, 0x12, 0x78, 0x56, 0x06}, []byte{0xA4, 0x10, 0x0C}, "Both IDs"},
+		{[]byte{0x2F, 0x12, 0x78, 0x56, 0x06}, []byte{0xA4, 0x10, 0x0D}, "User"},
+		{[]byte{0x2F, 0x12, 0x78, 0x56, 0x06}, nil, "User"},
+		{[]byte{0x22, 0x22, 0x22, 0x22, 0x22}, []byte{0xA4, 0x10, 0x0C}, "SSM ID only"},
+		{[]byte{0x22, 0x22, 0x22, 0x22, 0x22}, []byte{0xA4, 0x10, 0x0D}, ""},
+	}
+	for _, tt := range tests {
+		e, ok := db.Lookup(tt.rom, tt.ssm)
+		if ok != (tt.want != "") || e.Model != tt.want {
+			t.Errorf("%X/%X: want '%s'. got: '%s' (%v)", tt.rom, tt.ssm, tt.want, e.Model, ok)
+		}
+	}
+}
+
+func TestEntry(t *testing.T) {
+	labels := map[string]Entry{
+		"2006 WRX 5MT, USDM": {Year: 2006, Model: "WRX", Transmission: "5MT", Market: "USDM"},
+		"Forester, JDM":      {Model: "Forester", Market: "JDM"},
+		"Legacy":             {Model: " Legacy "},
+	}
+	for want, e := range labels {
+		if got := e.Label(); got != want {
+			t.Errorf("want label '%s'. got: '%s'", want, got)
+		}
+	}
+
+	invalid := map[string]Entry{
+		"the model is required":                                           {ROMID: "2F12785606"},
+		"a ROM ID or SSM ID is required":                                  {Model: "WRX"},
+		"invalid ROM ID '2F1278': must be 5 bytes":                        {ROMID: "2F1278", Model: "WRX"},
+		"invalid SSM ID 'A4100G': encoding/hex: invalid byte: U+0047 'G'": {SSMID: "A4100G", Model: "WRX"},
+	}
+	for want, e := range invalid {
+		if err := e.Validate(); err == nil || err.Error() != want {
+			t.Errorf("want error '%s'. got: %v", want, err)
+		}
+	}
+	if err := (Entry{ROMID: "2F12785606", SSMID: "A4100C", Model: "WRX"}).Validate(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,114 @@
+package serialport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/pkg/errors"
+	"go.bug.st/serial/enumerator"
+)
+
+// DefaultProbeTimeout is the amount of time a port is given to answer an init request.
+const DefaultProbeTimeout = time.Second * 2
+
+// ProbeBaudRate is the SSM2 protocol's standard baud rate. Ports are always probed
+// at it, since a configured baud rate meant for one cable could keep an ECU on
+// another from answering.
+const ProbeBaudRate = 4800
+
+// ProbeResult describes the outcome of probing a single serial port for an ECU.
+type ProbeResult struct {
+	Port *enumerator.PortDetails
+	// ECU is set when the port answered the init request.
+	ECU *ssm2.ECU
+	// Err is set when the port couldn't be opened or didn't answer.
+	Err error
+}
+
+// Answered returns true when an ECU answered on the probed port.
+func (r ProbeResult) Answered() bool {
+	return r.ECU != nil
+}
+
+// Probe opens each port returned by enumerator.GetDetailedPortsList, sends an
+// init request, and reports which ports answered with an SSM ID and ROM ID.
+// Each port is given timeout to answer at ProbeBaudRate. The results are in the
+// enumerated order.
+func Probe(ctx context.Context, timeout time.Duration, opts ssm2.ConnectionOptions, l ssm2.Logger) ([]ProbeResult, error) {
+	ports, err := enumerator.GetDetailedPortsList()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing serial ports")
+	}
+
+	return probePorts(ctx, ports, timeout, func(name string) (ssm2.Connection, error) {
+		return OpenConnection(name, probeOptions(opts, timeout), l)
+	}), nil
+}
+
+// FirstResponding probes the available ports and returns the name of the
+// first one an ECU answered on.
+func FirstResponding(ctx context.Context, timeout time.Duration, opts ssm2.ConnectionOptions, l ssm2.Logger) (string, error) {
+	results, err := Probe(ctx, timeout, opts, l)
+	if err != nil {
+		return "", err
+	}
+
+	for _, r := range results {
+		if r.Answered() {
+			return r.Port.Name, nil
+		}
+	}
+	return "", errors.New("no ECU answered on any serial port")
+}
+
+// probeOptions uses ProbeBaudRate and limits the read timeouts so an unresponsive
+// port is given up on quickly.
+func probeOptions(opts ssm2.ConnectionOptions, timeout time.Duration) ssm2.ConnectionOptions {
+	opts = opts.WithDefaults()
+	opts.BaudRate = ProbeBaudRate
+	if opts.ReadTimeout > timeout {
+		opts.ReadTimeout = timeout
+	}
+	if opts.TotalReadTimeout > timeout {
+		opts.TotalReadTimeout = timeout
+	}
+	opts.MaxReadAttempts = 1
+	return opts
+}
+
+// probePorts probes the ports concurrently since each one is independent.
+func probePorts(ctx context.Context, ports []*enumerator.PortDetails, timeout time.Duration,
+	open func(name string) (ssm2.Connection, error)) []ProbeResult {
+	results := make([]ProbeResult, len(ports))
+
+	var wg sync.WaitGroup
+	for i, p := range ports {
+		wg.Add(1)
+		go func(i int, p *enumerator.PortDetails) {
+			defer wg.Done()
+			results[i] = ProbeResult{Port: p}
+
+			conn, err := open(p.Name)
+			if err != nil {
+				results[i].Err = err
+				return
+			}
+			defer conn.Close()
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			ecu, err := conn.InitECU(ctx)
+			if err != nil {
+				results[i].Err = errors.Wrap(err, "sending init request")
+				return
+			}
+			results[i].ECU = ecu
+		}(i, p)
+	}
+	wg.Wait()
+
+	return results
+}
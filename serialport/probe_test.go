@@ -0,0 +1,68 @@
+package serialport
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"go.bug.st/serial/enumerator"
+)
+
+func TestProbePorts(t *testing.T) {
+	ports := []*enumerator.PortDetails{
+		{Name: "/dev/ttyGPS"},
+		{Name: "/dev/ttyUSB0"},
+		{Name: "/dev/ttyBusy"},
+	}
+	open := func(name string) (ssm2.Connection, error) {
+		switch name {
+		case "/dev/ttyUSB0":
+			return ssm2.NewFakeConnection(time.Millisecond), nil
+		case "/dev/ttyBusy":
+			return nil, errors.New("port busy")
+		}
+		return ssm2.NewConnection(&silentPort{}, nil), nil
+	}
+
+	results := probePorts(context.Background(), ports, time.Millisecond*50, open)
+	if len(results) != len(ports) {
+		t.Fatalf("want %d results. got: %d.", len(ports), len(results))
+	}
+
+	for i, r := range results {
+		if r.Port != ports[i] {
+			t.Fatalf("result %d is for %s, want %s", i, r.Port.Name, ports[i].Name)
+		}
+		answered := r.Port.Name == "/dev/ttyUSB0"
+		if r.Answered() != answered {
+			t.Fatalf("%s: Answered() = %v, want %v (err: %v)", r.Port.Name, r.Answered(), answered, r.Err)
+		}
+		if !answered && r.Err == nil {
+			t.Fatalf("%s: expected an error", r.Port.Name)
+		}
+	}
+}
+
+func TestProbeOptions(t *testing.T) {
+	opts := probeOptions(ssm2.ConnectionOptions{BaudRate: 9600, ReadTimeout: time.Second * 5}, time.Second)
+	if opts.BaudRate != ProbeBaudRate {
+		t.Fatalf("want baud rate %d. got: %d", ProbeBaudRate, opts.BaudRate)
+	}
+	if opts.ReadTimeout != time.Second || opts.TotalReadTimeout != time.Second || opts.MaxReadAttempts != 1 {
+		t.Fatalf("want the reads limited to the timeout. got: %+v", opts)
+	}
+}
+
+// silentPort never returns any data, like a port without an ECU behind it.
+type silentPort struct{}
+
+func (p *silentPort) Read(b []byte) (int, error) {
+	time.Sleep(time.Millisecond * 10)
+	return 0, nil
+}
+
+func (p *silentPort) Write(b []byte) (int, error) { return len(b), nil }
+
+func (p *silentPort) Close() error { return nil }
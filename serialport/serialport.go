@@ -0,0 +1,50 @@
+// Package serialport opens the serial ports used to communicate with an ECU.
+package serialport
+
+import (
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"github.com/pkg/errors"
+	"go.bug.st/serial"
+)
+
+// Open opens the named serial port using the 8N1 settings required by
+// the SSM2 protocol and the baud rate and read timeout from opts.
+func Open(name string, opts ssm2.ConnectionOptions) (serial.Port, error) {
+	opts = opts.WithDefaults()
+
+	sp, err := serial.Open(name, &serial.Mode{
+		BaudRate: opts.BaudRate,
+		DataBits: ssm2.ConnectionDataBits,
+		Parity:   serial.NoParity,
+		StopBits: serial.OneStopBit,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening serial port '%s'", name)
+	}
+
+	if err = sp.SetReadTimeout(opts.ReadTimeout); err != nil {
+		sp.Close()
+		return nil, errors.Wrap(err, "setting serial port read timeout")
+	}
+	if err = sp.ResetInputBuffer(); err != nil {
+		sp.Close()
+		return nil, errors.Wrap(err, "resetting input buffer")
+	}
+
+	return sp, nil
+}
+
+// OpenConnection opens the named serial port and returns an ssm2.Connection using it.
+func OpenConnection(name string, opts ssm2.ConnectionOptions, l ssm2.Logger) (ssm2.Connection, error) {
+	if l == nil {
+		l = ssm2.NopLogger
+	}
+
+	l.Info("opening serial port", "port", name)
+	sp, err := Open(name, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return ssm2.NewConnectionWithOptions(sp, l, opts), nil
+}
@@ -0,0 +1,147 @@
+package serialport
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gavinwade12/ecLogger/protocols/ssm2"
+	"go.bug.st/serial/enumerator"
+)
+
+// PollInterval is how often the port list is re-read when change
+// notifications aren't available on the host.
+const PollInterval = time.Second * 5
+
+// settleDelay gives udev time to finish setting up a device node
+// before the port list is re-read.
+const settleDelay = time.Millisecond * 250
+
+// EventType describes a change to the available serial ports.
+type EventType int
+
+const (
+	PortAdded EventType = iota
+	PortRemoved
+)
+
+func (t EventType) String() string {
+	if t == PortRemoved {
+		return "removed"
+	}
+	return "added"
+}
+
+// Event is emitted by Watch when a serial port is added or removed.
+type Event struct {
+	Type EventType
+	Port *enumerator.PortDetails
+}
+
+// Watch emits an Event whenever a serial port is added or removed until ctx
+// is canceled, at which point the channel is closed. The ports present when
+// Watch is called are emitted first as PortAdded events.
+//
+// On Linux, device changes are detected as they happen. Everywhere else (or
+// if the notifications can't be set up) the port list is polled instead.
+func Watch(ctx context.Context, l ssm2.Logger) <-chan Event {
+	if l == nil {
+		l = ssm2.NopLogger
+	}
+
+	events := make(chan Event, 10)
+	go func() {
+		defer close(events)
+
+		changes, err := notifyChanges(ctx)
+		if err != nil {
+			l.Info("serial port change notifications unavailable, polling instead", "error", err)
+			changes = pollChanges(ctx, PollInterval)
+		}
+
+		known := map[string]*enumerator.PortDetails{}
+		scan := func() {
+			list, err := enumerator.GetDetailedPortsList()
+			if err != nil {
+				l.Warn("listing serial ports", "error", err)
+				return
+			}
+
+			var changed []Event
+			changed, known = diffPorts(known, list)
+			for _, e := range changed {
+				select {
+				case events <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+
+		scan()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-changes:
+				if !ok {
+					return
+				}
+				scan()
+			}
+		}
+	}()
+
+	return events
+}
+
+// pollChanges signals on every interval until ctx is canceled.
+func pollChanges(ctx context.Context, interval time.Duration) <-chan struct{} {
+	changes := make(chan struct{})
+	go func() {
+		defer close(changes)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				select {
+				case changes <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return changes
+}
+
+// diffPorts compares the known ports with the current list and returns the
+// resulting events (sorted by port name) and the new set of known ports.
+func diffPorts(known map[string]*enumerator.PortDetails,
+	list []*enumerator.PortDetails) ([]Event, map[string]*enumerator.PortDetails) {
+	current := make(map[string]*enumerator.PortDetails, len(list))
+	for _, p := range list {
+		current[p.Name] = p
+	}
+
+	events := []Event{}
+	for name, p := range known {
+		if current[name] == nil {
+			events = append(events, Event{Type: PortRemoved, Port: p})
+		}
+	}
+	for name, p := range current {
+		if known[name] == nil {
+			events = append(events, Event{Type: PortAdded, Port: p})
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Port.Name < events[j].Port.Name
+	})
+
+	return events, current
+}
@@ -0,0 +1,92 @@
+package serialport
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// notifyChanges watches /dev and /sys/class/tty for serial devices being
+// created or removed. Events are coalesced so a burst of changes from
+// plugging in a single device results in a single signal.
+func notifyChanges(ctx context.Context) (<-chan struct{}, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = w.Add("/dev"); err != nil {
+		w.Close()
+		return nil, err
+	}
+	for _, dir := range []string{"/dev/serial/by-id", "/sys/class/tty"} {
+		if _, err := os.Stat(dir); err == nil {
+			w.Add(dir) // best effort; /dev alone is enough
+		}
+	}
+
+	changes := make(chan struct{}, 1)
+	go func() {
+		defer close(changes)
+		defer w.Close()
+
+		// also re-read the list occasionally in case an event was missed
+		fallback := time.NewTicker(PollInterval * 6)
+		defer fallback.Stop()
+
+		var settle <-chan time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if e.Op&(fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 || !isSerialDevice(e.Name) {
+					continue
+				}
+				if settle == nil {
+					settle = time.After(settleDelay)
+				}
+			case _, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+			case <-settle:
+				settle = nil
+				signalChange(changes)
+			case <-fallback.C:
+				signalChange(changes)
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+func signalChange(changes chan<- struct{}) {
+	select {
+	case changes <- struct{}{}:
+	default: // a signal is already pending
+	}
+}
+
+// isSerialDevice returns true for the device names used by serial adapters.
+func isSerialDevice(name string) bool {
+	if strings.HasPrefix(name, "/dev/serial") {
+		return true
+	}
+
+	base := filepath.Base(name)
+	for _, prefix := range []string{"ttyUSB", "ttyACM", "ttyS", "ttyAMA", "rfcomm"} {
+		if strings.HasPrefix(base, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package serialport
+
+import (
+	"context"
+	"errors"
+)
+
+// notifyChanges isn't supported outside of Linux, so the port list is polled instead.
+func notifyChanges(ctx context.Context) (<-chan struct{}, error) {
+	return nil, errors.New("device change notifications aren't supported on this platform")
+}
@@ -0,0 +1,36 @@
+package serialport
+
+import (
+	"testing"
+
+	"go.bug.st/serial/enumerator"
+)
+
+func TestDiffPorts(t *testing.T) {
+	gps := &enumerator.PortDetails{Name: "/dev/ttyACM0"}
+	cable := &enumerator.PortDetails{Name: "/dev/ttyUSB0"}
+	wideband := &enumerator.PortDetails{Name: "/dev/ttyUSB1"}
+
+	events, known := diffPorts(map[string]*enumerator.PortDetails{},
+		[]*enumerator.PortDetails{cable, gps})
+	if len(events) != 2 || events[0].Port != gps || events[1].Port != cable ||
+		events[0].Type != PortAdded || events[1].Type != PortAdded {
+		t.Fatalf("unexpected initial events: %+v", events)
+	}
+
+	events, known = diffPorts(known, []*enumerator.PortDetails{gps, wideband})
+	if len(events) != 2 {
+		t.Fatalf("want 2 events. got: %+v", events)
+	}
+	if events[0].Type != PortRemoved || events[0].Port != cable {
+		t.Fatalf("want %s removed. got: %s %s", cable.Name, events[0].Type, events[0].Port.Name)
+	}
+	if events[1].Type != PortAdded || events[1].Port != wideband {
+		t.Fatalf("want %s added. got: %s %s", wideband.Name, events[1].Type, events[1].Port.Name)
+	}
+
+	events, _ = diffPorts(known, []*enumerator.PortDetails{gps, wideband})
+	if len(events) != 0 {
+		t.Fatalf("want no events for an unchanged list. got: %+v", events)
+	}
+}
@@ -0,0 +1,173 @@
+package units
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrorInvalidConversion is returned when an invalid unit conversion attempt is made.
+var ErrorInvalidConversion = errors.New("units are invalid for conversion")
+
+// StoichGasoline is the stoichiometric AFR of gasoline, which is used to convert
+// between AFR and Lambda unless a Converter for another fuel is used.
+const StoichGasoline = 14.7
+
+// Converter converts values between units.
+type Converter struct {
+	// Stoich is the stoichiometric AFR of the fuel, used to convert between AFR and Lambda.
+	Stoich float64
+}
+
+// DefaultConverter converts between AFR and Lambda using the stoichiometric AFR of gasoline.
+var DefaultConverter = Converter{Stoich: StoichGasoline}
+
+// Convert converts the value from one unit to another using the DefaultConverter.
+func Convert(value float32, from, to Unit) (float32, error) {
+	v, err := DefaultConverter.Convert(float64(value), from, to)
+	return float32(v), err
+}
+
+// Convert converts the value from one unit to another by following the
+// shortest path of conversions between them.
+func (c Converter) Convert(value float64, from, to Unit) (float64, error) {
+	if from == to {
+		return value, nil
+	}
+	path := findPath(from, to)
+	if path == nil {
+		return 0, ErrorInvalidConversion
+	}
+	for _, cv := range path {
+		value = cv.convert(value, c)
+	}
+	return value, nil
+}
+
+// CanConvert returns true if values can be converted from one unit to the other.
+func CanConvert(from, to Unit) bool {
+	return from == to || findPath(from, to) != nil
+}
+
+// ConvertibleUnits returns the units values can be converted to from u, sorted.
+func ConvertibleUnits(u Unit) []Unit {
+	visited := map[Unit]bool{u: true}
+	queue := []Unit{u}
+	var reachable []Unit
+	for len(queue) > 0 {
+		from := queue[0]
+		queue = queue[1:]
+		for _, cv := range graph[from] {
+			if !visited[cv.to] {
+				visited[cv.to] = true
+				reachable = append(reachable, cv.to)
+				queue = append(queue, cv.to)
+			}
+		}
+	}
+	sort.Slice(reachable, func(i, j int) bool { return reachable[i] < reachable[j] })
+	return reachable
+}
+
+// conversion is an edge in the conversion graph, converting values from one unit to another.
+type conversion struct {
+	from, to Unit
+	convert  func(v float64, c Converter) float64
+}
+
+// scale returns the conversions between units where to = from * factor.
+func scale(from, to Unit, factor float64) []conversion {
+	return []conversion{
+		{from, to, func(v float64, _ Converter) float64 { return v * factor }},
+		{to, from, func(v float64, _ Converter) float64 { return v / factor }},
+	}
+}
+
+// inverse returns the conversions between units where to = k / from (e.g. km/l to l/100k).
+func inverse(from, to Unit, k float64) []conversion {
+	return []conversion{
+		{from, to, func(v float64, _ Converter) float64 { return k / v }},
+		{to, from, func(v float64, _ Converter) float64 { return k / v }},
+	}
+}
+
+// graph has the conversions from each unit. Units of a dimension are connected
+// through as few conversions as possible, usually by way of a metric unit.
+var graph = newGraph(
+	scale(MPH, KMH, 1.60934),
+	scale(Miles, Kilometers, 1.60934),
+	[]conversion{
+		{C, F, func(v float64, _ Converter) float64 { return v/5*9 + 32 }},
+		{F, C, func(v float64, _ Converter) float64 { return (v - 32) / 9 * 5 }},
+	},
+	scale(KPA, PSI, 37.0/255),
+	scale(KPA, BAR, 0.01),
+	scale(KPA, HPA, 10),
+	scale(KPA, InHG, 0.2953),
+	scale(KPA, MmHG, 7.50062),
+	scale(MPA, KPA, 1000),
+	scale(KPAPerSecond, PSIPerSecond, 37.0/255),
+	scale(GS, LbPerMinute, 0.132277),
+	scale(GS, KgPerHour, 3.6),
+	[]conversion{
+		{Lambda, AFR, func(v float64, c Converter) float64 { return v * c.Stoich }},
+		{AFR, Lambda, func(v float64, c Converter) float64 { return v / c.Stoich }},
+	},
+	scale(CCPerMinute, LPerHour, 0.06),
+	scale(Liters, GallonsUS, 1/3.785412),
+	scale(MPGUS, MPGUK, 1.20095),
+	scale(MPGUS, KMPerL, 0.425144),
+	inverse(KMPerL, LPer100K, 100),
+	scale(Amps, Milliamps, 1000),
+	scale(S, MS, 1000),
+	scale(MS, US, 1000),
+	scale(Nm, LbFt, 0.737562),
+)
+
+func newGraph(conversions ...[]conversion) map[Unit][]conversion {
+	g := map[Unit][]conversion{}
+	for _, cvs := range conversions {
+		for _, cv := range cvs {
+			g[cv.from] = append(g[cv.from], cv)
+		}
+	}
+	return g
+}
+
+// paths caches the shortest path of conversions between two units, keyed by [from, to].
+// A nil path means the units can't be converted.
+var paths sync.Map
+
+// findPath returns the shortest path of conversions from one unit to another,
+// or nil if there isn't one.
+func findPath(from, to Unit) []conversion {
+	key := [2]Unit{from, to}
+	if p, ok := paths.Load(key); ok {
+		return p.([]conversion)
+	}
+
+	// breadth-first search, recording the conversion used to reach each unit
+	prev := map[Unit]conversion{}
+	visited := map[Unit]bool{from: true}
+	queue := []Unit{from}
+	for len(queue) > 0 && !visited[to] {
+		u := queue[0]
+		queue = queue[1:]
+		for _, cv := range graph[u] {
+			if !visited[cv.to] {
+				visited[cv.to] = true
+				prev[cv.to] = cv
+				queue = append(queue, cv.to)
+			}
+		}
+	}
+
+	var path []conversion
+	if visited[to] && from != to {
+		for u := to; u != from; u = prev[u].from {
+			path = append([]conversion{prev[u]}, path...)
+		}
+	}
+	paths.Store(key, path)
+	return path
+}
@@ -0,0 +1,81 @@
+package units
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestGraph_Dimensions(t *testing.T) {
+	for from, cvs := range graph {
+		for _, cv := range cvs {
+			df, ok := Dimensions[cv.from]
+			if !ok {
+				t.Errorf("%s has no dimension", cv.from)
+			}
+			if dt := Dimensions[cv.to]; df != dt {
+				t.Errorf("%s (%s) converts to %s (%s)", from, df, cv.to, dt)
+			}
+		}
+	}
+}
+
+func TestGraph_RoundTrip(t *testing.T) {
+	for from := range graph {
+		for _, to := range ConvertibleUnits(from) {
+			v, err := DefaultConverter.Convert(42, from, to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			back, err := DefaultConverter.Convert(v, to, from)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(back-42) > 1e-9 {
+				t.Errorf("%s -> %s -> %s = %v, want 42", from, to, from, back)
+			}
+		}
+	}
+}
+
+func TestConverter_Convert(t *testing.T) {
+	tests := []struct {
+		c        Converter
+		value    float64
+		from, to Unit
+		want     float64
+		wantErr  bool
+	}{
+		{DefaultConverter, 100, C, F, 212, false},
+		{DefaultConverter, 1, MPA, PSI, 145.098, false},
+		{DefaultConverter, 1, BAR, MmHG, 750.062, false},
+		{DefaultConverter, 100, GS, LbPerMinute, 13.2277, false},
+		{DefaultConverter, 30, MPGUS, LPer100K, 7.8405, false},
+		{DefaultConverter, 1000, US, S, 0.001, false},
+		{DefaultConverter, 1, Lambda, AFR, 14.7, false},
+		{Converter{Stoich: 9.76}, 1, Lambda, AFR, 9.76, false},
+		{Converter{Stoich: 9.76}, 9.76, AFR, Lambda, 1, false},
+		{DefaultConverter, 1, KPA, KPA, 1, false},
+		{DefaultConverter, 1, KPA, C, 0, true},
+		{DefaultConverter, 1, Unit("furlongs"), Miles, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := tt.c.Convert(tt.value, tt.from, tt.to)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("Convert(%v, %s, %s) error = %v, wantErr %v", tt.value, tt.from, tt.to, err, tt.wantErr)
+		}
+		if math.Abs(got-tt.want) > 0.001 {
+			t.Errorf("Convert(%v, %s, %s) = %v, want %v", tt.value, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestConvertibleUnits(t *testing.T) {
+	want := []Unit{KMPerL, LPer100K, MPGUK}
+	if got := ConvertibleUnits(MPGUS); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := ConvertibleUnits(Gear); len(got) != 0 {
+		t.Fatalf("want no conversions for gear. got: %v", got)
+	}
+}
@@ -0,0 +1,85 @@
+package units
+
+import (
+	"fmt"
+	"sort"
+)
+
+// System maps dimensions to the units their values are preferred in, e.g. metric or imperial units.
+type System map[Dimension]Unit
+
+// Metric prefers metric units.
+var Metric = System{
+	Temperature:    C,
+	Pressure:       KPA,
+	PressureRate:   KPAPerSecond,
+	Speed:          KMH,
+	Distance:       Kilometers,
+	FuelEfficiency: LPer100K,
+	Volume:         Liters,
+	MassFlow:       GS,
+	Torque:         Nm,
+}
+
+// Imperial prefers imperial (US) units.
+var Imperial = System{
+	Temperature:    F,
+	Pressure:       PSI,
+	PressureRate:   PSIPerSecond,
+	Speed:          MPH,
+	Distance:       Miles,
+	FuelEfficiency: MPGUS,
+	Volume:         GallonsUS,
+	MassFlow:       LbPerMinute,
+	Torque:         LbFt,
+}
+
+// Preferred returns the unit the system prefers for values in u, or u when the
+// system doesn't have a preference for u's dimension.
+func (s System) Preferred(u Unit) Unit {
+	d, ok := Dimensions[u]
+	if !ok {
+		return u
+	}
+	if p, ok := s[d]; ok && CanConvert(u, p) {
+		return p
+	}
+	return u
+}
+
+// Validate returns an error if a preferred unit isn't a unit of its dimension.
+func (s System) Validate() error {
+	for d, u := range s {
+		if Dimensions[u] != d {
+			return fmt.Errorf("'%s' isn't a unit of %s", u, d)
+		}
+	}
+	return nil
+}
+
+// UnitsOf returns the units of the dimension, sorted.
+func UnitsOf(d Dimension) []Unit {
+	var of []Unit
+	for u, ud := range Dimensions {
+		if ud == d {
+			of = append(of, u)
+		}
+	}
+	sort.Slice(of, func(i, j int) bool { return of[i] < of[j] })
+	return of
+}
+
+// ConvertibleDimensions returns the dimensions with units that can be converted
+// to each other, which are the dimensions a System can have a preference for, sorted.
+func ConvertibleDimensions() []Dimension {
+	seen := map[Dimension]bool{}
+	for u := range graph {
+		seen[Dimensions[u]] = true
+	}
+	dims := make([]Dimension, 0, len(seen))
+	for d := range seen {
+		dims = append(dims, d)
+	}
+	sort.Slice(dims, func(i, j int) bool { return dims[i] < dims[j] })
+	return dims
+}
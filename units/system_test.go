@@ -0,0 +1,35 @@
+package units
+
+import "testing"
+
+func TestSystem_Preferred(t *testing.T) {
+	tests := []struct {
+		system System
+		unit   Unit
+		want   Unit
+	}{
+		{Imperial, C, F},
+		{Imperial, MPA, PSI},
+		{Imperial, KMPerL, MPGUS},
+		{Metric, MPH, KMH},
+		{Metric, RPM, RPM},
+		{nil, PSI, PSI},
+		{Imperial, Unit("krpm"), Unit("krpm")},
+	}
+	for _, tt := range tests {
+		if got := tt.system.Preferred(tt.unit); got != tt.want {
+			t.Errorf("Preferred(%s) = %s, want %s", tt.unit, got, tt.want)
+		}
+	}
+}
+
+func TestSystem_Validate(t *testing.T) {
+	for _, s := range []System{Metric, Imperial} {
+		if err := s.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := (System{Speed: PSI}).Validate(); err == nil {
+		t.Fatal("expected an error for a unit of another dimension")
+	}
+}
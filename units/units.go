@@ -1,6 +1,6 @@
 package units
 
-import "errors"
+import "sort"
 
 // Unit provides common values for units used to describe a parameter's value.
 type Unit string
@@ -16,7 +16,8 @@ const (
 	Kilometers Unit = "km"
 
 	// Rotational Speed
-	RPM Unit = "rpm"
+	RPM          Unit = "rpm"
+	RPMPerSecond Unit = "rpm/s"
 
 	// Timing
 	Degress Unit = "degrees"
@@ -34,8 +35,14 @@ const (
 	InHG Unit = "inHg"
 	MmHG Unit = "mmHg"
 
+	// Pressure Rate of Change
+	KPAPerSecond Unit = "kPa/s"
+	PSIPerSecond Unit = "psi/s"
+
 	// Airflow
-	GS Unit = "g/s"
+	GS          Unit = "g/s"
+	LbPerMinute Unit = "lb/min"
+	KgPerHour   Unit = "kg/h"
 
 	// Fueling
 	AFR               Unit = "AFR"
@@ -43,6 +50,12 @@ const (
 	DegreesCrankAngle Unit = "°CA"
 	MM3PerStroke      Unit = "mm³/st"
 	MGPerCylinder     Unit = "mg/cyl"
+	CCPerMinute       Unit = "cc/min"
+	LPerHour          Unit = "L/h"
+
+	// Volume
+	Liters    Unit = "L"
+	GallonsUS Unit = "gal (US)"
 
 	// Fuel Efficiency
 	MPGUS    Unit = "mpg (US)"
@@ -60,6 +73,7 @@ const (
 	Time Unit = "Time"
 	MS   Unit = "ms"
 	US   Unit = "µs"
+	S    Unit = "s"
 
 	// Misc
 	Percent                Unit = "%"
@@ -77,147 +91,109 @@ const (
 	Grams                  Unit = "g"
 	Coefficient            Unit = "coefficient"
 	Nm                     Unit = "Nm"
+	LbFt                   Unit = "lb-ft"
 )
 
-// ErrorInvalidConversion is returned when an invalid unit conversion attempt is made.
-var ErrorInvalidConversion = errors.New("units are invalid for conversion")
-
-func Convert(value float32, from, to Unit) (float32, error) {
-	cvs := UnitConversions[from]
-	if cvs == nil {
-		return 0, ErrorInvalidConversion
-	}
+// Dimension is the quantity a unit measures. Units can only be converted to units of the same dimension.
+type Dimension string
 
-	cv := cvs[to]
-	if cv == nil {
-		return 0, ErrorInvalidConversion
-	}
+// The dimensions of the package-defined Units.
+const (
+	Speed                  Dimension = "speed"
+	Distance               Dimension = "distance"
+	RotationalSpeed        Dimension = "rotational speed"
+	RotationalAcceleration Dimension = "rotational acceleration"
+	Angle                  Dimension = "angle"
+	AngularVelocity        Dimension = "angular velocity"
+	Acceleration           Dimension = "acceleration"
+	Temperature            Dimension = "temperature"
+	Pressure               Dimension = "pressure"
+	PressureRate           Dimension = "pressure rate of change"
+	MassFlow               Dimension = "mass flow"
+	MixtureRatio           Dimension = "mixture ratio"
+	InjectionQuantity      Dimension = "injection quantity"
+	CylinderCharge         Dimension = "cylinder charge"
+	VolumeFlow             Dimension = "volume flow"
+	Volume                 Dimension = "volume"
+	FuelEfficiency         Dimension = "fuel efficiency"
+	Voltage                Dimension = "voltage"
+	Current                Dimension = "current"
+	Resistance             Dimension = "resistance"
+	Duration               Dimension = "duration"
+	Mass                   Dimension = "mass"
+	Torque                 Dimension = "torque"
+	// Dimensionless units are ratios, counts, and ECU values that can't be converted.
+	Dimensionless Dimension = "dimensionless"
+)
 
-	return cv(value), nil
+// Dimensions are the dimensions of the package-defined Units.
+var Dimensions = map[Unit]Dimension{
+	MPH:                    Speed,
+	KMH:                    Speed,
+	Miles:                  Distance,
+	Kilometers:             Distance,
+	RPM:                    RotationalSpeed,
+	RPMPerSecond:           RotationalAcceleration,
+	Degress:                Angle,
+	DegreesCrankAngle:      Angle,
+	DegreesPerSecond:       AngularVelocity,
+	MetersPerSecondSquared: Acceleration,
+	F:                      Temperature,
+	C:                      Temperature,
+	PSI:                    Pressure,
+	BAR:                    Pressure,
+	KPA:                    Pressure,
+	HPA:                    Pressure,
+	MPA:                    Pressure,
+	InHG:                   Pressure,
+	MmHG:                   Pressure,
+	KPAPerSecond:           PressureRate,
+	PSIPerSecond:           PressureRate,
+	GS:                     MassFlow,
+	LbPerMinute:            MassFlow,
+	KgPerHour:              MassFlow,
+	AFR:                    MixtureRatio,
+	Lambda:                 MixtureRatio,
+	MM3PerStroke:           InjectionQuantity,
+	MGPerCylinder:          CylinderCharge,
+	GramsPerRev:            CylinderCharge,
+	CCPerMinute:            VolumeFlow,
+	LPerHour:               VolumeFlow,
+	Liters:                 Volume,
+	GallonsUS:              Volume,
+	MPGUS:                  FuelEfficiency,
+	MPGUK:                  FuelEfficiency,
+	KMPerL:                 FuelEfficiency,
+	LPer100K:               FuelEfficiency,
+	Volts:                  Voltage,
+	Amps:                   Current,
+	Milliamps:              Current,
+	Ohms:                   Resistance,
+	MS:                     Duration,
+	US:                     Duration,
+	S:                      Duration,
+	Grams:                  Mass,
+	Nm:                     Torque,
+	LbFt:                   Torque,
+	Time:                   Dimensionless,
+	Times:                  Dimensionless,
+	Percent:                Dimensionless,
+	Steps:                  Dimensionless,
+	Gear:                   Dimensionless,
+	Count:                  Dimensionless,
+	MisfireCount:           Dimensionless,
+	Multiplier:             Dimensionless,
+	Index:                  Dimensionless,
+	Raw:                    Dimensionless,
+	Coefficient:            Dimensionless,
 }
 
-// UnitConversions provides conversion functions for the package-defined Units.
-var UnitConversions = map[Unit]map[Unit]func(v float32) float32{
-	MPH: {
-		KMH: func(v float32) float32 {
-			return v * 1.60934
-		},
-	},
-	KMH: {
-		MPH: func(v float32) float32 {
-			return v * 0.621371
-		},
-	},
-	F: {
-		C: func(v float32) float32 {
-			return (v - 32) / 9 * 5
-		},
-	},
-	C: {
-		F: func(v float32) float32 {
-			return (v / 5 * 9) + 32
-		},
-	},
-	KPA: {
-		PSI: func(v float32) float32 {
-			return v * 37 / 255
-		},
-		BAR: func(v float32) float32 {
-			return v / 100
-		},
-		HPA: func(v float32) float32 {
-			return v * 10
-		},
-		InHG: func(v float32) float32 {
-			return v * 0.2953
-		},
-		MmHG: func(v float32) float32 {
-			return v * 7.5
-		},
-	},
-	PSI: {
-		KPA: func(v float32) float32 {
-			return v * 255 / 37
-		},
-		BAR: func(v float32) float32 {
-			return v * 0.0689475729
-		},
-		HPA: func(v float32) float32 {
-			return v * 2550 / 37
-		},
-		InHG: func(v float32) float32 {
-			return v * 2.03602
-		},
-		MmHG: func(v float32) float32 {
-			return v * 51.7149
-		},
-	},
-	BAR: {
-		PSI: func(v float32) float32 {
-			return v * 14.5038
-		},
-		KPA: func(v float32) float32 {
-			return v * 100
-		},
-		HPA: func(v float32) float32 {
-			return v * 1000
-		},
-		InHG: func(v float32) float32 {
-			return v * 29.53
-		},
-		MmHG: func(v float32) float32 {
-			return v * 750.062
-		},
-	},
-	HPA: {
-		PSI: func(v float32) float32 {
-			return v * 0.0145038
-		},
-		BAR: func(v float32) float32 {
-			return v / 1000
-		},
-		KPA: func(v float32) float32 {
-			return v / 10
-		},
-		InHG: func(v float32) float32 {
-			return v * 0.029529983071445
-		},
-		MmHG: func(v float32) float32 {
-			return v * 0.75006157584566
-		},
-	},
-	InHG: {
-		PSI: func(v float32) float32 {
-			return v * 0.491154
-		},
-		BAR: func(v float32) float32 {
-			return v * 0.0338639
-		},
-		KPA: func(v float32) float32 {
-			return v * 3.3863886666667
-		},
-		HPA: func(v float32) float32 {
-			return v * 33.863886666667
-		},
-		MmHG: func(v float32) float32 {
-			return v * 25.4
-		},
-	},
-	MmHG: {
-		PSI: func(v float32) float32 {
-			return v * 0.0193368
-		},
-		BAR: func(v float32) float32 {
-			return v * 0.00133322
-		},
-		KPA: func(v float32) float32 {
-			return v * 0.13332239
-		},
-		HPA: func(v float32) float32 {
-			return v * 1.3332239
-		},
-		InHG: func(v float32) float32 {
-			return v * 0.0393701
-		},
-	},
+// AllUnits returns the package-defined Units, sorted.
+func AllUnits() []Unit {
+	all := make([]Unit, 0, len(Dimensions))
+	for u := range Dimensions {
+		all = append(all, u)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+	return all
 }